@@ -3,6 +3,7 @@ package cmd
 import (
 	// Packages
 	manager "github.com/mutablelogic/go-media/gomedia/manager"
+	ffmpeg "github.com/mutablelogic/go-media/pkg/ffmpeg"
 	server "github.com/mutablelogic/go-server"
 
 	// Imports
@@ -19,6 +20,8 @@ type CLICommands struct {
 	MetadataCLICommands
 	CapabilitiesCLICommands
 	EncodingCLICommands
+	SubtitlesCLICommands
+	RawCLICommands
 }
 
 type BaseCmd struct {
@@ -47,6 +50,9 @@ func (runner *BaseCmd) WithManager(ctx server.Cmd, fn func(*manager.Media) error
 		return err
 	}
 
+	// Send FFmpeg log messages to the logger
+	ffmpeg.SetLogHandler(ctx.Logger().Handler())
+
 	// Set basic mamager options
 	opts := []manager.Opt{
 		manager.WithTracer(ctx.Tracer()),
@@ -1,12 +1,15 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
 	// Packages
+	gomedia "github.com/mutablelogic/go-media"
 	manager "github.com/mutablelogic/go-media/gomedia/manager"
 	schema "github.com/mutablelogic/go-media/gomedia/schema"
 	server "github.com/mutablelogic/go-server"
@@ -40,6 +43,7 @@ func (c *ProbeCmd) Run(ctx server.Cmd) error {
 			Reader:      r,
 			InputFormat: c.InputFormat,
 			InputOpts:   c.InputOpts,
+			Captions:    c.Captions,
 		})
 		if err != nil {
 			return err
@@ -58,6 +62,21 @@ func (c *ProbeCmd) Run(ctx server.Cmd) error {
 		if len(resp.MimeTypes) > 0 {
 			fmt.Printf("MIME Types: %s\n", strings.Join(resp.MimeTypes, ", "))
 		}
+		if c.Captions {
+			if len(resp.Captions) == 0 {
+				fmt.Println("Captions: none")
+			}
+			for _, captions := range resp.Captions {
+				var services []string
+				if captions.CEA608 {
+					services = append(services, "CEA-608")
+				}
+				if captions.CEA708 {
+					services = append(services, "CEA-708")
+				}
+				fmt.Printf("Captions: stream %d (%s)\n", captions.Stream, strings.Join(services, ", "))
+			}
+		}
 
 		rows := make([]schema.Stream, 0, len(resp.Streams))
 		for _, s := range resp.Streams {
@@ -73,6 +92,21 @@ func (c *ProbeCmd) Run(ctx server.Cmd) error {
 				return err
 			}
 		}
+
+		chapters := make([]schema.Chapter, 0, len(resp.Chapters))
+		for _, c := range resp.Chapters {
+			if c == nil {
+				continue
+			}
+			chapters = append(chapters, *c)
+		}
+
+		if len(chapters) > 0 {
+			table := tui.TableFor[schema.Chapter](tui.SetWidth(termwidth))
+			if _, err := table.Write(os.Stdout, chapters...); err != nil {
+				return err
+			}
+		}
 		return nil
 	})
 }
@@ -124,6 +158,8 @@ type SampleFormatsCmd struct {
 
 type EncodingCLICommands struct {
 	AudioSegment AudioSegmentCmd `cmd:"" name:"audio-segment" help:"Segment audio and log segments." group:"ENCODING"`
+	Trim         TrimCmd         `cmd:"" name:"trim" help:"Cut part of a media file without re-encoding." group:"ENCODING"`
+	Concat       ConcatCmd       `cmd:"" name:"concat" help:"Join media files one after another, with a chapter for each." group:"ENCODING"`
 }
 
 type AudioSegmentCmd struct {
@@ -136,6 +172,20 @@ type AudioSegmentCmd struct {
 	SilenceThreshold float64       `flag:"" name:"silence-threshold" help:"Silence threshold as RMS energy (0.0-1.0). Also enables silence splitting. 0 uses auto threshold (0.005)." default:"0"`
 }
 
+type TrimCmd struct {
+	BaseCmd
+	File string `arg:"" name:"file" type:"existingfile" help:"File to trim."`
+	Out  string `flag:"" name:"out" help:"Output file, which sets the output format." required:"" type:"path"`
+	schema.TrimRequest
+}
+
+type ConcatCmd struct {
+	BaseCmd
+	Files []string `arg:"" name:"file" type:"existingfile" help:"Files to join, in order."`
+	Out   string   `flag:"" name:"out" help:"Output file, which sets the output format." required:"" type:"path"`
+	schema.ConcatRequest
+}
+
 func (c *AudioChannelsCmd) Run(ctx server.Cmd) error {
 	json, termwidth := c.IsJSONOutput(ctx)
 	return c.WithManager(ctx, func(manager *manager.Media) error {
@@ -292,3 +342,103 @@ func (c *AudioSegmentCmd) Run(ctx server.Cmd) error {
 		})
 	})
 }
+
+func (c *TrimCmd) Run(ctx server.Cmd) error {
+	json, _ := c.IsJSONOutput(ctx)
+	if filepath.Clean(c.Out) == filepath.Clean(c.File) {
+		return gomedia.ErrBadParameter.With("output file is the same as the input file")
+	}
+	return c.WithManager(ctx, func(manager *manager.Media) error {
+		r, err := os.Open(c.File)
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+
+		w, err := os.Create(c.Out)
+		if err != nil {
+			return err
+		}
+
+		// Trim, and remove the output on error
+		req := c.TrimRequest
+		req.Reader = r
+		if req.Format == "" {
+			req.Format = c.Out
+		}
+		resp, err := manager.Trim(ctx.Context(), w, req)
+		if err := errors.Join(err, w.Close()); err != nil {
+			os.Remove(c.Out)
+			return err
+		}
+
+		if json {
+			fmt.Println(resp)
+			return nil
+		}
+		fmt.Printf("Trimmed %s from %v to %v into %s\n", c.File, resp.Start, resp.End, c.Out)
+		if resp.Encoded > 0 {
+			fmt.Printf("Re-encoded %d video frames at the cut points\n", resp.Encoded)
+		}
+		return nil
+	})
+}
+
+func (c *ConcatCmd) Run(ctx server.Cmd) error {
+	json, termwidth := c.IsJSONOutput(ctx)
+	for _, file := range c.Files {
+		if filepath.Clean(c.Out) == filepath.Clean(file) {
+			return gomedia.ErrBadParameter.With("output file is the same as an input file")
+		}
+	}
+	return c.WithManager(ctx, func(manager *manager.Media) error {
+		req := c.ConcatRequest
+		for _, file := range c.Files {
+			r, err := os.Open(file)
+			if err != nil {
+				return err
+			}
+			defer r.Close()
+			req.Readers = append(req.Readers, r)
+		}
+
+		w, err := os.Create(c.Out)
+		if err != nil {
+			return err
+		}
+
+		// Join, and remove the output on error
+		if req.Format == "" {
+			req.Format = c.Out
+		}
+		resp, err := manager.Concat(ctx.Context(), w, req)
+		if err := errors.Join(err, w.Close()); err != nil {
+			os.Remove(c.Out)
+			return err
+		}
+
+		if json {
+			fmt.Println(resp)
+			return nil
+		}
+		if resp.Copy {
+			fmt.Printf("Joined %d files into %s without re-encoding, duration %v\n", len(c.Files), c.Out, resp.Duration)
+		} else {
+			fmt.Printf("Joined %d files into %s, re-encoding %d frames, duration %v\n", len(c.Files), c.Out, resp.Frames, resp.Duration)
+		}
+
+		chapters := make([]schema.Chapter, 0, len(resp.Chapters))
+		for _, c := range resp.Chapters {
+			if c != nil {
+				chapters = append(chapters, *c)
+			}
+		}
+		if len(chapters) > 0 {
+			table := tui.TableFor[schema.Chapter](tui.SetWidth(termwidth))
+			if _, err := table.Write(os.Stdout, chapters...); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
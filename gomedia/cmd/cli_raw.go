@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	// Packages
+	gomedia "github.com/mutablelogic/go-media"
+	manager "github.com/mutablelogic/go-media/gomedia/manager"
+	schema "github.com/mutablelogic/go-media/gomedia/schema"
+	server "github.com/mutablelogic/go-server"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type RawCLICommands struct {
+	Raw RawCmd `cmd:"" name:"raw" help:"Develop and export RAW camera files." group:"RAW"`
+}
+
+type RawCmd struct {
+	Develop RawDevelopCmd `cmd:"" name:"develop" help:"Develop RAW files into proof images."`
+	Export  RawExportCmd  `cmd:"" name:"export" help:"Export RAW files as 16-bit TIFF or linear DNG for archiving."`
+}
+
+type RawDevelopCmd struct {
+	BaseCmd
+	Path      string   `arg:"" name:"path" type:"path" help:"RAW file or directory of RAW files to develop." default:"."`
+	Out       string   `flag:"" name:"out" help:"Output template for developed images." required:""`
+	Recursive bool     `flag:"" name:"recursive" short:"r" help:"Recursively develop files in a directory." negatable:""`
+	Exclude   []string `flag:"" name:"exclude" help:"Exclude files with these extensions (e.g. .jpg, .xmp)."`
+	schema.RAWDevelopRequest
+}
+
+type RawExportCmd struct {
+	BaseCmd
+	Path      string   `arg:"" name:"path" type:"path" help:"RAW file or directory of RAW files to export." default:"."`
+	Out       string   `flag:"" name:"out" help:"Output template for exported files." required:""`
+	Recursive bool     `flag:"" name:"recursive" short:"r" help:"Recursively export files in a directory." negatable:""`
+	Exclude   []string `flag:"" name:"exclude" help:"Exclude files with these extensions (e.g. .jpg, .xmp)."`
+	schema.RAWExportRequest
+}
+
+// rawFn writes a single RAW file read from r into w
+type rawFn func(ctx context.Context, w io.Writer, r io.Reader) error
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+func (c *RawDevelopCmd) Run(ctx server.Cmd) error {
+	return c.WithManager(ctx, func(manager *manager.Media) error {
+		return walkRAW(ctx, c.Path, c.Out, c.Recursive, c.Exclude, developExt(c.Format), "Developing", func(ctx context.Context, w io.Writer, r io.Reader) error {
+			req := c.RAWDevelopRequest
+			req.Reader = r
+			return manager.DevelopRAW(ctx, w, req)
+		})
+	})
+}
+
+func (c *RawExportCmd) Run(ctx server.Cmd) error {
+	ext := "dng"
+	if c.Format == "tiff" {
+		ext = "tif"
+	}
+	return c.WithManager(ctx, func(manager *manager.Media) error {
+		return walkRAW(ctx, c.Path, c.Out, c.Recursive, c.Exclude, ext, "Exporting", func(ctx context.Context, w io.Writer, r io.Reader) error {
+			req := c.RAWExportRequest
+			req.Reader = r
+			return manager.ExportRAW(ctx, w, req)
+		})
+	})
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+func developExt(format string) string {
+	switch format {
+	case "png":
+		return "png"
+	case "tiff":
+		return "tif"
+	default:
+		return "jpg"
+	}
+}
+
+// walkRAW calls fn for each regular file under path, writing to the file
+// named by the output template. Files which turn out not to be RAW files
+// are skipped, and the partially written output removed.
+func walkRAW(ctx server.Cmd, path, out string, recursive bool, exclude []string, ext, verb string, fn rawFn) error {
+	log := ctx.Logger()
+
+	// Gather FS walking options
+	opts := []WalkOpt{WithTemplate(out)}
+	if recursive {
+		opts = append(opts, WithRecursive())
+	}
+	if len(exclude) > 0 {
+		opts = append(opts, WithExcludeExt(exclude...))
+	}
+
+	return WalkFS(ctx.Context(), path, func(ctx context.Context, fullPath string, relPath string, entry fs.DirEntry, tmpl *Templater) error {
+		// Skip directories, but allow the walk to continue into them
+		if entry.IsDir() {
+			return nil
+		}
+
+		// Only open regular files
+		if !entry.Type().IsRegular() {
+			log.WarnContext(ctx, "Skipping non-regular file", "path", relPath)
+			return nil
+		}
+
+		// Open the file
+		r, err := os.Open(fullPath)
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+
+		// Write the output file, removing it again if the file turns out
+		// not to be a RAW file
+		err = tmpl.Create(map[string]any{
+			"path": relPath,
+			"name": entry.Name(),
+			"ext":  "." + ext,
+		}, func(w io.Writer) error {
+			if w, ok := w.(gomedia.NamedWriter); ok {
+				log.InfoContext(ctx, verb+" "+filepath.Base(w.Name()), "path", relPath)
+			}
+			return fn(ctx, w, r)
+		})
+		if errors.Is(err, gomedia.ErrNotImplemented) {
+			log.WarnContext(ctx, "Skipping unsupported file", "path", relPath, "error", err.Error())
+			return nil
+		}
+		return err
+	}, opts...)
+}
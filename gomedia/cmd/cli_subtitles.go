@@ -0,0 +1,289 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	// Packages
+	gomedia "github.com/mutablelogic/go-media"
+	manager "github.com/mutablelogic/go-media/gomedia/manager"
+	schema "github.com/mutablelogic/go-media/gomedia/schema"
+	server "github.com/mutablelogic/go-server"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type SubtitlesCLICommands struct {
+	Subtitles SubtitlesCmd `cmd:"" name:"subtitles" help:"Extract, add or burn in subtitles and closed captions." group:"SUBTITLES"`
+}
+
+type SubtitlesCmd struct {
+	Extract  SubtitlesExtractCmd  `cmd:"" name:"extract" help:"Extract subtitle streams as SRT, WebVTT or ASS files."`
+	Captions SubtitlesCaptionsCmd `cmd:"" name:"captions" help:"Extract CEA-608 closed captions from the video as an SRT or WebVTT file."`
+	Add      SubtitlesAddCmd      `cmd:"" name:"add" help:"Add SRT, WebVTT or ASS files as subtitle streams, without re-encoding."`
+	Burn     SubtitlesBurnCmd     `cmd:"" name:"burn" help:"Draw subtitles on the video, re-encoding it."`
+}
+
+type SubtitlesExtractCmd struct {
+	BaseCmd
+	File string `arg:"" name:"file" type:"existingfile" help:"File to extract subtitles from."`
+	Out  string `flag:"" name:"out" help:"Output directory for the subtitle files." default:"." type:"path"`
+	schema.ExtractSubtitlesRequest
+}
+
+type SubtitlesCaptionsCmd struct {
+	BaseCmd
+	File string `arg:"" name:"file" type:"existingfile" help:"File to extract closed captions from."`
+	Out  string `flag:"" name:"out" help:"Output directory for the subtitle file." default:"." type:"path"`
+	schema.ExtractCaptionsRequest
+}
+
+type SubtitlesAddCmd struct {
+	BaseCmd
+	File      string   `arg:"" name:"file" type:"existingfile" help:"File to add subtitles to."`
+	Subtitles []string `arg:"" name:"subtitles" type:"existingfile" help:"Subtitle files, with the language and forced or sdh in the name (movie.en.forced.srt)."`
+	Out       string   `flag:"" name:"out" help:"Output file, which sets the output format." required:"" type:"path"`
+	Default   string   `flag:"" name:"default" help:"Language of the subtitle stream shown by default."`
+	schema.AddSubtitlesRequest
+}
+
+type SubtitlesBurnCmd struct {
+	BaseCmd
+	File      string `arg:"" name:"file" type:"existingfile" help:"File to draw subtitles on."`
+	Subtitles string `arg:"" name:"subtitles" type:"existingfile" help:"Subtitle file, or media file with subtitle streams."`
+	Out       string `flag:"" name:"out" help:"Output file, which sets the output format." required:"" type:"path"`
+	schema.BurnSubtitlesRequest
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+func (c *SubtitlesExtractCmd) Run(ctx server.Cmd) error {
+	json, _ := c.IsJSONOutput(ctx)
+	return c.WithManager(ctx, func(manager *manager.Media) error {
+		r, err := os.Open(c.File)
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+
+		req := c.ExtractSubtitlesRequest
+		req.Reader = r
+		resp, err := manager.ExtractSubtitles(ctx.Context(), req)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(c.Out, 0755); err != nil {
+			return err
+		}
+
+		// Write a file for each stream, named after the input, and add the
+		// stream index when streams have the same language
+		base := strings.TrimSuffix(filepath.Base(c.File), filepath.Ext(c.File))
+		written := make(map[string]bool)
+		for _, track := range resp.Tracks {
+			if track.Bitmap {
+				fmt.Fprintf(os.Stderr, "Skipping stream %d: %s subtitles are images\n", track.Stream, track.Codec)
+				continue
+			}
+			path := filepath.Join(c.Out, track.Filename(base, c.Format))
+			if written[path] {
+				path = filepath.Join(c.Out, track.Filename(base+"."+strconv.Itoa(track.Stream), c.Format))
+			}
+			written[path] = true
+
+			w, err := os.Create(path)
+			if err != nil {
+				return err
+			}
+			if err := errors.Join(track.Write(w, c.Format), w.Close()); err != nil {
+				os.Remove(path)
+				return err
+			}
+			if !json {
+				fmt.Printf("Wrote %d subtitles from stream %d to %s\n", len(track.Subtitles), track.Stream, path)
+			}
+		}
+
+		if json {
+			fmt.Println(resp)
+		}
+		return nil
+	})
+}
+
+func (c *SubtitlesCaptionsCmd) Run(ctx server.Cmd) error {
+	json, _ := c.IsJSONOutput(ctx)
+	return c.WithManager(ctx, func(manager *manager.Media) error {
+		r, err := os.Open(c.File)
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+
+		req := c.ExtractCaptionsRequest
+		req.Reader = r
+		resp, err := manager.ExtractCaptions(ctx.Context(), req)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(c.Out, 0755); err != nil {
+			return err
+		}
+
+		// Write the captions, named after the input and the channel
+		base := strings.TrimSuffix(filepath.Base(c.File), filepath.Ext(c.File))
+		path := filepath.Join(c.Out, resp.Track.Filename(base+"."+strings.ToLower(resp.Track.Title), c.Format))
+		w, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		if err := errors.Join(resp.Track.Write(w, c.Format), w.Close()); err != nil {
+			os.Remove(path)
+			return err
+		}
+
+		if json {
+			fmt.Println(resp)
+			return nil
+		}
+		if !resp.CEA608 {
+			fmt.Fprintf(os.Stderr, "Stream %d has CEA-708 captions only, which are not decoded\n", resp.Stream)
+		}
+		fmt.Printf("Wrote %d captions from stream %d to %s\n", len(resp.Track.Subtitles), resp.Stream, path)
+		return nil
+	})
+}
+
+func (c *SubtitlesAddCmd) Run(ctx server.Cmd) error {
+	json, _ := c.IsJSONOutput(ctx)
+	for _, file := range append([]string{c.File}, c.Subtitles...) {
+		if filepath.Clean(c.Out) == filepath.Clean(file) {
+			return gomedia.ErrBadParameter.With("output file is the same as an input file")
+		}
+	}
+	return c.WithManager(ctx, func(manager *manager.Media) error {
+		r, err := os.Open(c.File)
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+
+		req := c.AddSubtitlesRequest
+		req.Reader = r
+		for _, file := range c.Subtitles {
+			r, err := os.Open(file)
+			if err != nil {
+				return err
+			}
+			defer r.Close()
+			input := subtitleInput(file)
+			input.Reader = r
+			if c.Default != "" {
+				input.Default = strings.EqualFold(input.Language, c.Default)
+			}
+			req.Subtitles = append(req.Subtitles, input)
+		}
+
+		w, err := os.Create(c.Out)
+		if err != nil {
+			return err
+		}
+
+		// Add the subtitles, and remove the output on error
+		if req.Format == "" {
+			req.Format = c.Out
+		}
+		resp, err := manager.AddSubtitles(ctx.Context(), w, req)
+		if err := errors.Join(err, w.Close()); err != nil {
+			os.Remove(c.Out)
+			return err
+		}
+
+		if json {
+			fmt.Println(resp)
+			return nil
+		}
+		for _, track := range resp.Tracks {
+			fmt.Printf("Added %s subtitles as stream %d, language %q\n", track.Codec, track.Stream, track.Language)
+		}
+		fmt.Printf("Wrote %d subtitles and %d packets to %s\n", resp.Subtitles, resp.Packets, c.Out)
+		return nil
+	})
+}
+
+func (c *SubtitlesBurnCmd) Run(ctx server.Cmd) error {
+	json, _ := c.IsJSONOutput(ctx)
+	for _, file := range []string{c.File, c.Subtitles} {
+		if filepath.Clean(c.Out) == filepath.Clean(file) {
+			return gomedia.ErrBadParameter.With("output file is the same as an input file")
+		}
+	}
+	return c.WithManager(ctx, func(manager *manager.Media) error {
+		r, err := os.Open(c.File)
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+		subtitles, err := os.Open(c.Subtitles)
+		if err != nil {
+			return err
+		}
+		defer subtitles.Close()
+
+		w, err := os.Create(c.Out)
+		if err != nil {
+			return err
+		}
+
+		// Burn in, and remove the output on error
+		req := c.BurnSubtitlesRequest
+		req.Reader = r
+		req.Subtitles = subtitles
+		if req.Format == "" {
+			req.Format = c.Out
+		}
+		resp, err := manager.BurnSubtitles(ctx.Context(), w, req)
+		if err := errors.Join(err, w.Close()); err != nil {
+			os.Remove(c.Out)
+			return err
+		}
+
+		if json {
+			fmt.Println(resp)
+			return nil
+		}
+		fmt.Printf("Burned %d subtitles into %s, encoding %d frames, duration %v\n", resp.Subtitles, c.Out, resp.Frames, resp.Duration)
+		return nil
+	})
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// Return the language and dispositions of a subtitle file from the parts of
+// its name, so movie.en.forced.srt is forced English subtitles
+func subtitleInput(file string) *schema.SubtitleInput {
+	input := new(schema.SubtitleInput)
+	parts := strings.Split(strings.TrimSuffix(filepath.Base(file), filepath.Ext(file)), ".")
+	for _, part := range parts[1:] {
+		switch strings.ToLower(part) {
+		case "forced":
+			input.Forced = true
+		case "sdh", "cc", "hi":
+			input.HearingImpaired = true
+		case "default":
+			input.Default = true
+		default:
+			if input.Language == "" && len(part) >= 2 && len(part) <= 3 {
+				input.Language = part
+			}
+		}
+	}
+	return input
+}
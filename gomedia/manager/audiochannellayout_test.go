@@ -6,7 +6,7 @@ import (
 	// Packages
 	schema "github.com/mutablelogic/go-media/gomedia/schema"
 	test "github.com/mutablelogic/go-media/gomedia/test"
-	ff "github.com/mutablelogic/go-media/sys/ffmpeg80"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
 )
 
 func TestListAudioChannelLayouts_All(t *testing.T) {
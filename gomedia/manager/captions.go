@@ -0,0 +1,208 @@
+package manager
+
+import (
+	"context"
+	"io"
+	"time"
+
+	// Packages
+	otel "github.com/mutablelogic/go-client/pkg/otel"
+	gomedia "github.com/mutablelogic/go-media"
+	schema "github.com/mutablelogic/go-media/gomedia/schema"
+	metadata "github.com/mutablelogic/go-media/metadata"
+	ffmpeg "github.com/mutablelogic/go-media/pkg/ffmpeg"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
+	attribute "go.opentelemetry.io/otel/attribute"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+const (
+	// Probe decodes this much of each video stream to detect captions
+	probeCaptionsDuration = 10 * time.Second
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// ExtractCaptions decodes the video of the input, and decodes the CEA-608
+// closed captions in the ATSC A53 side data of the frames, which is how
+// captions are carried in broadcast and many MP4 files. The captions of one
+// field are returned as a subtitle track, timed from the start of the input,
+// which can be written as SRT or WebVTT with SubtitleTrack.Write.
+//
+// The response reports whether the stream has CEA-608 and CEA-708 data, but
+// CEA-708 services are not decoded. Returns ErrNotFound when the stream has
+// no captions.
+func (m *Media) ExtractCaptions(ctx context.Context, req schema.ExtractCaptionsRequest) (_ *schema.ExtractCaptionsResponse, err error) {
+	name := "reader"
+	if named, ok := req.Reader.(metadata.NamedStream); ok {
+		name = named.Name()
+	}
+
+	ctx, endSpan := otel.StartSpan(m.tracer, ctx, "ExtractCaptions",
+		attribute.String("input", name),
+		attribute.IntSlice("streams", req.Streams),
+		attribute.Int("field", req.Field),
+	)
+	defer func() { endSpan(err) }()
+
+	if req.Reader == nil {
+		return nil, gomedia.ErrBadParameter.With("nil reader")
+	}
+	if len(req.Streams) > 1 {
+		return nil, gomedia.ErrBadParameter.With("captions are extracted from one video stream")
+	}
+	field := req.Field
+	if field == 0 {
+		field = 1
+	}
+
+	// Open the input, and find the video stream
+	reader, err := ffmpeg.NewReader(req.Reader, ffmpeg.OptContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	stream := reader.BestStream(gomedia.VIDEO)
+	if len(req.Streams) > 0 {
+		stream = req.Streams[0]
+	}
+	if stream < 0 && len(req.Streams) == 0 {
+		return nil, gomedia.ErrNotFound.With("no video stream")
+	}
+	if stream < 0 || stream >= len(reader.AVStreams()) || reader.AVStreams()[stream].CodecPar().CodecType() != ff.AVMEDIA_TYPE_VIDEO {
+		return nil, gomedia.ErrNotFound.Withf("video stream %d", stream)
+	}
+
+	// Decode captions with the timestamps of the video, from the start of
+	// the input
+	tb := reader.AVStreams()[stream].TimeBase()
+	start := durationToTs(reader.StartTime(), tb)
+	dec, err := ffmpeg.NewCaptionDecoder(field, tb)
+	if err != nil {
+		return nil, err
+	}
+	defer dec.Close()
+
+	resp := &schema.ExtractCaptionsResponse{
+		Captions: schema.Captions{Stream: stream},
+		Track: &schema.SubtitleTrack{
+			Stream: stream,
+			Codec:  ff.AV_CODEC_ID_EIA_608.Name(),
+			Title:  "CC1",
+		},
+	}
+	if field == 2 {
+		resp.Track.Title = "CC3"
+	}
+	captionfn := func(caption *ffmpeg.Subtitle) error {
+		resp.Track.Subtitles = append(resp.Track.Subtitles, caption)
+		return nil
+	}
+
+	// Decode the video, and the captions of each frame
+	end := int64(ff.AV_NOPTS_VALUE)
+	mapfn := func(i int, par *ffmpeg.Par) (*ffmpeg.Par, error) {
+		if i == stream {
+			return par, nil
+		}
+		return nil, nil
+	}
+	framefn := func(i int, frame *ffmpeg.Frame) error {
+		if i != stream {
+			return nil
+		}
+		resp.Frames++
+		pts := frame.Pts()
+		if pts == int64(ff.AV_NOPTS_VALUE) {
+			pts = (*ff.AVFrame)(frame).BestEffortTimestamp()
+		}
+		sd := frame.SideData()
+		if pts == int64(ff.AV_NOPTS_VALUE) || sd == nil || len(sd.Captions) == 0 {
+			return nil
+		}
+		pts -= start
+		end = max(end, pts)
+		cea608, cea708 := sd.CaptionServices()
+		resp.CEA608 = resp.CEA608 || cea608
+		resp.CEA708 = resp.CEA708 || cea708
+		return dec.Decode(pts, sd.Captions, captionfn)
+	}
+	if err := reader.Demux(ctx, mapfn, framefn, nil); err != nil {
+		return nil, err
+	}
+	if !resp.CEA608 && !resp.CEA708 {
+		return nil, gomedia.ErrNotFound.Withf("no closed captions in stream %d", stream)
+	}
+
+	// End the caption which is shown at the end of the stream
+	if end != int64(ff.AV_NOPTS_VALUE) {
+		if err := dec.Decode(end, nil, captionfn); err != nil {
+			return nil, err
+		}
+	}
+
+	// Return the captions
+	return resp, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// Decode the start of each video stream, and return the streams which have
+// closed captions in the side data of their frames
+func probeCaptions(ctx context.Context, reader *ffmpeg.Reader) ([]schema.Captions, error) {
+	captions := make(map[int]*schema.Captions)
+	for _, stream := range reader.AVStreams() {
+		if stream.CodecPar().CodecType() == ff.AVMEDIA_TYPE_VIDEO && !stream.Disposition().Is(ff.AV_DISPOSITION_ATTACHED_PIC) {
+			captions[stream.Index()] = &schema.Captions{Stream: stream.Index()}
+		}
+	}
+	if len(captions) == 0 {
+		return nil, nil
+	}
+
+	// Stop when each stream has been decoded for long enough
+	done := make(map[int]bool, len(captions))
+	start := reader.StartTime()
+	mapfn := func(stream int, par *ffmpeg.Par) (*ffmpeg.Par, error) {
+		if _, exists := captions[stream]; exists {
+			return par, nil
+		}
+		return nil, nil
+	}
+	framefn := func(stream int, frame *ffmpeg.Frame) error {
+		c, exists := captions[stream]
+		if !exists {
+			return nil
+		}
+		if sd := frame.SideData(); sd != nil {
+			cea608, cea708 := sd.CaptionServices()
+			c.CEA608 = c.CEA608 || cea608
+			c.CEA708 = c.CEA708 || cea708
+		}
+		if pts := frame.Pts(); pts != int64(ff.AV_NOPTS_VALUE) {
+			if tsToDuration(pts, reader.AVStreams()[stream].TimeBase())-start >= probeCaptionsDuration {
+				done[stream] = true
+			}
+		}
+		if len(done) == len(captions) {
+			return io.EOF
+		}
+		return nil
+	}
+	if err := reader.Demux(ctx, mapfn, framefn, nil); err != nil {
+		return nil, err
+	}
+
+	// Return the streams with captions, in order
+	var result []schema.Captions
+	for _, stream := range reader.AVStreams() {
+		if c, exists := captions[stream.Index()]; exists && (c.CEA608 || c.CEA708) {
+			result = append(result, *c)
+		}
+	}
+	return result, nil
+}
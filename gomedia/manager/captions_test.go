@@ -0,0 +1,149 @@
+package manager_test
+
+import (
+	"errors"
+	"math/bits"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	// Packages
+	gomedia "github.com/mutablelogic/go-media"
+	schema "github.com/mutablelogic/go-media/gomedia/schema"
+	test "github.com/mutablelogic/go-media/gomedia/test"
+	ffmpeg "github.com/mutablelogic/go-media/pkg/ffmpeg"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
+)
+
+func TestExtractCaptions_NoCaptions(t *testing.T) {
+	m, ctx := test.Begin(t)
+
+	f, err := os.Open(filepath.Join("..", "..", "etc", "test", "sample.mp4"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if _, err := m.ExtractCaptions(ctx, schema.ExtractCaptionsRequest{Reader: f}); !errors.Is(err, gomedia.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestExtractCaptions_StartTime(t *testing.T) {
+	m, ctx := test.Begin(t)
+
+	f, err := os.Open(writeCaptions(t, filepath.Join(t.TempDir(), "captions.ts")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	// The caption is shown one second after the start of the stream
+	resp, err := m.ExtractCaptions(ctx, schema.ExtractCaptionsRequest{Reader: f})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.CEA608 || len(resp.Track.Subtitles) != 1 {
+		t.Fatalf("expected one CEA-608 caption, got %v", resp.Track.Subtitles)
+	}
+	if s := resp.Track.Subtitles[0]; s.Start != time.Second || !strings.Contains(s.Text, "HI") {
+		t.Fatalf("unexpected caption at %v: %q", s.Start, s.Text)
+	}
+}
+
+func TestExtractCaptions_BadParameters(t *testing.T) {
+	m, ctx := test.Begin(t)
+
+	if _, err := m.ExtractCaptions(ctx, schema.ExtractCaptionsRequest{}); !errors.Is(err, gomedia.ErrBadParameter) {
+		t.Fatalf("expected ErrBadParameter, got %v", err)
+	}
+
+	// Audio has no captions
+	f, err := os.Open(filepath.Join("..", "..", "etc", "test", "sample.mp3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := m.ExtractCaptions(ctx, schema.ExtractCaptionsRequest{Reader: f}); !errors.Is(err, gomedia.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestProbe_Captions(t *testing.T) {
+	m, ctx := test.Begin(t)
+
+	f, err := os.Open(filepath.Join("..", "..", "etc", "test", "sample.mp4"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	resp, err := m.Probe(ctx, schema.ProbeRequest{Reader: f, Captions: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Captions) != 0 {
+		t.Fatalf("expected no captions, got %v", resp.Captions)
+	}
+}
+
+// Encode two seconds of MPEG-2 video which starts at ten seconds, with a
+// pop-on caption in the A53 side data of the frame at eleven seconds, and
+// return the path
+func writeCaptions(t *testing.T, path string) string {
+	t.Helper()
+
+	// Return a CEA-608 cc_data triplet for the first field, with odd parity
+	cc := func(hi, lo byte) []byte {
+		parity := func(b byte) byte {
+			if bits.OnesCount8(b)%2 == 0 {
+				return b | 0x80
+			}
+			return b
+		}
+		return []byte{0xFC, parity(hi), parity(lo)}
+	}
+	var data []byte
+	data = append(data, cc(0x14, 0x20)...)
+	data = append(data, cc('H', 'I')...)
+	data = append(data, cc(0x14, 0x2F)...)
+
+	par, err := ffmpeg.NewVideoPar("yuv420p", "160x120", 25)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writer, err := ffmpeg.Create(path, ffmpeg.OptStream(0, par))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer writer.Close()
+	frame, err := ffmpeg.NewFrame(par)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer frame.Close()
+	if err := frame.AllocateBuffers(); err != nil {
+		t.Fatal(err)
+	}
+	for i := 250; i < 300; i++ {
+		frame.SetPts(int64(i))
+		if i == 275 {
+			if _, err := ff.AVUtil_frame_new_side_data((*ff.AVFrame)(frame), ff.AV_FRAME_DATA_A53_CC, data); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := writer.EncodeFrame(0, frame); err != nil {
+			t.Fatal(err)
+		}
+		ff.AVUtil_frame_remove_side_data((*ff.AVFrame)(frame), ff.AV_FRAME_DATA_A53_CC)
+	}
+	if err := writer.EncodeFrame(0, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
@@ -0,0 +1,575 @@
+package manager
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	// Packages
+	otel "github.com/mutablelogic/go-client/pkg/otel"
+	gomedia "github.com/mutablelogic/go-media"
+	schema "github.com/mutablelogic/go-media/gomedia/schema"
+	metadata "github.com/mutablelogic/go-media/metadata"
+	ffmpeg "github.com/mutablelogic/go-media/pkg/ffmpeg"
+	ffschema "github.com/mutablelogic/go-media/pkg/ffmpeg/schema"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
+	attribute "go.opentelemetry.io/otel/attribute"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// concat joins inputs one after another, where times are in the output
+type concat struct {
+	inputs  []*concatInput
+	streams []*concatStream // Output streams, by output index
+	format  string
+	oformat *ff.AVOutputFormat
+	writer  *ffmpeg.Writer
+	copy    bool          // Packets are copied, rather than re-encoded
+	offset  time.Duration // Output time at which the current input starts
+	end     time.Duration // End of the packets or frames written so far
+	resp    schema.ConcatResponse
+}
+
+type concatInput struct {
+	name     string // Name of the input, for errors
+	title    string // Title of the chapter for the input
+	reader   *ffmpeg.Reader
+	streams  map[int]int   // Output stream index, by input stream index
+	start    time.Duration // Timestamp of the first frame
+	duration time.Duration
+	offset   time.Duration // Output time at which the input starts
+}
+
+type concatStream struct {
+	t     gomedia.Type
+	codec *ff.AVCodecParameters // Parameters of the stream in the first input
+	par   *ffmpeg.Par           // Parameters of the encoder, when re-encoding
+	tb    ff.AVRational         // Timebase of the written packets or encoded frames
+	next  int64                 // Earliest decode time of the next packet or frame, in the timebase
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Concat joins the inputs one after another and writes them to w, with a
+// chapter for each input. The output has the best video and audio streams
+// of the first input, and every input needs to have the same kinds of
+// streams. When the codec parameters of the inputs match, as for the
+// chapters of a camera recording, the packets are copied with their
+// timestamps moved along. Otherwise the inputs are decoded, resampled to
+// the parameters of the first input and encoded with the default codecs of
+// the output format.
+//
+// The metadata and artwork of the first input are copied, except for the
+// title, which becomes the title of the first chapter. Inputs are read
+// once, so they don't need to be seekable.
+func (m *Media) Concat(ctx context.Context, w io.Writer, req schema.ConcatRequest) (_ *schema.ConcatResponse, err error) {
+	ctx, endSpan := otel.StartSpan(m.tracer, ctx, "Concat",
+		attribute.Int("inputs", len(req.Readers)),
+		attribute.String("mode", req.Mode),
+	)
+	defer func() { endSpan(err) }()
+
+	if len(req.Readers) == 0 || w == nil {
+		return nil, gomedia.ErrBadParameter.With("no inputs or nil writer")
+	}
+	if slices.Contains(req.Readers, nil) {
+		return nil, gomedia.ErrBadParameter.With("nil reader")
+	}
+	switch req.Mode {
+	case "", "auto", "copy", "encode":
+		// Valid mode
+	default:
+		return nil, gomedia.ErrBadParameter.Withf("invalid mode %q", req.Mode)
+	}
+
+	// Open the inputs, and map their streams to the output
+	c := new(concat)
+	defer c.free()
+	for n, r := range req.Readers {
+		if err := c.open(ctx, n, r); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.mapStreams(); err != nil {
+		return nil, err
+	}
+
+	// Copy the packets when the inputs match
+	match := c.match()
+	switch req.Mode {
+	case "copy":
+		if !match {
+			return nil, gomedia.ErrBadParameter.With("the codec parameters of the inputs differ")
+		}
+		c.copy = true
+	case "encode":
+		c.copy = false
+	default:
+		c.copy = match
+	}
+
+	// The output has the same format as the first input, unless set
+	if c.format, c.oformat, err = outputFormat(req.Format, c.inputs[0].reader); err != nil {
+		return nil, err
+	}
+
+	// Join the inputs, move the chapters to where the inputs were placed,
+	// and write the trailer
+	err = c.join(ctx, w)
+	if err == nil {
+		err = c.setChapters()
+	}
+	if c.writer != nil {
+		err = errors.Join(err, c.writer.Close())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Return the output
+	c.resp.Copy = c.copy
+	c.resp.Duration = c.offset
+	return &c.resp, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// Open an input, which is the nth input from zero
+func (c *concat) open(ctx context.Context, n int, r io.Reader) error {
+	in := &concatInput{
+		name:    fmt.Sprint("input ", n+1),
+		title:   fmt.Sprint("Part ", n+1),
+		streams: make(map[int]int),
+	}
+	if named, ok := r.(metadata.NamedStream); ok && named.Name() != "" {
+		in.name = named.Name()
+		in.title = strings.TrimSuffix(filepath.Base(in.name), filepath.Ext(in.name))
+	}
+
+	// Video is decoded upright when re-encoding, and packets are unchanged
+	reader, err := ffmpeg.NewReader(r, ffmpeg.OptContext(ctx), ffmpeg.OptAutorotate())
+	if err != nil {
+		return fmt.Errorf("%s: %w", in.name, err)
+	}
+	in.reader = reader
+	c.inputs = append(c.inputs, in)
+
+	// The chapters are written with the header, so the duration of each
+	// input needs to be known before the packets are read
+	in.start = reader.StartTime()
+	if in.duration = reader.Duration(); in.duration <= 0 {
+		return gomedia.ErrBadParameter.Withf("%s: unknown duration", in.name)
+	}
+	if n > 0 {
+		prev := c.inputs[n-1]
+		in.offset = prev.offset + prev.duration
+	}
+
+	// The title of the input is the title of the chapter
+	for _, entry := range reader.Metadata("title") {
+		if title := strings.TrimSpace(entry.Value()); title != "" {
+			in.title = title
+			break
+		}
+	}
+
+	// Return success
+	return nil
+}
+
+// Close the inputs
+func (c *concat) free() {
+	for _, in := range c.inputs {
+		in.reader.Close()
+	}
+	c.inputs = nil
+}
+
+// The output has the best video and audio streams of the first input, which
+// are joined with the best streams of the same kind in each input
+func (c *concat) mapStreams() error {
+	first := c.inputs[0]
+	for _, t := range []gomedia.Type{gomedia.VIDEO, gomedia.AUDIO} {
+		if stream := first.reader.BestStream(t); stream >= 0 {
+			c.streams = append(c.streams, &concatStream{
+				t:     t,
+				codec: first.reader.AVStreams()[stream].CodecPar(),
+				tb:    first.reader.AVStreams()[stream].TimeBase(),
+			})
+		}
+	}
+	if len(c.streams) == 0 {
+		return gomedia.ErrBadParameter.Withf("%s: no audio or video streams", first.name)
+	}
+	for _, in := range c.inputs {
+		for out, s := range c.streams {
+			stream := in.reader.BestStream(s.t)
+			if stream < 0 {
+				return gomedia.ErrBadParameter.Withf("%s: no %v stream", in.name, s.t)
+			}
+			in.streams[stream] = out
+		}
+	}
+	return nil
+}
+
+// Return true if the packets of the inputs can be joined without
+// re-encoding them
+func (c *concat) match() bool {
+	for _, in := range c.inputs[1:] {
+		for stream, out := range in.streams {
+			if !concatMatch(c.streams[out].codec, in.reader.AVStreams()[stream].CodecPar()) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Join the inputs, copying packets or re-encoding frames
+func (c *concat) join(ctx context.Context, w io.Writer) error {
+	if c.copy {
+		if err := c.create(ctx, w); err != nil {
+			return err
+		}
+	}
+	for _, in := range c.inputs {
+		in.offset = c.offset
+		if c.copy {
+			if err := c.remux(ctx, in); err != nil {
+				return err
+			}
+		} else if err := c.transcode(ctx, w, in); err != nil {
+			return err
+		}
+
+		// The next input starts after this one, or after the last packet or
+		// frame when the input runs past its duration
+		c.offset = max(c.offset+in.duration, c.end)
+	}
+	if c.copy {
+		return nil
+	}
+
+	// Flush the encoders
+	if c.writer == nil {
+		return gomedia.ErrBadParameter.With("no frames were decoded")
+	}
+	for out := range c.streams {
+		if err := c.writer.EncodeFrame(out, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Create the output with the streams, metadata and artwork of the first
+// input and a chapter for each input
+func (c *concat) create(ctx context.Context, w io.Writer) error {
+	first := c.inputs[0]
+	opts := []ffmpeg.Opt{ffmpeg.OptContext(ctx), ffmpeg.OptOutputFormat(c.format)}
+	if c.copy {
+		opts = append(opts, ffmpeg.OptCopy())
+	}
+	for out, s := range c.streams {
+		par := s.par
+		if c.copy {
+			par = &ffmpeg.Par{AVCodecParameters: *s.codec}
+		} else if par == nil {
+			return gomedia.ErrInternalError.Withf("no parameters for output stream %d", out)
+		}
+		opts = append(opts, ffmpeg.OptStream(out+1, par))
+	}
+
+	// Copy the metadata and artwork of the first input
+	for _, entry := range first.reader.Metadata() {
+		if entry.Key() != "title" {
+			opts = append(opts, ffmpeg.OptMetadata(entry))
+		}
+	}
+	for _, entry := range first.reader.Metadata(ffmpeg.MetaArtwork) {
+		if entry.Key() == ffmpeg.MetaArtwork {
+			opts = append(opts, ffmpeg.OptMetadata(entry))
+		}
+	}
+
+	// Mark the start of each input, from the durations of the inputs which
+	// have not been placed yet
+	last := c.inputs[len(c.inputs)-1]
+	opts = append(opts, ffmpeg.OptChapters(c.chapters(last.offset+last.duration)...))
+
+	// Create the output
+	writer, err := ffmpeg.NewWriter(w, opts...)
+	if err != nil {
+		return err
+	}
+	c.writer = writer
+
+	// Copied packets keep the timebase of the first input, and frames are
+	// encoded at the timebase of the encoder
+	for out, s := range c.streams {
+		if c.copy {
+			s.next = math.MinInt64
+		} else {
+			s.tb = writer.Stream(out).Par().TimeBase()
+			s.next = 0
+		}
+	}
+
+	// Return success
+	return nil
+}
+
+// Return a chapter for each input, from the output time at which it starts
+// to the time at which the next input starts, or to end for the last input
+func (c *concat) chapters(end time.Duration) []*ffschema.Chapter {
+	chapters := make([]*ffschema.Chapter, 0, len(c.inputs))
+	for n, in := range c.inputs {
+		chapter := &ffschema.Chapter{
+			Id:    int64(n + 1),
+			Start: in.offset,
+			End:   end,
+			Title: in.title,
+		}
+		if n+1 < len(c.inputs) {
+			chapter.End = c.inputs[n+1].offset
+		}
+		chapters = append(chapters, chapter)
+	}
+	return chapters
+}
+
+// Set the chapters to where the inputs were placed, which is later than
+// their durations when an input runs past its duration. Formats which write
+// the chapters with the header keep the chapters from the durations.
+func (c *concat) setChapters() error {
+	for _, chapter := range c.chapters(c.offset) {
+		if err := c.writer.SetChapter(chapter.Id, chapter.Start, chapter.End); err != nil {
+			return err
+		}
+		c.resp.Chapters = append(c.resp.Chapters, schema.WrapChapter(chapter))
+	}
+	return nil
+}
+
+// Copy the packets of an input, moving their timestamps along
+func (c *concat) remux(ctx context.Context, in *concatInput) error {
+	return in.reader.Decode(ctx, func(stream int, pkt *ffmpeg.Packet) error {
+		out, exists := in.streams[stream]
+		if !exists {
+			return nil
+		}
+		s := c.streams[out]
+		ff.AVCodec_packet_rescale_ts(pkt.AVPacket, in.reader.AVStreams()[stream].TimeBase(), s.tb)
+
+		// Move the timestamps along, keeping decode times increasing across
+		// the join
+		shift := durationToTs(c.offset-in.start, s.tb)
+		pts, dts := pkt.Pts(), pkt.Dts()
+		if pts != int64(ff.AV_NOPTS_VALUE) {
+			pts += shift
+		}
+		if dts != int64(ff.AV_NOPTS_VALUE) {
+			dts = max(dts+shift, s.next)
+			s.next = dts + 1
+			if pts != int64(ff.AV_NOPTS_VALUE) {
+				pts = max(pts, dts)
+			}
+		}
+		pkt.SetPts(pts)
+		pkt.SetDts(dts)
+		if pts != int64(ff.AV_NOPTS_VALUE) {
+			c.end = max(c.end, tsToDuration(pts+pkt.Duration(), s.tb))
+		}
+
+		// Write the packet
+		pkt.SetPos(-1)
+		pkt.SetStreamIndex(out)
+		pkt.SetTimeBase(s.tb)
+		if err := c.writer.Write(pkt); err != nil {
+			return err
+		}
+		c.resp.Packets++
+		return nil
+	})
+}
+
+// Decode the frames of an input, resample them to the parameters of the
+// output and encode them. The output is created when the first frame is
+// decoded, when the parameters are known.
+func (c *concat) transcode(ctx context.Context, w io.Writer, in *concatInput) error {
+	mapfn := func(stream int, par *ffmpeg.Par) (*ffmpeg.Par, error) {
+		out, exists := in.streams[stream]
+		if !exists {
+			return nil, nil
+		}
+		s := c.streams[out]
+		if s.par == nil {
+			dest, err := encoderPar(c.oformat, in.reader.AVStreams()[stream], par)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", in.name, err)
+			}
+			s.par = dest
+		}
+		return s.par, nil
+	}
+	framefn := func(stream int, frame *ffmpeg.Frame) error {
+		out, exists := in.streams[stream]
+		if !exists {
+			return nil
+		}
+		if c.writer == nil {
+			if err := c.create(ctx, w); err != nil {
+				return err
+			}
+		}
+		return c.encode(in, stream, out, frame)
+	}
+	return in.reader.Demux(ctx, mapfn, framefn, nil)
+}
+
+// Return the output format, which is the format of the input unless set
+func outputFormat(name string, reader *ffmpeg.Reader) (string, *ff.AVOutputFormat, error) {
+	if format := reader.InputFormat(); name == "" && format != nil {
+		name, _, _ = strings.Cut(format.Name(), ",")
+	}
+	oformat := ff.AVFormat_guess_format(name, name, "")
+	if oformat == nil {
+		return "", nil, gomedia.ErrBadParameter.Withf("invalid output format %q", name)
+	}
+	return name, oformat, nil
+}
+
+// Return the parameters for encoding a stream with the default codec of the
+// output format, which are those of the decoded stream where the encoder
+// supports them
+func encoderPar(oformat *ff.AVOutputFormat, stream *ff.AVStream, par *ffmpeg.Par) (*ffmpeg.Par, error) {
+	switch par.Type() {
+	case gomedia.VIDEO:
+		codec := ff.AVCodec_find_encoder(oformat.VideoCodec())
+		if codec == nil {
+			return nil, gomedia.ErrNotImplemented.Withf("no video encoder for format %q", oformat.Name())
+		}
+		pixfmt := par.PixelFormat()
+		if formats := codec.PixelFormats(); len(formats) > 0 && !slices.Contains(formats, pixfmt) {
+			pixfmt = formats[0]
+		}
+		framerate := stream.AvgFrameRate()
+		if framerate.Num() <= 0 || framerate.Den() <= 0 {
+			framerate = stream.RFrameRate()
+		}
+		if framerate.Num() <= 0 || framerate.Den() <= 0 {
+			return nil, gomedia.ErrBadParameter.With("unknown frame rate")
+		}
+		dest, err := ffmpeg.NewVideoPar(ff.AVUtil_get_pix_fmt_name(pixfmt), par.WidthHeight(), ff.AVUtil_rational_q2d(framerate))
+		if err != nil {
+			return nil, err
+		}
+		if sar := par.SampleAspectRatio(); sar.Num() > 0 && sar.Den() > 0 {
+			dest.SetSampleAspectRatio(sar)
+		}
+		if bitrate := stream.CodecPar().BitRate(); bitrate > 0 {
+			dest.SetBitRate(bitrate)
+		}
+		return dest, nil
+	case gomedia.AUDIO:
+		codec := ff.AVCodec_find_encoder(oformat.AudioCodec())
+		if codec == nil {
+			return nil, gomedia.ErrNotImplemented.Withf("no audio encoder for format %q", oformat.Name())
+		}
+		samplefmt := par.SampleFormat()
+		if formats := codec.SampleFormats(); len(formats) > 0 && !slices.Contains(formats, samplefmt) {
+			samplefmt = formats[0]
+		}
+		samplerate := par.SampleRate()
+		if rates := codec.SupportedSamplerates(); len(rates) > 0 && !slices.Contains(rates, samplerate) {
+			samplerate = rates[0]
+		}
+		ch := par.ChannelLayout()
+		layout, err := ff.AVUtil_channel_layout_describe(&ch)
+		if err != nil {
+			return nil, err
+		}
+		dest, err := ffmpeg.NewAudioPar(ff.AVUtil_get_sample_fmt_name(samplefmt), layout, samplerate)
+		if err != nil {
+			return nil, err
+		}
+		if bitrate := stream.CodecPar().BitRate(); bitrate > 0 {
+			dest.SetBitRate(bitrate)
+		}
+		return dest, nil
+	default:
+		return nil, gomedia.ErrInternalError.Withf("unexpected %v stream", par.Type())
+	}
+}
+
+// Encode a frame, moving its timestamp along
+func (c *concat) encode(in *concatInput, stream, out int, frame *ffmpeg.Frame) error {
+	s := c.streams[out]
+	tb := frame.TimeBase()
+	if tb.Num() == 0 || tb.Den() == 0 {
+		tb = in.reader.AVStreams()[stream].TimeBase()
+	}
+	ts := frame.Pts()
+	if ts == int64(ff.AV_NOPTS_VALUE) {
+		ts = (*ff.AVFrame)(frame).BestEffortTimestamp()
+	}
+	pts := s.next
+	if ts != int64(ff.AV_NOPTS_VALUE) {
+		pts = durationToTs(tsToDuration(ts, tb)-in.start+c.offset, s.tb)
+	}
+
+	switch s.t {
+	case gomedia.VIDEO:
+		// Drop frames which would be shown at the same time as the last,
+		// when the input has a higher frame rate than the output. The
+		// encoder chooses the picture type, rather than follow the input.
+		if pts < s.next {
+			return nil
+		}
+		s.next = pts + 1
+		(*ff.AVFrame)(frame).SetPictType(ff.AV_PICTURE_TYPE_NONE)
+	case gomedia.AUDIO:
+		// Audio follows on from the last samples, without overlapping them
+		pts = max(pts, s.next)
+		s.next = pts + ff.AVUtil_rational_rescale_q(int64(frame.NumSamples()), ff.AVUtil_rational(1, frame.SampleRate()), s.tb)
+	}
+	frame.SetPts(pts)
+	(*ff.AVFrame)(frame).SetTimeBase(s.tb)
+	c.end = max(c.end, tsToDuration(s.next, s.tb))
+
+	// Encode the frame
+	if err := c.writer.EncodeFrame(out, frame); err != nil {
+		return err
+	}
+	c.resp.Frames++
+	return nil
+}
+
+// Return true if packets with the codec parameters b can follow packets
+// with the codec parameters a in the same stream
+func concatMatch(a, b *ff.AVCodecParameters) bool {
+	if a.CodecType() != b.CodecType() || a.CodecID() != b.CodecID() || !bytes.Equal(a.Extradata(), b.Extradata()) {
+		return false
+	}
+	switch a.CodecType() {
+	case ff.AVMEDIA_TYPE_VIDEO:
+		return a.Width() == b.Width() && a.Height() == b.Height() && a.PixelFormat() == b.PixelFormat()
+	case ff.AVMEDIA_TYPE_AUDIO:
+		ach, bch := a.ChannelLayout(), b.ChannelLayout()
+		return a.SampleRate() == b.SampleRate() && a.SampleFormat() == b.SampleFormat() && ff.AVUtil_channel_layout_compare(&ach, &bch)
+	default:
+		return true
+	}
+}
@@ -0,0 +1,146 @@
+package manager_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	// Packages
+	gomedia "github.com/mutablelogic/go-media"
+	schema "github.com/mutablelogic/go-media/gomedia/schema"
+	test "github.com/mutablelogic/go-media/gomedia/test"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
+)
+
+func TestConcat_Copy(t *testing.T) {
+	m, ctx := test.Begin(t)
+
+	var readers []io.Reader
+	for range 2 {
+		f, err := os.Open(filepath.Join("..", "..", "etc", "test", "sample.mp4"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		readers = append(readers, f)
+	}
+
+	out := filepath.Join(t.TempDir(), "concat.mp4")
+	w, err := os.Create(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	resp, err := m.Concat(ctx, w, schema.ConcatRequest{Readers: readers})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The inputs match, so the packets are copied, with a chapter for each
+	if !resp.Copy || resp.Packets == 0 || resp.Frames != 0 {
+		t.Fatalf("expected copied packets only, got %d copied and %d encoded", resp.Packets, resp.Frames)
+	}
+	if len(resp.Chapters) != 2 {
+		t.Fatalf("expected 2 chapters, got %d", len(resp.Chapters))
+	}
+	if resp.Chapters[1].Start != resp.Chapters[0].End {
+		t.Fatalf("expected the second chapter to start at %v, got %v", resp.Chapters[0].End, resp.Chapters[1].Start)
+	}
+	if resp.Chapters[1].End != resp.Duration {
+		t.Fatalf("expected the second chapter to end at %v, got %v", resp.Duration, resp.Chapters[1].End)
+	}
+
+	// The output lasts for both inputs
+	r, err := os.Open(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	probe, err := m.Probe(ctx, schema.ProbeRequest{Reader: r})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Duration(probe.Duration * float64(time.Second)); d < 2*resp.Chapters[0].End-time.Second/2 {
+		t.Fatalf("expected duration of about %v, got %v", 2*resp.Chapters[0].End, d)
+	}
+	if len(probe.Chapters) != 2 {
+		t.Fatalf("expected 2 chapters in the output, got %d", len(probe.Chapters))
+	}
+}
+
+func TestConcat_Encode(t *testing.T) {
+	m, ctx := test.Begin(t)
+	if ff.AVCodec_find_encoder(ff.AV_CODEC_ID_AAC) == nil {
+		t.Skip("no AAC encoder")
+	}
+
+	var readers []io.Reader
+	for _, name := range []string{"sample.mp3", "jfk.wav"} {
+		f, err := os.Open(filepath.Join("..", "..", "etc", "test", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		readers = append(readers, f)
+	}
+
+	out := filepath.Join(t.TempDir(), "concat.m4a")
+	w, err := os.Create(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	resp, err := m.Concat(ctx, w, schema.ConcatRequest{Readers: readers, Format: out})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The sample rates differ, so the audio is re-encoded
+	if resp.Copy || resp.Frames == 0 {
+		t.Fatalf("expected encoded frames, got %d copied and %d encoded", resp.Packets, resp.Frames)
+	}
+	if len(resp.Chapters) != 2 {
+		t.Fatalf("expected 2 chapters, got %d", len(resp.Chapters))
+	}
+	if resp.Chapters[0].Title != "sample" || resp.Chapters[1].Title != "jfk" {
+		t.Fatalf("expected chapters titled by file, got %q and %q", resp.Chapters[0].Title, resp.Chapters[1].Title)
+	}
+	if resp.Duration < resp.Chapters[1].End {
+		t.Fatalf("expected duration of at least %v, got %v", resp.Chapters[1].End, resp.Duration)
+	}
+}
+
+func TestConcat_BadParameters(t *testing.T) {
+	m, ctx := test.Begin(t)
+
+	var buf bytes.Buffer
+	for _, req := range []schema.ConcatRequest{
+		{},
+		{Readers: []io.Reader{nil}},
+		{Readers: []io.Reader{bytes.NewReader([]byte{0})}, Mode: "join"},
+	} {
+		if _, err := m.Concat(ctx, &buf, req); !errors.Is(err, gomedia.ErrBadParameter) {
+			t.Errorf("expected ErrBadParameter for %+v, got %v", req, err)
+		}
+	}
+
+	// The inputs can't be copied when their sample rates differ
+	var readers []io.Reader
+	for _, name := range []string{"sample.mp3", "jfk.wav"} {
+		f, err := os.Open(filepath.Join("..", "..", "etc", "test", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		readers = append(readers, f)
+	}
+	if _, err := m.Concat(ctx, &buf, schema.ConcatRequest{Readers: readers, Mode: "copy"}); !errors.Is(err, gomedia.ErrBadParameter) {
+		t.Errorf("expected ErrBadParameter, got %v", err)
+	}
+}
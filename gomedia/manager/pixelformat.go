@@ -5,7 +5,7 @@ import (
 
 	// Packages
 	schema "github.com/mutablelogic/go-media/gomedia/schema"
-	ff "github.com/mutablelogic/go-media/sys/ffmpeg80"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
 )
 
 ////////////////////////////////////////////////////////////////////////////////
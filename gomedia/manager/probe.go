@@ -18,16 +18,17 @@ import (
 // PUBLIC METHODS
 
 // Probe a media stream from any reader and return information about its
-// container format and streams.
+// container format, streams and chapters.
 func (m *Media) Probe(ctx context.Context, req goschema.ProbeRequest) (_ *goschema.ProbeResponse, err error) {
 	name := "reader"
 	if named, ok := req.Reader.(metadata.NamedStream); ok {
 		name = named.Name()
 	}
 
-	_, endSpan := otel.StartSpan(m.tracer, ctx, "Probe",
+	ctx, endSpan := otel.StartSpan(m.tracer, ctx, "Probe",
 		attribute.String("input", name),
 		attribute.String("input_format", req.InputFormat),
+		attribute.Bool("captions", req.Captions),
 	)
 	defer func() { endSpan(err) }()
 
@@ -35,7 +36,7 @@ func (m *Media) Probe(ctx context.Context, req goschema.ProbeRequest) (_ *gosche
 		return nil, errors.New("nil reader")
 	}
 
-	reader, err := ffmpeg.NewReader(req.Reader, ffmpeg.WithInput(req.InputFormat, req.InputOpts...))
+	reader, err := ffmpeg.NewReader(req.Reader, ffmpeg.OptContext(ctx), ffmpeg.WithInput(req.InputFormat, req.InputOpts...))
 	if err != nil {
 		return nil, err
 	}
@@ -61,6 +62,20 @@ func (m *Media) Probe(ctx context.Context, req goschema.ProbeRequest) (_ *gosche
 		}
 	}
 
+	// Chapter information.
+	var chapters []*goschema.Chapter
+	for _, chapter := range reader.Chapters() {
+		chapters = append(chapters, goschema.WrapChapter(chapter))
+	}
+
+	// Closed captions, which are only found by decoding the video
+	var captions []goschema.Captions
+	if req.Captions {
+		if captions, err = probeCaptions(ctx, reader); err != nil {
+			return nil, err
+		}
+	}
+
 	// Response
 	resp := &goschema.ProbeResponse{
 		Format:      formatName,
@@ -68,6 +83,8 @@ func (m *Media) Probe(ctx context.Context, req goschema.ProbeRequest) (_ *gosche
 		MimeTypes:   mimeTypes,
 		Duration:    reader.Duration().Seconds(),
 		Streams:     streams,
+		Chapters:    chapters,
+		Captions:    captions,
 	}
 
 	return resp, nil
@@ -0,0 +1,195 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	// Packages
+	otel "github.com/mutablelogic/go-client/pkg/otel"
+	gomedia "github.com/mutablelogic/go-media"
+	schema "github.com/mutablelogic/go-media/gomedia/schema"
+	metadata "github.com/mutablelogic/go-media/metadata"
+	raw "github.com/mutablelogic/go-media/pkg/raw"
+	attribute "go.opentelemetry.io/otel/attribute"
+	tiff "golang.org/x/image/tiff"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+// rawProofQuality is the JPEG quality used for developed proofs.
+const rawProofQuality = 90
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// DevelopRAW develops a RAW camera file with the requested white balance,
+// exposure, highlight recovery, colour space and bit depth, and writes the
+// result to w as a JPEG, PNG or TIFF image. Returns ErrNotImplemented if the
+// input is not a RAW file supported by libraw.
+func (m *Media) DevelopRAW(ctx context.Context, w io.Writer, req schema.RAWDevelopRequest) (err error) {
+	name := "reader"
+	if named, ok := req.Reader.(metadata.NamedStream); ok {
+		name = named.Name()
+	}
+
+	_, endSpan := otel.StartSpan(m.tracer, ctx, "DevelopRAW",
+		attribute.String("input", name),
+		attribute.String("format", req.Format),
+	)
+	defer func() { endSpan(err) }()
+
+	if req.Reader == nil || w == nil {
+		return errors.New("nil reader or writer")
+	}
+
+	// Check the options before the (slow) develop
+	opts, err := developOptions(req.RAWDevelopParams)
+	if err != nil {
+		return err
+	}
+	opts.Bits = req.Bits
+	if req.Format == "" || req.Format == "jpeg" {
+		if opts.Bits == 16 {
+			return gomedia.ErrBadParameter.With("16-bit output requires png or tiff format")
+		}
+	}
+
+	// Open and develop the RAW file
+	data, err := raw.Read(req.Reader)
+	if err != nil {
+		return gomedia.ErrNotImplemented.With(err.Error())
+	}
+	defer data.Close()
+
+	img, err := data.Develop(opts)
+	if err != nil {
+		return err
+	}
+
+	// Encode the image
+	return encodeImage(w, img, req.Format)
+}
+
+// ExportRAW develops a RAW camera file to 16 bits per sample and writes it
+// to w as a TIFF or linear DNG for archiving, carrying over the EXIF and XMP
+// metadata and optionally the embedded preview. Returns ErrNotImplemented if
+// the input is not a RAW file supported by libraw.
+func (m *Media) ExportRAW(ctx context.Context, w io.Writer, req schema.RAWExportRequest) (err error) {
+	name := "reader"
+	if named, ok := req.Reader.(metadata.NamedStream); ok {
+		name = named.Name()
+	}
+
+	_, endSpan := otel.StartSpan(m.tracer, ctx, "ExportRAW",
+		attribute.String("input", name),
+		attribute.String("format", req.Format),
+	)
+	defer func() { endSpan(err) }()
+
+	if req.Reader == nil || w == nil {
+		return errors.New("nil reader or writer")
+	}
+
+	// Check the options before the (slow) develop
+	develop, err := developOptions(req.RAWDevelopParams)
+	if err != nil {
+		return err
+	}
+	opts := &raw.ExportOptions{
+		Develop: *develop,
+		Linear:  req.Linear,
+		Preview: req.Preview,
+	}
+	switch req.Format {
+	case "", "dng":
+		opts.Format = raw.ExportDNG
+	case "tiff":
+		opts.Format = raw.ExportTIFF
+	default:
+		return gomedia.ErrBadParameter.Withf("unsupported export format %q", req.Format)
+	}
+
+	// Open and export the RAW file
+	data, err := raw.Read(req.Reader)
+	if err != nil {
+		return gomedia.ErrNotImplemented.With(err.Error())
+	}
+	defer data.Close()
+
+	return data.Export(w, opts)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+func developOptions(req schema.RAWDevelopParams) (*raw.DevelopOptions, error) {
+	opts := &raw.DevelopOptions{
+		Exposure: req.Exposure,
+		HalfSize: req.HalfSize,
+	}
+
+	// White balance
+	switch req.WhiteBalance {
+	case "", "as-shot":
+		opts.WhiteBalance = raw.WhiteBalanceAsShot
+	case "auto":
+		opts.WhiteBalance = raw.WhiteBalanceAuto
+	case "custom":
+		opts.WhiteBalance = raw.WhiteBalanceCustom
+	default:
+		return nil, gomedia.ErrBadParameter.Withf("unsupported white balance %q", req.WhiteBalance)
+	}
+	if len(req.Multipliers) > 0 {
+		if len(req.Multipliers) < 3 || len(req.Multipliers) > 4 {
+			return nil, gomedia.ErrBadParameter.With("white balance multipliers must be R,G,B[,G2]")
+		}
+		opts.WhiteBalance = raw.WhiteBalanceCustom
+		copy(opts.Multipliers[:], req.Multipliers)
+	}
+
+	// Highlight recovery
+	switch req.Highlight {
+	case "", "clip":
+		opts.Highlight = raw.HighlightClip
+	case "unclip":
+		opts.Highlight = raw.HighlightUnclip
+	case "blend":
+		opts.Highlight = raw.HighlightBlend
+	case "rebuild":
+		opts.Highlight = raw.HighlightRebuild
+	default:
+		return nil, gomedia.ErrBadParameter.Withf("unsupported highlight mode %q", req.Highlight)
+	}
+
+	// Colour space
+	switch req.ColorSpace {
+	case "", "srgb":
+		opts.ColorSpace = raw.ColorSpaceSRGB
+	case "adobergb":
+		opts.ColorSpace = raw.ColorSpaceAdobeRGB
+	case "prophoto":
+		opts.ColorSpace = raw.ColorSpaceProPhoto
+	default:
+		return nil, gomedia.ErrBadParameter.Withf("unsupported colour space %q", req.ColorSpace)
+	}
+
+	return opts, nil
+}
+
+func encodeImage(w io.Writer, img image.Image, format string) error {
+	switch format {
+	case "", "jpeg":
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: rawProofQuality})
+	case "png":
+		return png.Encode(w, img)
+	case "tiff":
+		return tiff.Encode(w, img, &tiff.Options{Compression: tiff.Deflate})
+	default:
+		return gomedia.ErrBadParameter.Withf("unsupported image format %q", format)
+	}
+}
@@ -0,0 +1,73 @@
+package manager_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	// Packages
+	gomedia "github.com/mutablelogic/go-media"
+	schema "github.com/mutablelogic/go-media/gomedia/schema"
+	test "github.com/mutablelogic/go-media/gomedia/test"
+)
+
+func TestDevelopRAW_NotRAW(t *testing.T) {
+	m, ctx := test.Begin(t)
+
+	f, err := os.Open(filepath.Join("..", "..", "etc", "test", "sample.png"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var buf bytes.Buffer
+	err = m.DevelopRAW(ctx, &buf, schema.RAWDevelopRequest{Reader: f})
+	if !errors.Is(err, gomedia.ErrNotImplemented) {
+		t.Fatalf("expected ErrNotImplemented, got %v", err)
+	}
+}
+
+func TestDevelopRAW_BadOptions(t *testing.T) {
+	m, ctx := test.Begin(t)
+
+	var buf bytes.Buffer
+	err := m.DevelopRAW(ctx, &buf, schema.RAWDevelopRequest{
+		Reader: bytes.NewReader([]byte{0}),
+		Format: "jpeg",
+		Bits:   16,
+	})
+	if !errors.Is(err, gomedia.ErrBadParameter) {
+		t.Fatalf("expected ErrBadParameter, got %v", err)
+	}
+}
+
+func TestExportRAW_NotRAW(t *testing.T) {
+	m, ctx := test.Begin(t)
+
+	f, err := os.Open(filepath.Join("..", "..", "etc", "test", "sample.png"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var buf bytes.Buffer
+	err = m.ExportRAW(ctx, &buf, schema.RAWExportRequest{Reader: f, Format: "dng"})
+	if !errors.Is(err, gomedia.ErrNotImplemented) {
+		t.Fatalf("expected ErrNotImplemented, got %v", err)
+	}
+}
+
+func TestExportRAW_BadFormat(t *testing.T) {
+	m, ctx := test.Begin(t)
+
+	var buf bytes.Buffer
+	err := m.ExportRAW(ctx, &buf, schema.RAWExportRequest{
+		Reader: bytes.NewReader([]byte{0}),
+		Format: "jpeg",
+	})
+	if !errors.Is(err, gomedia.ErrBadParameter) {
+		t.Fatalf("expected ErrBadParameter, got %v", err)
+	}
+}
@@ -145,38 +145,26 @@ func encodeSegmentM4A(_ context.Context, sampleRate int, samples []int16, outPat
 	}
 	defer writer.Close()
 
-	const fallbackFrameSamples = 1152
-	frameSamples := fallbackFrameSamples
-	if stream := writer.Stream(0); stream != nil && stream.FrameSize() > 0 {
-		frameSamples = stream.FrameSize()
-	}
-
 	frame, err := ffmpeg.NewFrame(audioPar)
 	if err != nil {
 		return err
 	}
 	defer frame.Close()
 
-	pts := 0
-	for offset := 0; offset < len(samples); offset += frameSamples {
-		chunkEnd := offset + frameSamples
-		if chunkEnd > len(samples) {
-			chunkEnd = len(samples)
-		}
-
-		chunk := make([]float32, frameSamples)
-		for i, sample := range samples[offset:chunkEnd] {
-			chunk[i] = float32(sample) / float32(math.MaxInt16)
-		}
-
-		frame.SetPts(int64(pts))
-		if err := frame.SetFloat32(0, chunk); err != nil {
+	// The encoder re-chunks the samples to the codec frame size, so the
+	// segment can be sent as a single frame
+	data := make([]float32, len(samples))
+	for i, sample := range samples {
+		data[i] = float32(sample) / float32(math.MaxInt16)
+	}
+	if len(data) > 0 {
+		frame.SetPts(0)
+		if err := frame.SetFloat32(0, data); err != nil {
 			return err
 		}
 		if err := writer.EncodeFrame(0, frame); err != nil {
 			return err
 		}
-		pts += frameSamples
 	}
 
 	if err := writer.EncodeFrame(0, nil); err != nil {
@@ -0,0 +1,124 @@
+package manager
+
+import (
+	"context"
+	"slices"
+
+	// Packages
+	otel "github.com/mutablelogic/go-client/pkg/otel"
+	gomedia "github.com/mutablelogic/go-media"
+	schema "github.com/mutablelogic/go-media/gomedia/schema"
+	metadata "github.com/mutablelogic/go-media/metadata"
+	ffmpeg "github.com/mutablelogic/go-media/pkg/ffmpeg"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
+	attribute "go.opentelemetry.io/otel/attribute"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// ExtractSubtitles decodes the subtitle streams of the input, or the streams
+// in the request, with the language and dispositions of each stream. Text
+// subtitles can then be written as SRT, WebVTT or ASS with
+// SubtitleTrack.Write, and bitmap subtitles have the images. The subtitles
+// are timed from the start of the input.
+func (m *Media) ExtractSubtitles(ctx context.Context, req schema.ExtractSubtitlesRequest) (_ *schema.ExtractSubtitlesResponse, err error) {
+	name := "reader"
+	if named, ok := req.Reader.(metadata.NamedStream); ok {
+		name = named.Name()
+	}
+
+	ctx, endSpan := otel.StartSpan(m.tracer, ctx, "ExtractSubtitles",
+		attribute.String("input", name),
+		attribute.IntSlice("streams", req.Streams),
+	)
+	defer func() { endSpan(err) }()
+
+	if req.Reader == nil {
+		return nil, gomedia.ErrBadParameter.With("nil reader")
+	}
+
+	// Open the input
+	reader, err := ffmpeg.NewReader(req.Reader, ffmpeg.OptContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	// Describe the subtitle streams to decode
+	resp := new(schema.ExtractSubtitlesResponse)
+	tracks := make(map[int]*schema.SubtitleTrack)
+	for _, stream := range reader.Streams(gomedia.SUBTITLE) {
+		if len(req.Streams) > 0 && !slices.Contains(req.Streams, stream.Index()) {
+			continue
+		}
+		track := newSubtitleTrack(reader.AVStreams()[stream.Index()])
+		tracks[stream.Index()] = track
+		resp.Tracks = append(resp.Tracks, track)
+	}
+	for _, stream := range req.Streams {
+		if _, exists := tracks[stream]; !exists {
+			return nil, gomedia.ErrNotFound.Withf("subtitle stream %d", stream)
+		}
+	}
+	if len(tracks) == 0 {
+		return nil, gomedia.ErrNotFound.With("no subtitle streams")
+	}
+
+	// Decode the subtitles, timed from the start of the input
+	start := reader.StartTime()
+	mapfn := func(stream int, par *ffmpeg.Par) (*ffmpeg.Par, error) {
+		if _, exists := tracks[stream]; exists {
+			return par, nil
+		}
+		return nil, nil
+	}
+	subtitlefn := func(stream int, sub *ff.AVSubtitle) error {
+		track, exists := tracks[stream]
+		if !exists {
+			return nil
+		}
+		subtitle := ffmpeg.NewSubtitle(sub)
+		subtitle.Start -= start
+		if subtitle.End != 0 {
+			subtitle.End -= start
+		}
+		if len(subtitle.Bitmaps) > 0 {
+			track.Bitmap = true
+		}
+		track.Subtitles = append(track.Subtitles, subtitle)
+		return nil
+	}
+	if err := reader.Demux(ctx, mapfn, nil, subtitlefn); err != nil {
+		return nil, err
+	}
+
+	// Return the tracks
+	return resp, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+func newSubtitleTrack(stream *ff.AVStream) *schema.SubtitleTrack {
+	par := stream.CodecPar()
+	track := &schema.SubtitleTrack{
+		Stream:          stream.Index(),
+		Codec:           par.CodecID().Name(),
+		Forced:          stream.Disposition().Is(ff.AV_DISPOSITION_FORCED),
+		HearingImpaired: stream.Disposition().Is(ff.AV_DISPOSITION_HEARING_IMPAIRED),
+	}
+	if entry := ff.AVUtil_dict_get(stream.Metadata(), "language", nil, 0); entry != nil {
+		track.Language = entry.Value()
+	}
+	if entry := ff.AVUtil_dict_get(stream.Metadata(), "title", nil, 0); entry != nil {
+		track.Title = entry.Value()
+	}
+
+	// ASS streams have the script info and styles in the extradata
+	switch par.CodecID() {
+	case ff.AV_CODEC_ID_ASS, ff.AV_CODEC_ID_SSA:
+		track.Header = string(par.Extradata())
+	}
+	return track
+}
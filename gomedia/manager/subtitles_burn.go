@@ -0,0 +1,330 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	// Packages
+	otel "github.com/mutablelogic/go-client/pkg/otel"
+	gomedia "github.com/mutablelogic/go-media"
+	schema "github.com/mutablelogic/go-media/gomedia/schema"
+	metadata "github.com/mutablelogic/go-media/metadata"
+	ffmpeg "github.com/mutablelogic/go-media/pkg/ffmpeg"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
+	attribute "go.opentelemetry.io/otel/attribute"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// subtitleBurn re-encodes an input with subtitles drawn on the video
+type subtitleBurn struct {
+	reader  *ffmpeg.Reader
+	format  string
+	oformat *ff.AVOutputFormat
+	writer  *ffmpeg.Writer
+	filter  *ffmpeg.Filter
+	spec    string                      // Filter which draws the subtitles
+	streams map[int]*subtitleBurnStream // Output streams, by input stream index
+	start   time.Duration               // Start time of the input
+	resp    schema.BurnSubtitlesResponse
+}
+
+type subtitleBurnStream struct {
+	t    gomedia.Type
+	out  int           // Output stream index
+	par  *ffmpeg.Par   // Parameters of the encoder
+	tb   ff.AVRational // Timebase of the encoded frames
+	next int64         // Earliest timestamp of the next frame, in the timebase
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// BurnSubtitles draws text subtitles on the video of the input, and writes
+// it to w with the audio. The subtitles are the first text subtitle stream
+// of the subtitle file, or the stream in the request, and are drawn with
+// the styles of ASS subtitles. The video and audio are re-encoded with the
+// default codecs of the output format.
+//
+// Bitmap subtitles, such as DVD or PGS subtitles, cannot be burned in.
+// FFmpeg needs to be built with libass for the subtitles filter.
+func (m *Media) BurnSubtitles(ctx context.Context, w io.Writer, req schema.BurnSubtitlesRequest) (_ *schema.BurnSubtitlesResponse, err error) {
+	name := "reader"
+	if named, ok := req.Reader.(metadata.NamedStream); ok {
+		name = named.Name()
+	}
+
+	ctx, endSpan := otel.StartSpan(m.tracer, ctx, "BurnSubtitles",
+		attribute.String("input", name),
+		attribute.IntSlice("streams", req.Streams),
+	)
+	defer func() { endSpan(err) }()
+
+	if req.Reader == nil || req.Subtitles == nil || w == nil {
+		return nil, gomedia.ErrBadParameter.With("nil reader, subtitles or writer")
+	}
+	if ff.AVFilter_get_by_name("subtitles") == nil {
+		return nil, gomedia.ErrNotImplemented.With("subtitles filter is not available")
+	}
+
+	// Decode the subtitles, and use the first text subtitle stream
+	subtitles, err := m.ExtractSubtitles(ctx, schema.ExtractSubtitlesRequest{Reader: req.Subtitles, Streams: req.Streams})
+	if err != nil {
+		return nil, err
+	}
+	var track *schema.SubtitleTrack
+	for _, t := range subtitles.Tracks {
+		if !t.Bitmap && len(t.Subtitles) > 0 {
+			track = t
+			break
+		}
+	}
+	if track == nil {
+		return nil, gomedia.ErrNotImplemented.With("no text subtitles to burn in")
+	}
+
+	// The filter reads the subtitles from a file, which is written as ASS
+	// to keep their styles
+	dir, err := os.MkdirTemp("", "gomedia-subtitles-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "subtitles.ass")
+	if err := writeSubtitleFile(path, track); err != nil {
+		return nil, err
+	}
+
+	// Open the input, with the video upright
+	b := &subtitleBurn{
+		streams: make(map[int]*subtitleBurnStream),
+		spec:    "subtitles=filename=" + filterEscape(filepath.ToSlash(path)),
+	}
+	defer b.free()
+	reader, err := ffmpeg.NewReader(req.Reader, ffmpeg.OptContext(ctx), ffmpeg.OptAutorotate())
+	if err != nil {
+		return nil, err
+	}
+	b.reader = reader
+	b.start = reader.StartTime()
+
+	// The output has the same format as the input, unless set, with the
+	// best video and audio streams of the input
+	if b.format, b.oformat, err = outputFormat(req.Format, reader); err != nil {
+		return nil, err
+	}
+	for _, t := range []gomedia.Type{gomedia.VIDEO, gomedia.AUDIO} {
+		if stream := reader.BestStream(t); stream >= 0 {
+			b.streams[stream] = &subtitleBurnStream{t: t, out: len(b.streams)}
+		} else if t == gomedia.VIDEO {
+			return nil, gomedia.ErrBadParameter.With("no video stream")
+		}
+	}
+
+	// Draw the subtitles and encode the frames, then write the trailer
+	err = b.transcode(ctx, w)
+	if b.writer != nil {
+		err = errors.Join(err, b.writer.Close())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Return the subtitle stream which was burned in
+	b.resp.Subtitles = len(track.Subtitles)
+	b.resp.Track = track
+	b.resp.Track.Subtitles = nil
+	return &b.resp, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// Close the input and the filter
+func (b *subtitleBurn) free() {
+	if b.filter != nil {
+		b.filter.Close()
+	}
+	if b.reader != nil {
+		b.reader.Close()
+	}
+}
+
+// Decode the frames of the input, draw the subtitles on the video and
+// encode them. The output is created when the first frame is decoded, when
+// the parameters are known.
+func (b *subtitleBurn) transcode(ctx context.Context, w io.Writer) error {
+	mapfn := func(stream int, par *ffmpeg.Par) (*ffmpeg.Par, error) {
+		s, exists := b.streams[stream]
+		if !exists {
+			return nil, nil
+		}
+		dest, err := encoderPar(b.oformat, b.reader.AVStreams()[stream], par)
+		if err != nil {
+			return nil, err
+		}
+		s.par = dest
+		return dest, nil
+	}
+	framefn := func(stream int, frame *ffmpeg.Frame) error {
+		s, exists := b.streams[stream]
+		if !exists {
+			return nil
+		}
+		if b.writer == nil {
+			if err := b.create(ctx, w); err != nil {
+				return err
+			}
+		}
+		return b.encode(stream, s, frame)
+	}
+	if err := b.reader.Demux(ctx, mapfn, framefn, nil); err != nil {
+		return err
+	}
+	if b.writer == nil {
+		return gomedia.ErrBadParameter.With("no frames were decoded")
+	}
+
+	// Flush the filter and the encoders
+	for _, s := range b.streams {
+		if s.t == gomedia.VIDEO {
+			if err := b.filter.Process(nil, func(frame *ffmpeg.Frame) error {
+				return b.write(s, frame)
+			}); err != nil {
+				return err
+			}
+		}
+		if err := b.writer.EncodeFrame(s.out, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Create the output with the streams, metadata and chapters of the input,
+// and the filter which draws the subtitles
+func (b *subtitleBurn) create(ctx context.Context, w io.Writer) error {
+	opts := []ffmpeg.Opt{ffmpeg.OptContext(ctx), ffmpeg.OptOutputFormat(b.format)}
+	for stream, s := range b.streams {
+		if s.par == nil {
+			return gomedia.ErrInternalError.Withf("no parameters for stream %d", stream)
+		}
+		opts = append(opts, ffmpeg.OptStream(s.out+1, s.par))
+	}
+
+	// Copy the metadata, artwork and chapters
+	for _, entry := range b.reader.Metadata() {
+		opts = append(opts, ffmpeg.OptMetadata(entry))
+	}
+	for _, entry := range b.reader.Metadata(ffmpeg.MetaArtwork) {
+		if entry.Key() == ffmpeg.MetaArtwork {
+			opts = append(opts, ffmpeg.OptMetadata(entry))
+		}
+	}
+	for _, chapter := range b.reader.Chapters() {
+		opts = append(opts, ffmpeg.OptChapters(chapter))
+	}
+
+	// Create the output
+	writer, err := ffmpeg.NewWriter(w, opts...)
+	if err != nil {
+		return err
+	}
+	b.writer = writer
+
+	// Frames are encoded at the timebase of the encoder, and the subtitles
+	// are drawn on frames with the parameters of the video encoder
+	for _, s := range b.streams {
+		s.tb = writer.Stream(s.out).Par().TimeBase()
+		if s.t == gomedia.VIDEO {
+			filter, err := ffmpeg.NewFilter(b.spec, s.par, s.par)
+			if err != nil {
+				return err
+			}
+			b.filter = filter
+		}
+	}
+
+	// Return success
+	return nil
+}
+
+// Encode a frame, timed from the start of the input, drawing the subtitles
+// on video frames
+func (b *subtitleBurn) encode(stream int, s *subtitleBurnStream, frame *ffmpeg.Frame) error {
+	tb := frame.TimeBase()
+	if tb.Num() == 0 || tb.Den() == 0 {
+		tb = b.reader.AVStreams()[stream].TimeBase()
+	}
+	ts := frame.Pts()
+	if ts == int64(ff.AV_NOPTS_VALUE) {
+		ts = (*ff.AVFrame)(frame).BestEffortTimestamp()
+	}
+	pts := s.next
+	if ts != int64(ff.AV_NOPTS_VALUE) {
+		pts = durationToTs(tsToDuration(ts, tb)-b.start, s.tb)
+	}
+
+	switch s.t {
+	case gomedia.VIDEO:
+		// Drop frames which would be shown at the same time as the last, and
+		// let the encoder choose the picture type
+		if pts < s.next {
+			return nil
+		}
+		s.next = pts + 1
+		frame.SetPts(pts)
+		(*ff.AVFrame)(frame).SetTimeBase(s.tb)
+		(*ff.AVFrame)(frame).SetPictType(ff.AV_PICTURE_TYPE_NONE)
+		return b.filter.Process(frame, func(frame *ffmpeg.Frame) error {
+			return b.write(s, frame)
+		})
+	default:
+		// Audio follows on from the last samples
+		pts = max(pts, s.next)
+		s.next = pts + ff.AVUtil_rational_rescale_q(int64(frame.NumSamples()), ff.AVUtil_rational(1, frame.SampleRate()), s.tb)
+		frame.SetPts(pts)
+		(*ff.AVFrame)(frame).SetTimeBase(s.tb)
+		return b.write(s, frame)
+	}
+}
+
+// Encode a frame which is timed in the timebase of the encoder
+func (b *subtitleBurn) write(s *subtitleBurnStream, frame *ffmpeg.Frame) error {
+	if frame == nil {
+		return nil
+	}
+	if err := b.writer.EncodeFrame(s.out, frame); err != nil {
+		return err
+	}
+	end := s.next
+	if s.t == gomedia.VIDEO {
+		end = frame.Pts() + 1
+	}
+	b.resp.Duration = max(b.resp.Duration, tsToDuration(end, s.tb))
+	b.resp.Frames++
+	return nil
+}
+
+// Write the subtitles of a track to a file in ASS format
+func writeSubtitleFile(path string, track *schema.SubtitleTrack) error {
+	w, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	return errors.Join(track.Write(w, "ass"), w.Close())
+}
+
+// Escape a filter option value in a filter graph, first for the option
+// parser and then for the filter graph parser, so the value can contain
+// any character
+func filterEscape(value string) string {
+	value = strings.NewReplacer(`\`, `\\`, `'`, `\'`, `:`, `\:`).Replace(value)
+	return strings.NewReplacer(`\`, `\\`, `'`, `\'`, `[`, `\[`, `]`, `\]`, `,`, `\,`, `;`, `\;`).Replace(value)
+}
@@ -0,0 +1,406 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"time"
+
+	// Packages
+	otel "github.com/mutablelogic/go-client/pkg/otel"
+	gomedia "github.com/mutablelogic/go-media"
+	schema "github.com/mutablelogic/go-media/gomedia/schema"
+	metadata "github.com/mutablelogic/go-media/metadata"
+	ffmpeg "github.com/mutablelogic/go-media/pkg/ffmpeg"
+	ffschema "github.com/mutablelogic/go-media/pkg/ffmpeg/schema"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
+	attribute "go.opentelemetry.io/otel/attribute"
+	language "golang.org/x/text/language"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+var (
+	// ISO 639-2/B codes of the languages where they differ from the
+	// ISO 639-2/T codes returned by the language package
+	iso639B = map[string]string{
+		"bod": "tib", "ces": "cze", "cym": "wel", "deu": "ger", "ell": "gre",
+		"eus": "baq", "fas": "per", "fra": "fre", "hye": "arm", "isl": "ice",
+		"kat": "geo", "mkd": "mac", "mri": "mao", "msa": "may", "mya": "bur",
+		"nld": "dut", "ron": "rum", "slk": "slo", "sqi": "alb", "zho": "chi",
+	}
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// subtitleMux copies the streams of an input and adds subtitle streams
+type subtitleMux struct {
+	reader  *ffmpeg.Reader
+	writer  *ffmpeg.Writer
+	streams map[int]int // Output stream index, by input stream index
+	inputs  []*subtitleMuxInput
+	pending []*subtitlePacket // Subtitle packets waiting to be written, in presentation order
+	resp    schema.AddSubtitlesResponse
+}
+
+type subtitleMuxInput struct {
+	*schema.SubtitleInput
+	reader *ffmpeg.Reader
+	stream int  // Subtitle stream of the subtitle file
+	out    int  // Output stream index
+	encode bool // Subtitles are encoded for the output format, rather than copied
+	track  *schema.SubtitleTrack
+}
+
+type subtitlePacket struct {
+	*ff.AVPacket
+	ts time.Duration // Presentation time
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// AddSubtitles copies the audio, video and subtitle streams of the input to
+// w, and adds a subtitle stream for each subtitle file, with the language
+// and dispositions of the file. Subtitles are copied when the output format
+// can store them, for example SubRip, WebVTT or ASS in Matroska, and are
+// otherwise encoded with the subtitle codec of the format, such as mov_text
+// for MP4 or WebVTT for HLS.
+//
+// The subtitles are timed from the start of the input. The metadata,
+// artwork and chapters of the input are copied.
+func (m *Media) AddSubtitles(ctx context.Context, w io.Writer, req schema.AddSubtitlesRequest) (_ *schema.AddSubtitlesResponse, err error) {
+	name := "reader"
+	if named, ok := req.Reader.(metadata.NamedStream); ok {
+		name = named.Name()
+	}
+
+	ctx, endSpan := otel.StartSpan(m.tracer, ctx, "AddSubtitles",
+		attribute.String("input", name),
+		attribute.Int("subtitles", len(req.Subtitles)),
+	)
+	defer func() { endSpan(err) }()
+
+	if req.Reader == nil || w == nil {
+		return nil, gomedia.ErrBadParameter.With("nil reader or writer")
+	}
+	if len(req.Subtitles) == 0 {
+		return nil, gomedia.ErrBadParameter.With("no subtitles")
+	}
+	for _, in := range req.Subtitles {
+		if in == nil || in.Reader == nil {
+			return nil, gomedia.ErrBadParameter.With("nil subtitle reader")
+		}
+	}
+
+	// Open the input
+	mux := &subtitleMux{streams: make(map[int]int)}
+	defer mux.free()
+	reader, err := ffmpeg.NewReader(req.Reader, ffmpeg.OptContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	mux.reader = reader
+
+	// The output has the same format as the input, unless set
+	format, oformat, err := outputFormat(req.Format, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	// Copy the audio, video and subtitle streams, then add the subtitles
+	for _, stream := range reader.Streams(gomedia.ANY) {
+		switch stream.Type() {
+		case gomedia.AUDIO, gomedia.VIDEO, gomedia.SUBTITLE:
+			if stream.Type() == gomedia.SUBTITLE && !ff.AVFormat_query_codec(oformat, stream.CodecPar().CodecID()) {
+				return nil, gomedia.ErrBadParameter.Withf("format %q cannot store the %s subtitles of stream %d", format, stream.CodecPar().CodecID().Name(), stream.Index())
+			}
+			mux.streams[stream.Index()] = len(mux.streams)
+		}
+	}
+	for n, in := range req.Subtitles {
+		if err := mux.open(ctx, oformat, len(mux.streams)+n, in); err != nil {
+			return nil, fmt.Errorf("subtitles %d: %w", n+1, err)
+		}
+	}
+	if err := mux.create(ctx, w, format); err != nil {
+		return nil, err
+	}
+
+	// Read the subtitles, then copy the packets of the input with the
+	// subtitles in between, and write the trailer
+	for _, in := range mux.inputs {
+		if err := mux.read(ctx, in); err != nil {
+			return nil, err
+		}
+	}
+	if err := errors.Join(mux.copy(ctx), mux.writer.Close()); err != nil {
+		return nil, err
+	}
+
+	// Return the subtitle streams
+	for _, in := range mux.inputs {
+		mux.resp.Tracks = append(mux.resp.Tracks, in.track)
+	}
+	return &mux.resp, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// Open a subtitle file, which becomes the output stream out
+func (mux *subtitleMux) open(ctx context.Context, oformat *ff.AVOutputFormat, out int, in *schema.SubtitleInput) error {
+	reader, err := ffmpeg.NewReader(in.Reader, ffmpeg.OptContext(ctx))
+	if err != nil {
+		return err
+	}
+	input := &subtitleMuxInput{
+		SubtitleInput: in,
+		reader:        reader,
+		out:           out,
+	}
+	mux.inputs = append(mux.inputs, input)
+
+	// Use the best subtitle stream, which is copied when the format can
+	// store it and otherwise encoded
+	if input.stream = reader.BestStream(gomedia.SUBTITLE); input.stream < 0 {
+		return gomedia.ErrBadParameter.With("no subtitle stream")
+	}
+	codec := reader.AVStreams()[input.stream].CodecPar().CodecID()
+	if !ff.AVFormat_query_codec(oformat, codec) {
+		if codec = oformat.SubtitleCodec(); codec == ff.AV_CODEC_ID_NONE {
+			return gomedia.ErrBadParameter.Withf("format %q cannot store subtitles", oformat.Name())
+		}
+		input.encode = true
+	}
+
+	// Describe the output stream
+	input.track = &schema.SubtitleTrack{
+		Stream:          out,
+		Codec:           codec.Name(),
+		Language:        subtitleLanguage(in.Language),
+		Title:           in.Title,
+		Forced:          in.Forced,
+		HearingImpaired: in.HearingImpaired,
+	}
+
+	// Return success
+	return nil
+}
+
+// Close the inputs and release the packets which were not written
+func (mux *subtitleMux) free() {
+	for _, pkt := range mux.pending {
+		ff.AVCodec_packet_free(pkt.AVPacket)
+	}
+	mux.pending = nil
+	for _, in := range mux.inputs {
+		in.reader.Close()
+	}
+	mux.inputs = nil
+	if mux.reader != nil {
+		mux.reader.Close()
+	}
+}
+
+// Create the output with the streams, metadata and chapters of the input,
+// and the subtitle streams
+func (mux *subtitleMux) create(ctx context.Context, w io.Writer, format string) error {
+	opts := []ffmpeg.Opt{ffmpeg.OptCopy(), ffmpeg.OptContext(ctx), ffmpeg.OptOutputFormat(format)}
+	for _, stream := range mux.reader.AVStreams() {
+		out, exists := mux.streams[stream.Index()]
+		if !exists {
+			continue
+		}
+		opts = append(opts, ffmpeg.OptStream(out+1, &ffmpeg.Par{AVCodecParameters: *stream.CodecPar()}))
+		opts = append(opts, ffmpeg.OptDisposition(out+1, stream.Disposition()))
+		for _, key := range []string{"language", "title"} {
+			if entry := ff.AVUtil_dict_get(stream.Metadata(), key, nil, 0); entry != nil {
+				opts = append(opts, ffmpeg.OptStreamMetadata(out+1, ffmpeg.NewMetadata(key, entry.Value())))
+			}
+		}
+	}
+
+	// Add the subtitle streams, which are encoded by the writer when the
+	// format cannot store them
+	for _, in := range mux.inputs {
+		stream := in.reader.AVStreams()[in.stream]
+		opts = append(opts, ffmpeg.OptStream(in.out+1, &ffmpeg.Par{AVCodecParameters: *stream.CodecPar()}))
+		var disposition ff.AVDisposition
+		if in.Default {
+			disposition |= ff.AV_DISPOSITION_DEFAULT
+		}
+		if in.Forced {
+			disposition |= ff.AV_DISPOSITION_FORCED
+		}
+		if in.HearingImpaired {
+			disposition |= ff.AV_DISPOSITION_HEARING_IMPAIRED
+		}
+		opts = append(opts, ffmpeg.OptDisposition(in.out+1, disposition))
+		if in.track.Language != "" {
+			opts = append(opts, ffmpeg.OptStreamMetadata(in.out+1, ffmpeg.NewMetadata("language", in.track.Language)))
+		}
+		if in.track.Title != "" {
+			opts = append(opts, ffmpeg.OptStreamMetadata(in.out+1, ffmpeg.NewMetadata("title", in.track.Title)))
+		}
+	}
+
+	// Copy the metadata, artwork and chapters
+	for _, entry := range mux.reader.Metadata() {
+		opts = append(opts, ffmpeg.OptMetadata(entry))
+	}
+	for _, entry := range mux.reader.Metadata(ffmpeg.MetaArtwork) {
+		if entry.Key() == ffmpeg.MetaArtwork {
+			opts = append(opts, ffmpeg.OptMetadata(entry))
+		}
+	}
+	for _, chapter := range mux.reader.Chapters() {
+		opts = append(opts, ffmpeg.OptChapters(chapter))
+	}
+
+	// Create the output
+	writer, err := ffmpeg.NewWriter(w, opts...)
+	if err != nil {
+		return err
+	}
+	mux.writer = writer
+
+	// Return success
+	return nil
+}
+
+// Read the packets of a subtitle file, encoding the subtitles when the
+// format cannot store them, and keep them to write with the packets of
+// the input
+func (mux *subtitleMux) read(ctx context.Context, in *subtitleMuxInput) error {
+	start := mux.reader.StartTime()
+	if !in.encode {
+		tb := in.reader.AVStreams()[in.stream].TimeBase()
+		return in.reader.Decode(ctx, func(stream int, pkt *ffmpeg.Packet) error {
+			if stream != in.stream {
+				return nil
+			}
+			pkt.SetTimeBase(tb)
+			return mux.keep(in, pkt.AVPacket, start)
+		})
+	}
+
+	// Decode the subtitles and encode them for the format
+	encoder := mux.writer.Stream(in.out)
+	if encoder == nil {
+		return gomedia.ErrInternalError.Withf("no encoder for stream %d", in.out)
+	}
+	mapfn := func(stream int, par *ffmpeg.Par) (*ffmpeg.Par, error) {
+		if stream != in.stream {
+			return nil, nil
+		}
+		return par, nil
+	}
+	subtitlefn := func(_ int, sub *ff.AVSubtitle) error {
+		return encoder.EncodeSubtitle(sub, func(pkt *ffmpeg.Packet) error {
+			if pkt == nil {
+				return nil
+			}
+			return mux.keep(in, pkt.AVPacket, start)
+		})
+	}
+	return in.reader.Demux(ctx, mapfn, nil, subtitlefn)
+}
+
+// Keep a copy of a subtitle packet, moved to the start of the input
+func (mux *subtitleMux) keep(in *subtitleMuxInput, src *ff.AVPacket, start time.Duration) error {
+	if src.Pts() == int64(ff.AV_NOPTS_VALUE) {
+		return nil
+	}
+	pkt := ff.AVCodec_packet_clone(src)
+	if pkt == nil {
+		return errors.New("failed to allocate packet")
+	}
+	tb := pkt.TimeBase()
+	offset := durationToTs(start, tb)
+	pkt.SetPts(pkt.Pts() + offset)
+	pkt.SetDts(pkt.Pts())
+	pkt.SetPos(-1)
+	pkt.SetStreamIndex(in.out)
+
+	// Keep the packets in presentation order
+	ts := tsToDuration(pkt.Pts(), tb)
+	i, _ := slices.BinarySearchFunc(mux.pending, ts, func(p *subtitlePacket, ts time.Duration) int {
+		if p.ts <= ts {
+			return -1
+		}
+		return 1
+	})
+	mux.pending = slices.Insert(mux.pending, i, &subtitlePacket{AVPacket: pkt, ts: ts})
+	mux.resp.Subtitles++
+	return nil
+}
+
+// Copy the packets of the input, writing the subtitles which are shown
+// before each packet
+func (mux *subtitleMux) copy(ctx context.Context) error {
+	if err := mux.reader.Decode(ctx, func(stream int, pkt *ffmpeg.Packet) error {
+		out, exists := mux.streams[stream]
+		if !exists {
+			return nil
+		}
+		tb := mux.reader.AVStreams()[stream].TimeBase()
+		ts := pkt.Dts()
+		if ts == int64(ff.AV_NOPTS_VALUE) {
+			ts = pkt.Pts()
+		}
+		if ts != int64(ff.AV_NOPTS_VALUE) {
+			if err := mux.flush(tsToDuration(ts, tb)); err != nil {
+				return err
+			}
+		}
+
+		// Write the packet
+		pkt.SetPos(-1)
+		pkt.SetStreamIndex(out)
+		pkt.SetTimeBase(tb)
+		if err := mux.writer.Write(pkt); err != nil {
+			return err
+		}
+		mux.resp.Packets++
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// Write the subtitles after the end of the input
+	return mux.flush(math.MaxInt64)
+}
+
+// Write the subtitle packets which are shown at or before a time
+func (mux *subtitleMux) flush(until time.Duration) error {
+	for len(mux.pending) > 0 && mux.pending[0].ts <= until {
+		pkt := mux.pending[0]
+		mux.pending = mux.pending[1:]
+		err := mux.writer.Write(ffschema.NewPacket(pkt.AVPacket))
+		ff.AVCodec_packet_free(pkt.AVPacket)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Return the ISO 639-2/B code of a language, such as "eng" for "en" or "ger"
+// for "de", which is how MP4 and Matroska store it
+func subtitleLanguage(lang string) string {
+	if tag, err := language.Parse(lang); err == nil {
+		if base, confidence := tag.Base(); confidence != language.No {
+			if code, exists := iso639B[base.ISO3()]; exists {
+				return code
+			}
+			return base.ISO3()
+		}
+	}
+	return lang
+}
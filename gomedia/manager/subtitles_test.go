@@ -0,0 +1,244 @@
+package manager_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	// Packages
+	gomedia "github.com/mutablelogic/go-media"
+	schema "github.com/mutablelogic/go-media/gomedia/schema"
+	test "github.com/mutablelogic/go-media/gomedia/test"
+	ffmpeg "github.com/mutablelogic/go-media/pkg/ffmpeg"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
+)
+
+const testSRT = "1\n00:00:01,000 --> 00:00:02,500\nHello\nworld\n\n" +
+	"2\n00:00:03,000 --> 00:00:04,000\nGoodbye\n\n"
+
+func TestExtractSubtitles_SRT(t *testing.T) {
+	m, ctx := test.Begin(t)
+
+	path := filepath.Join(t.TempDir(), "sample.srt")
+	if err := os.WriteFile(path, []byte(testSRT), 0644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	resp, err := m.ExtractSubtitles(ctx, schema.ExtractSubtitlesRequest{Reader: f})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Tracks) != 1 {
+		t.Fatalf("expected one track, got %d", len(resp.Tracks))
+	}
+	track := resp.Tracks[0]
+	if track.Bitmap {
+		t.Fatal("expected text subtitles")
+	}
+	if len(track.Subtitles) != 2 {
+		t.Fatalf("expected two subtitles, got %d", len(track.Subtitles))
+	}
+	if s := track.Subtitles[0]; s.Start != time.Second || s.End != 2500*time.Millisecond || s.Text != "Hello\nworld" {
+		t.Fatalf("unexpected first subtitle: %v to %v %q", s.Start, s.End, s.Text)
+	}
+
+	// Write the subtitles as WebVTT
+	var buf bytes.Buffer
+	if err := track.Write(&buf, "vtt"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "00:00:03.000 --> 00:00:04.000\nGoodbye\n") {
+		t.Fatalf("unexpected WebVTT: %q", buf.String())
+	}
+}
+
+func TestExtractSubtitles_StartTime(t *testing.T) {
+	m, ctx := test.Begin(t)
+
+	// The video and subtitles are copied to a file which starts at ten seconds.
+	// Text subtitles cannot be carried in MPEG-TS, so the file is Matroska.
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sample.srt")
+	if err := os.WriteFile(path, []byte(testSRT), 0644); err != nil {
+		t.Fatal(err)
+	}
+	out := remuxOffset(t, filepath.Join(dir, "sample.mkv"), 10*time.Second, filepath.Join("..", "..", "etc", "test", "sample.mp4"), path)
+	f, err := os.Open(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	// The subtitles are timed from the start of the file
+	resp, err := m.ExtractSubtitles(ctx, schema.ExtractSubtitlesRequest{Reader: f})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Tracks) != 1 || len(resp.Tracks[0].Subtitles) != 2 {
+		t.Fatalf("expected one track with two subtitles, got %v", resp.Tracks)
+	}
+	if s := resp.Tracks[0].Subtitles[0]; s.Start != time.Second || s.End != 2500*time.Millisecond {
+		t.Fatalf("unexpected first subtitle: %v to %v", s.Start, s.End)
+	}
+}
+
+func TestExtractSubtitles_NoSubtitles(t *testing.T) {
+	m, ctx := test.Begin(t)
+
+	f, err := os.Open(filepath.Join("..", "..", "etc", "test", "sample.mp3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if _, err := m.ExtractSubtitles(ctx, schema.ExtractSubtitlesRequest{Reader: f}); !errors.Is(err, gomedia.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestExtractSubtitles_BadParameters(t *testing.T) {
+	m, ctx := test.Begin(t)
+
+	if _, err := m.ExtractSubtitles(ctx, schema.ExtractSubtitlesRequest{}); !errors.Is(err, gomedia.ErrBadParameter) {
+		t.Fatalf("expected ErrBadParameter, got %v", err)
+	}
+
+	track := &schema.SubtitleTrack{}
+	if err := track.Write(&bytes.Buffer{}, "txt"); !errors.Is(err, gomedia.ErrBadParameter) {
+		t.Fatalf("expected ErrBadParameter, got %v", err)
+	}
+}
+
+func TestAddSubtitles_MP4(t *testing.T) {
+	m, ctx := test.Begin(t)
+
+	r, err := os.Open(filepath.Join("..", "..", "etc", "test", "sample.mp4"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	// Add German subtitles to a copy of the file
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sample.de.srt")
+	if err := os.WriteFile(path, []byte(testSRT), 0644); err != nil {
+		t.Fatal(err)
+	}
+	subtitles, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer subtitles.Close()
+
+	out := filepath.Join(dir, "sample.mp4")
+	w, err := os.Create(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := m.AddSubtitles(ctx, w, schema.AddSubtitlesRequest{
+		Reader:    r,
+		Subtitles: []*schema.SubtitleInput{{Reader: subtitles, Language: "de", Default: true}},
+		Format:    out,
+	})
+	if err := errors.Join(err, w.Close()); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Subtitles != 2 || resp.Packets == 0 {
+		t.Fatalf("expected two subtitles and some packets, got %d and %d", resp.Subtitles, resp.Packets)
+	}
+
+	// The subtitles are stored as mov_text, with the bibliographic language code
+	f, err := os.Open(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	extract, err := m.ExtractSubtitles(ctx, schema.ExtractSubtitlesRequest{Reader: f})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(extract.Tracks) != 1 {
+		t.Fatalf("expected one track, got %d", len(extract.Tracks))
+	}
+	track := extract.Tracks[0]
+	if track.Codec != "mov_text" || track.Language != "ger" {
+		t.Fatalf("unexpected track: %s %q", track.Codec, track.Language)
+	}
+	if len(track.Subtitles) != 2 || track.Subtitles[1].Text != "Goodbye" {
+		t.Fatalf("unexpected subtitles: %v", track.Subtitles)
+	}
+}
+
+func TestAddSubtitles_BadParameters(t *testing.T) {
+	m, ctx := test.Begin(t)
+
+	if _, err := m.AddSubtitles(ctx, &bytes.Buffer{}, schema.AddSubtitlesRequest{}); !errors.Is(err, gomedia.ErrBadParameter) {
+		t.Fatalf("expected ErrBadParameter, got %v", err)
+	}
+	if _, err := m.BurnSubtitles(ctx, &bytes.Buffer{}, schema.BurnSubtitlesRequest{}); !errors.Is(err, gomedia.ErrBadParameter) {
+		t.Fatalf("expected ErrBadParameter, got %v", err)
+	}
+}
+
+// Copy the streams of the inputs to one output, with the timestamps of the
+// packets moved on by the offset, and return the path of the output
+func remuxOffset(t *testing.T, out string, offset time.Duration, paths ...string) string {
+	t.Helper()
+
+	var readers []*ffmpeg.Reader
+	var streams int
+	opts := []ffmpeg.Opt{ffmpeg.OptCopy()}
+	for _, path := range paths {
+		reader, err := ffmpeg.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer reader.Close()
+		for _, stream := range reader.AVStreams() {
+			par := ff.AVCodec_parameters_alloc()
+			defer ff.AVCodec_parameters_free(par)
+			if err := ff.AVCodec_parameters_copy(par, stream.CodecPar()); err != nil {
+				t.Fatal(err)
+			}
+			streams++
+			opts = append(opts, ffmpeg.OptStream(streams, &ffmpeg.Par{AVCodecParameters: *par}))
+		}
+		readers = append(readers, reader)
+	}
+
+	writer, err := ffmpeg.Create(out, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := 0
+	for _, reader := range readers {
+		streams := reader.AVStreams()
+		err = errors.Join(err, reader.Decode(t.Context(), func(i int, pkt *ffmpeg.Packet) error {
+			tb := streams[i].TimeBase()
+			ts := ff.AVUtil_rational_rescale_q(int64(offset), ff.AVUtil_rational(1, int(time.Second)), tb)
+			if pts := pkt.Pts(); pts != int64(ff.AV_NOPTS_VALUE) {
+				pkt.SetPts(pts + ts)
+			}
+			if dts := pkt.Dts(); dts != int64(ff.AV_NOPTS_VALUE) {
+				pkt.SetDts(dts + ts)
+			}
+			pkt.SetTimeBase(tb)
+			pkt.SetStreamIndex(base + i)
+			return writer.Write(pkt)
+		}))
+		base += len(streams)
+	}
+	if err := errors.Join(err, writer.Close()); err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
@@ -0,0 +1,21 @@
+package manager
+
+import (
+	"time"
+
+	// Packages
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// Convert a timestamp in a timebase to a time
+func tsToDuration(ts int64, tb ff.AVRational) time.Duration {
+	return time.Duration(ff.AVUtil_rational_rescale_q(ts, tb, ff.AVUtil_rational(1, int(time.Second))))
+}
+
+// Convert a time to a timestamp in a timebase
+func durationToTs(t time.Duration, tb ff.AVRational) int64 {
+	return ff.AVUtil_rational_rescale_q(int64(t), ff.AVUtil_rational(1, int(time.Second)), tb)
+}
@@ -0,0 +1,615 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	// Packages
+	otel "github.com/mutablelogic/go-client/pkg/otel"
+	gomedia "github.com/mutablelogic/go-media"
+	schema "github.com/mutablelogic/go-media/gomedia/schema"
+	metadata "github.com/mutablelogic/go-media/metadata"
+	ffmpeg "github.com/mutablelogic/go-media/pkg/ffmpeg"
+	ffschema "github.com/mutablelogic/go-media/pkg/ffmpeg/schema"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
+	attribute "go.opentelemetry.io/otel/attribute"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// trim is the plan for a cut, where times are timestamps in the input
+type trim struct {
+	reader  *ffmpeg.Reader
+	writer  *ffmpeg.Writer
+	streams map[int]*trimStream // Streams to copy, by input index
+	seekTo  int                 // Input index of the stream to seek on
+	video   int                 // Input index of the video stream which is cut at keyframes, or -1
+	smart   bool                // Re-encode the video at the cut points
+	start   time.Duration       // Start of the cut
+	end     time.Duration       // End of the cut
+	origin  time.Duration       // Input time which is written at zero
+	first   time.Duration       // Last video keyframe at or before the start
+	next    time.Duration       // First video keyframe at or after the start
+	last    time.Duration       // Last video keyframe before the end
+	stop    time.Duration       // First video keyframe at or after the end
+	over    bool                // There is video after the end
+	from    time.Duration       // Copy video from this keyframe...
+	to      time.Duration       // ...until this keyframe
+	delay   int64               // Presentation delay of the copied video, in the video timebase
+	h264    *h264Config         // How re-encoded video is spliced into the copied video
+	head    bool                // Video is re-encoded before the first copied keyframe
+	pending []*ff.AVPacket      // Re-encoded video packets waiting to be written, in decode order
+	resp    schema.TrimResponse
+}
+
+type trimStream struct {
+	index   int           // Output stream index
+	tb      ff.AVRational // Input timebase
+	t       gomedia.Type  // Stream type
+	started bool          // Video has been copied from a keyframe
+	done    bool          // No more packets are needed
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+const (
+	trimForever = time.Duration(math.MaxInt64)
+	trimNever   = time.Duration(math.MinInt64)
+
+	// Quality of the video which is re-encoded in a smart cut
+	trimCRF = 18
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Trim copies the part of the input between the start and end times to w,
+// without re-encoding. In keyframe mode the cut is widened to the video
+// keyframes at or before the start and at or after the end. In smart mode
+// the cut is exact: the video from the start to the first keyframe, and
+// from the last keyframe to the end, is re-encoded and the video between
+// is copied. Smart cuts are supported for H.264 video. Other streams are
+// cut at packet boundaries.
+//
+// Timestamps are rebased so the output starts at zero, and the metadata,
+// artwork and the chapters within the cut are copied. The input needs to
+// implement io.Seeker, as it is read more than once.
+func (m *Media) Trim(ctx context.Context, w io.Writer, req schema.TrimRequest) (_ *schema.TrimResponse, err error) {
+	name := "reader"
+	if named, ok := req.Reader.(metadata.NamedStream); ok {
+		name = named.Name()
+	}
+
+	ctx, endSpan := otel.StartSpan(m.tracer, ctx, "Trim",
+		attribute.String("input", name),
+		attribute.String("mode", req.Mode),
+		attribute.String("start", req.Start.String()),
+		attribute.String("end", req.End.String()),
+	)
+	defer func() { endSpan(err) }()
+
+	if req.Reader == nil || w == nil {
+		return nil, gomedia.ErrBadParameter.With("nil reader or writer")
+	}
+	if _, ok := req.Reader.(io.Seeker); !ok {
+		return nil, gomedia.ErrBadParameter.With("reader is not seekable")
+	}
+	if req.Start < 0 || req.End < 0 || (req.End != 0 && req.End <= req.Start) {
+		return nil, gomedia.ErrBadParameter.Withf("invalid range %v to %v", req.Start, req.End)
+	}
+	var smart bool
+	switch req.Mode {
+	case "", "keyframe":
+		// Snap to keyframes
+	case "smart":
+		smart = true
+	default:
+		return nil, gomedia.ErrBadParameter.Withf("invalid mode %q", req.Mode)
+	}
+
+	// Open the input
+	reader, err := ffmpeg.NewReader(req.Reader, ffmpeg.OptContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	// Find the keyframes, and re-encode the video at the cut points
+	t, err := newTrim(reader, req.Start, req.End, smart)
+	if err != nil {
+		return nil, err
+	}
+	defer t.free()
+	if err := t.plan(ctx); err != nil {
+		return nil, err
+	}
+
+	// The output has the same format as the input, unless set
+	format := req.Format
+	if format == "" && reader.InputFormat() != nil {
+		format, _, _ = strings.Cut(reader.InputFormat().Name(), ",")
+	}
+	if err := t.create(ctx, w, format); err != nil {
+		return nil, err
+	}
+
+	// Copy the packets and write the trailer
+	if err := errors.Join(t.copy(ctx), t.writer.Close()); err != nil {
+		return nil, err
+	}
+
+	// Return the cut, relative to the start of the input
+	t.resp.Start = t.origin - reader.StartTime()
+	t.resp.End = t.resp.Start + t.resp.Duration
+	return &t.resp, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+func newTrim(reader *ffmpeg.Reader, start, end time.Duration, smart bool) (*trim, error) {
+	offset := reader.StartTime()
+	t := &trim{
+		reader:  reader,
+		streams: make(map[int]*trimStream),
+		seekTo:  -1,
+		video:   -1,
+		smart:   smart,
+		start:   offset + start,
+		end:     trimForever,
+		first:   trimNever,
+		next:    trimForever,
+		last:    trimNever,
+		stop:    trimForever,
+	}
+	if end > 0 {
+		t.end = offset + end
+	}
+
+	// Copy the audio, video and subtitle streams. Artwork is copied with
+	// the metadata.
+	for _, stream := range reader.Streams(gomedia.ANY) {
+		switch stream.Type() {
+		case gomedia.AUDIO, gomedia.VIDEO, gomedia.SUBTITLE:
+			t.streams[stream.Index()] = &trimStream{
+				index: len(t.streams),
+				tb:    stream.TimeBase(),
+				t:     stream.Type(),
+			}
+			if t.seekTo < 0 {
+				t.seekTo = stream.Index()
+			}
+		}
+	}
+	if len(t.streams) == 0 {
+		return nil, gomedia.ErrBadParameter.With("no audio, video or subtitle streams")
+	}
+
+	// The cut is made at the keyframes of the video stream
+	if video := reader.BestStream(gomedia.VIDEO); video >= 0 {
+		if _, exists := t.streams[video]; exists {
+			t.video, t.seekTo = video, video
+		}
+	}
+
+	// The re-encoded video is spliced into the bitstream
+	if smart && t.video >= 0 {
+		par := reader.AVStreams()[t.video].CodecPar()
+		if par.CodecID() != ff.AV_CODEC_ID_H264 {
+			return nil, gomedia.ErrNotImplemented.Withf("smart cut of %v video", par.CodecID())
+		}
+		config, err := newH264Config(par.Extradata())
+		if err != nil {
+			return nil, err
+		}
+		t.h264 = config
+	}
+
+	// Return success
+	return t, nil
+}
+
+// Release any packets which were not written
+func (t *trim) free() {
+	for _, pkt := range t.pending {
+		ff.AVCodec_packet_free(pkt)
+	}
+	t.pending = nil
+}
+
+// Find the video keyframes to copy from and to, and in a smart cut
+// re-encode the video between the cut points and the keyframes
+func (t *trim) plan(ctx context.Context) error {
+	t.origin = t.start
+	if t.video < 0 {
+		return nil
+	}
+	if err := t.scan(ctx); err != nil {
+		return err
+	}
+
+	// Widen the cut to the keyframes
+	if !t.smart {
+		t.from, t.to = t.next, t.stop
+		if t.first != trimNever {
+			t.origin, t.from = t.first, t.first
+		}
+		t.end = t.stop
+		return nil
+	}
+
+	// Re-encode from the start to the first keyframe. Without a keyframe
+	// before the start, there are no pictures to re-encode.
+	t.from, t.to = t.next, t.stop
+	if t.first != trimNever && t.next > t.start {
+		if err := t.encode(ctx, t.start, min(t.next, t.end)); err != nil {
+			return err
+		}
+		t.head = len(t.pending) > 0
+	}
+
+	// Re-encode from the last keyframe to the end, unless the end is a
+	// keyframe or there is no video after it
+	switch {
+	case t.next >= t.end:
+		t.to = t.from
+	case t.over && t.stop != t.end:
+		t.to = t.last
+		if err := t.encode(ctx, t.last, t.end); err != nil {
+			return err
+		}
+	}
+
+	// Return success
+	return nil
+}
+
+// Read the video packets from the keyframe before the start of the cut to
+// the keyframe after the end
+func (t *trim) scan(ctx context.Context) error {
+	if err := t.reader.Seek(t.seekTo, t.start.Seconds()); err != nil {
+		return err
+	}
+	s := t.streams[t.video]
+	return t.reader.Decode(ctx, func(stream int, pkt *ffmpeg.Packet) error {
+		if stream != t.video {
+			return nil
+		}
+		ts, ok := trimPts(s, pkt.AVPacket)
+		if !ok {
+			return nil
+		}
+		if ts >= t.end {
+			t.over = true
+		}
+		if pkt.Flags()&ff.AV_PKT_FLAG_KEY == 0 {
+			return nil
+		}
+
+		// Stop at the first keyframe after the end
+		if ts >= t.end {
+			t.stop = ts
+			return io.EOF
+		}
+		if ts <= t.start {
+			t.first = ts
+		}
+		if ts >= t.start && t.next == trimForever {
+			t.next = ts
+			if pkt.Pts() != int64(ff.AV_NOPTS_VALUE) && pkt.Dts() != int64(ff.AV_NOPTS_VALUE) {
+				t.delay = pkt.Pts() - pkt.Dts()
+			}
+		}
+		t.last = ts
+		return nil
+	})
+}
+
+// Re-encode the video from one time to another, and keep the packets to
+// write with the copied packets
+func (t *trim) encode(ctx context.Context, from, to time.Duration) (err error) {
+	var encoder *ffmpeg.Writer
+	defer func() {
+		if encoder != nil {
+			err = errors.Join(err, encoder.Close())
+		}
+	}()
+
+	// The range ends at the end of the input when to is zero
+	if to == trimForever {
+		to = 0
+	}
+	if err := t.reader.Seek(t.video, from.Seconds()); err != nil {
+		return err
+	}
+
+	// Encode without B-frames, and with the parameter sets in the stream,
+	// at the timebase of the input
+	mapfn := func(stream int, par *ffmpeg.Par) (*ffmpeg.Par, error) {
+		if stream != t.video {
+			return nil, nil
+		}
+		dest := *par
+		dest.SetBitRate(0)
+		dest.SetCRF(trimCRF)
+		dest.SetBFrames(0)
+		writer, err := ffmpeg.NewWriter(io.Discard, ffmpeg.OptOutputFormat("h264"), ffmpeg.OptStream(1, &dest))
+		if err != nil {
+			return nil, err
+		}
+		encoder = writer
+		return par, nil
+	}
+	framefn := func(_ int, frame *ffmpeg.Frame) error {
+		if frame.Pts() == int64(ff.AV_NOPTS_VALUE) {
+			frame.SetPts((*ff.AVFrame)(frame).BestEffortTimestamp())
+		}
+		// Let the encoder choose the picture type, rather than follow the input
+		(*ff.AVFrame)(frame).SetPictType(ff.AV_PICTURE_TYPE_NONE)
+		return encoder.Stream(0).Encode(frame, t.splice)
+	}
+	if err := t.reader.Demux(ctx, mapfn, framefn, nil, ffmpeg.OptRange(from, to)); err != nil {
+		return err
+	}
+	if encoder == nil {
+		return gomedia.ErrInternalError.With("video was not decoded")
+	}
+
+	// Flush the encoder
+	return encoder.Stream(0).Encode(nil, t.splice)
+}
+
+// Keep a re-encoded packet, with the NAL units packed as in the input
+func (t *trim) splice(pkt *ffmpeg.Packet) error {
+	s := t.streams[t.video]
+	out, err := newTrimPacket(pkt.AVPacket, t.h264.pack(annexbNALs(pkt.Bytes())...))
+	if err != nil {
+		return err
+	}
+	if tb := pkt.TimeBase(); tb.Num() != 0 && tb.Den() != 0 {
+		ff.AVCodec_packet_rescale_ts(out, tb, s.tb)
+	}
+	out.SetTimeBase(s.tb)
+
+	// Without B-frames the decode time is the presentation time. It is moved
+	// back by the delay of the copied video, so that decode times increase
+	// across the splice.
+	out.SetDts(out.Pts() - t.delay)
+
+	t.pending = append(t.pending, out)
+	t.resp.Encoded++
+	return nil
+}
+
+// Create the output with the streams, metadata and chapters of the input
+func (t *trim) create(ctx context.Context, w io.Writer, format string) error {
+	opts := []ffmpeg.Opt{ffmpeg.OptCopy(), ffmpeg.OptContext(ctx)}
+	if format != "" {
+		opts = append(opts, ffmpeg.OptOutputFormat(format))
+	}
+	oformat := ff.AVFormat_guess_format(format, format, "")
+	for _, stream := range t.reader.Streams(gomedia.ANY) {
+		s, exists := t.streams[stream.Index()]
+		if !exists {
+			continue
+		}
+		// Subtitles are copied, rather than encoded for the format
+		if s.t == gomedia.SUBTITLE && oformat != nil && !ff.AVFormat_query_codec(oformat, stream.CodecPar().CodecID()) {
+			return gomedia.ErrBadParameter.Withf("format %q cannot store %s subtitles", format, stream.CodecPar().CodecID().Name())
+		}
+		opts = append(opts, ffmpeg.OptStream(s.index+1, &ffmpeg.Par{AVCodecParameters: *stream.CodecPar()}))
+	}
+
+	// Copy the metadata and artwork
+	for _, entry := range t.reader.Metadata() {
+		opts = append(opts, ffmpeg.OptMetadata(entry))
+	}
+	for _, entry := range t.reader.Metadata(ffmpeg.MetaArtwork) {
+		if entry.Key() == ffmpeg.MetaArtwork {
+			opts = append(opts, ffmpeg.OptMetadata(entry))
+		}
+	}
+
+	// Copy the chapters within the cut, relative to the start
+	for _, chapter := range t.reader.Chapters() {
+		if chapter.End <= t.origin || chapter.Start >= t.end {
+			continue
+		}
+		ch := *chapter
+		ch.Start = max(ch.Start, t.origin) - t.origin
+		ch.End = min(ch.End, t.end) - t.origin
+		opts = append(opts, ffmpeg.OptChapters(&ch))
+	}
+
+	// Create the output
+	writer, err := ffmpeg.NewWriter(w, opts...)
+	if err != nil {
+		return err
+	}
+	t.writer = writer
+	return nil
+}
+
+// Copy the packets within the cut to the output, with any re-encoded
+// video packets in decode order
+func (t *trim) copy(ctx context.Context) error {
+	if err := t.reader.Seek(t.seekTo, t.start.Seconds()); err != nil {
+		return err
+	}
+	if video, exists := t.streams[t.video]; exists && t.from >= t.to {
+		video.done = true
+	}
+
+	if err := t.reader.Decode(ctx, func(stream int, pkt *ffmpeg.Packet) error {
+		s, exists := t.streams[stream]
+		if !exists || s.done {
+			return nil
+		}
+		ts, ok := trimPts(s, pkt.AVPacket)
+		if !ok {
+			return nil
+		}
+		key := pkt.Flags()&ff.AV_PKT_FLAG_KEY != 0
+
+		// Video is copied from a keyframe, and the video which is cut at
+		// keyframes stops at a keyframe
+		switch {
+		case stream == t.video:
+			if key && ts >= t.to {
+				s.done = true
+			} else if key && ts >= t.from {
+				s.started = true
+			}
+			if s.done || !s.started {
+				return t.finished()
+			}
+		case s.t == gomedia.VIDEO:
+			if ts >= t.end {
+				s.done = key
+				return t.finished()
+			}
+			if key && ts >= t.origin {
+				s.started = true
+			}
+			if !s.started {
+				return nil
+			}
+		default:
+			if ts >= t.end {
+				s.done = true
+				return t.finished()
+			}
+			if ts < t.origin {
+				return nil
+			}
+		}
+
+		// Write the re-encoded packets which are decoded first
+		if err := t.flush(trimDts(s, pkt.AVPacket)); err != nil {
+			return err
+		}
+
+		// The parameter sets of the input precede the first copied keyframe
+		// after re-encoded video, which has its own parameter sets
+		if stream == t.video && t.head {
+			t.head = false
+			splice, err := newTrimPacket(pkt.AVPacket, append(t.h264.pack(t.h264.params...), pkt.Bytes()...))
+			if err != nil {
+				return err
+			}
+			defer ff.AVCodec_packet_free(splice)
+			pkt = ffschema.NewPacket(splice)
+		}
+
+		// Write the packet
+		if err := t.write(s, pkt.AVPacket); err != nil {
+			return err
+		}
+		t.resp.Packets++
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// Write any remaining re-encoded packets
+	return t.flush(trimForever)
+}
+
+// Return io.EOF when the audio and video streams need no more packets
+func (t *trim) finished() error {
+	for _, s := range t.streams {
+		if !s.done && s.t != gomedia.SUBTITLE {
+			return nil
+		}
+	}
+	return io.EOF
+}
+
+// Write the re-encoded packets which are decoded at or before a time
+func (t *trim) flush(until time.Duration) error {
+	s, exists := t.streams[t.video]
+	if !exists {
+		return nil
+	}
+	for len(t.pending) > 0 {
+		pkt := t.pending[0]
+		if trimDts(s, pkt) > until {
+			break
+		}
+		t.pending = t.pending[1:]
+		err := t.write(s, pkt)
+		ff.AVCodec_packet_free(pkt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Write a packet to the output, with the timestamps rebased so that the
+// output starts at zero
+func (t *trim) write(s *trimStream, pkt *ff.AVPacket) error {
+	offset := ff.AVUtil_rational_rescale_q(int64(t.origin), ff.AVUtil_rational(1, int(time.Second)), s.tb)
+	if pts := pkt.Pts(); pts != int64(ff.AV_NOPTS_VALUE) {
+		pkt.SetPts(pts - offset)
+		if end := tsToDuration(pts-offset+pkt.Duration(), s.tb); end > t.resp.Duration {
+			t.resp.Duration = end
+		}
+	}
+	if dts := pkt.Dts(); dts != int64(ff.AV_NOPTS_VALUE) {
+		pkt.SetDts(dts - offset)
+	}
+	pkt.SetPos(-1)
+	pkt.SetStreamIndex(s.index)
+	pkt.SetTimeBase(s.tb)
+	return t.writer.Write(ffschema.NewPacket(pkt))
+}
+
+// Return a new packet with the data, and the timestamps and flags of src,
+// which the caller should free
+func newTrimPacket(src *ff.AVPacket, data []byte) (*ff.AVPacket, error) {
+	pkt := ff.AVCodec_packet_alloc()
+	if pkt == nil {
+		return nil, errors.New("failed to allocate packet")
+	}
+	if err := ff.AVCodec_packet_from_data(pkt, data); err != nil {
+		ff.AVCodec_packet_free(pkt)
+		return nil, err
+	}
+	pkt.SetStreamIndex(src.StreamIndex())
+	pkt.SetPts(src.Pts())
+	pkt.SetDts(src.Dts())
+	pkt.SetDuration(src.Duration())
+	pkt.SetFlags(src.Flags())
+	pkt.SetTimeBase(src.TimeBase())
+	return pkt, nil
+}
+
+// Return the presentation time of a packet, or the decode time if it has
+// no presentation time
+func trimPts(s *trimStream, pkt *ff.AVPacket) (time.Duration, bool) {
+	ts := pkt.Pts()
+	if ts == int64(ff.AV_NOPTS_VALUE) {
+		ts = pkt.Dts()
+	}
+	if ts == int64(ff.AV_NOPTS_VALUE) {
+		return 0, false
+	}
+	return tsToDuration(ts, s.tb), true
+}
+
+// Return the decode time of a packet, or the presentation time if it has
+// no decode time
+func trimDts(s *trimStream, pkt *ff.AVPacket) time.Duration {
+	ts := pkt.Dts()
+	if ts == int64(ff.AV_NOPTS_VALUE) {
+		ts = pkt.Pts()
+	}
+	return tsToDuration(ts, s.tb)
+}
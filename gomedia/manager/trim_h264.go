@@ -0,0 +1,110 @@
+package manager
+
+import (
+	"bytes"
+
+	// Packages
+	gomedia "github.com/mutablelogic/go-media"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// h264Config describes how an H.264 stream stores its NAL units, from the
+// codec extradata, so that re-encoded pictures can be spliced into it
+type h264Config struct {
+	length int      // Size of the NAL unit length prefix, or zero for Annex B start codes
+	params [][]byte // Sequence and picture parameter sets
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+// Parse the extradata of an H.264 stream, which is either an
+// AVCDecoderConfigurationRecord (as in MP4 and Matroska) or Annex B
+// parameter sets (as in MPEG-TS), which may be empty
+func newH264Config(extradata []byte) (*h264Config, error) {
+	if len(extradata) == 0 || extradata[0] != 1 {
+		return &h264Config{params: annexbNALs(extradata)}, nil
+	}
+	if len(extradata) < 7 {
+		return nil, gomedia.ErrBadParameter.With("invalid avcC extradata")
+	}
+	config := &h264Config{length: int(extradata[4]&0x03) + 1}
+	if config.length == 3 {
+		return nil, gomedia.ErrBadParameter.With("invalid avcC length size")
+	}
+
+	// The sequence parameter sets, then the picture parameter sets
+	data := extradata[5:]
+	for _, mask := range []byte{0x1F, 0xFF} {
+		if len(data) < 1 {
+			return nil, gomedia.ErrBadParameter.With("invalid avcC extradata")
+		}
+		n := int(data[0] & mask)
+		data = data[1:]
+		for i := 0; i < n; i++ {
+			if len(data) < 2 {
+				return nil, gomedia.ErrBadParameter.With("invalid avcC extradata")
+			}
+			size := int(data[0])<<8 | int(data[1])
+			if len(data) < 2+size {
+				return nil, gomedia.ErrBadParameter.With("invalid avcC extradata")
+			}
+			config.params = append(config.params, data[2:2+size])
+			data = data[2+size:]
+		}
+	}
+
+	// Return success
+	return config, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// Return NAL units packed with a length prefix or start codes, as the
+// stream stores them
+func (c *h264Config) pack(nals ...[]byte) []byte {
+	var buf bytes.Buffer
+	for _, nal := range nals {
+		if c.length == 0 {
+			buf.Write([]byte{0, 0, 0, 1})
+		} else {
+			for i := c.length - 1; i >= 0; i-- {
+				buf.WriteByte(byte(len(nal) >> (8 * i)))
+			}
+		}
+		buf.Write(nal)
+	}
+	return buf.Bytes()
+}
+
+// Split Annex B data into NAL units, without the start codes
+func annexbNALs(data []byte) [][]byte {
+	var nals [][]byte
+	start := -1
+	for i := 0; i+2 < len(data); i++ {
+		if data[i] != 0 || data[i+1] != 0 || data[i+2] != 1 {
+			continue
+		}
+		if start >= 0 {
+			nals = appendNAL(nals, data[start:i])
+		}
+		i += 2
+		start = i + 1
+	}
+	if start >= 0 && start < len(data) {
+		nals = appendNAL(nals, data[start:])
+	}
+	return nals
+}
+
+// Append a NAL unit, without the zero bytes which precede a four-byte
+// start code
+func appendNAL(nals [][]byte, nal []byte) [][]byte {
+	if nal = bytes.TrimRight(nal, "\x00"); len(nal) > 0 {
+		nals = append(nals, nal)
+	}
+	return nals
+}
@@ -0,0 +1,155 @@
+package manager_test
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	// Packages
+	gomedia "github.com/mutablelogic/go-media"
+	schema "github.com/mutablelogic/go-media/gomedia/schema"
+	test "github.com/mutablelogic/go-media/gomedia/test"
+	ffmpeg "github.com/mutablelogic/go-media/pkg/ffmpeg"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
+)
+
+func TestTrim_Keyframe(t *testing.T) {
+	m, ctx := test.Begin(t)
+
+	f, err := os.Open(filepath.Join("..", "..", "etc", "test", "sample.mp4"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	out := filepath.Join(t.TempDir(), "trim.mp4")
+	w, err := os.Create(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	resp, err := m.Trim(ctx, w, schema.TrimRequest{
+		Reader: f,
+		Start:  time.Second,
+		End:    2 * time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The cut is widened to the keyframes
+	if resp.Start > time.Second || resp.End < 2*time.Second {
+		t.Fatalf("expected cut around 1s to 2s, got %v to %v", resp.Start, resp.End)
+	}
+	if resp.Packets == 0 || resp.Encoded != 0 {
+		t.Fatalf("expected copied packets only, got %d copied and %d encoded", resp.Packets, resp.Encoded)
+	}
+
+	// The output starts at zero and lasts for the cut
+	r, err := os.Open(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	probe, err := m.Probe(ctx, schema.ProbeRequest{Reader: r})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(probe.Streams) == 0 {
+		t.Fatal("expected streams in the output")
+	}
+	if d := time.Duration(probe.Duration * float64(time.Second)); d < time.Second/2 || d > resp.Duration+time.Second/2 {
+		t.Fatalf("expected duration of about %v, got %v", resp.Duration, d)
+	}
+}
+
+func TestTrim_Smart(t *testing.T) {
+	m, ctx := test.Begin(t)
+	if ff.AVCodec_find_encoder(ff.AV_CODEC_ID_H264) == nil {
+		t.Skip("no H.264 encoder")
+	}
+
+	f, err := os.Open(filepath.Join("..", "..", "etc", "test", "sample.mp4"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	out := filepath.Join(t.TempDir(), "trim.mp4")
+	w, err := os.Create(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	resp, err := m.Trim(ctx, w, schema.TrimRequest{
+		Reader: f,
+		Start:  1100 * time.Millisecond,
+		End:    2100 * time.Millisecond,
+		Mode:   "smart",
+	})
+	if errors.Is(err, gomedia.ErrNotImplemented) {
+		t.Skip(err)
+	} else if err != nil {
+		t.Fatal(err)
+	}
+
+	// The cut is exact
+	if resp.Start != 1100*time.Millisecond {
+		t.Fatalf("expected cut from 1.1s, got %v", resp.Start)
+	}
+	if resp.Duration > time.Second+100*time.Millisecond {
+		t.Fatalf("expected duration of about 1s, got %v", resp.Duration)
+	}
+
+	// Every video frame decodes, starting at zero with increasing timestamps
+	reader, err := ffmpeg.Open(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	frames, last := 0, -1.0
+	if err := reader.Demux(ctx, func(_ int, par *ffmpeg.Par) (*ffmpeg.Par, error) {
+		if par.Type() != gomedia.VIDEO {
+			return nil, nil
+		}
+		return par, nil
+	}, func(_ int, frame *ffmpeg.Frame) error {
+		ts := frame.Ts()
+		if frames == 0 && math.Abs(ts) > 0.001 {
+			t.Errorf("expected the first frame at zero, got %v", ts)
+		}
+		if frames > 0 && ts <= last {
+			t.Errorf("expected frame %d after %v, got %v", frames, last, ts)
+		}
+		frames, last = frames+1, ts
+		return nil
+	}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if frames == 0 {
+		t.Fatal("expected video frames in the output")
+	}
+}
+
+func TestTrim_BadParameters(t *testing.T) {
+	m, ctx := test.Begin(t)
+
+	var buf bytes.Buffer
+	for _, req := range []schema.TrimRequest{
+		{Reader: bytes.NewReader([]byte{0}), Start: -time.Second},
+		{Reader: bytes.NewReader([]byte{0}), Start: 2 * time.Second, End: time.Second},
+		{Reader: bytes.NewReader([]byte{0}), Mode: "exact"},
+		{Reader: nil},
+		{Reader: bytes.NewBuffer([]byte{0})},
+	} {
+		if _, err := m.Trim(ctx, &buf, req); !errors.Is(err, gomedia.ErrBadParameter) {
+			t.Errorf("expected ErrBadParameter for %+v, got %v", req, err)
+		}
+	}
+}
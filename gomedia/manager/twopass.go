@@ -0,0 +1,78 @@
+package manager
+
+import (
+	"context"
+	"io"
+	"os"
+
+	// Packages
+	otel "github.com/mutablelogic/go-client/pkg/otel"
+	gomedia "github.com/mutablelogic/go-media"
+	ffmpeg "github.com/mutablelogic/go-media/pkg/ffmpeg"
+	attribute "go.opentelemetry.io/otel/attribute"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// EncodeFn encodes the input read from r to w. The writer options in opts
+// select the pass, and should be passed to ffmpeg.NewWriter together with an
+// explicit output format, as the first pass is written to a file without an
+// extension.
+type EncodeFn func(ctx context.Context, r io.Reader, w io.Writer, opts ...ffmpeg.Opt) error
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// EncodeTwoPass calls fn twice over the input, first to collect encoder
+// statistics and then to encode to w using them. The input is rewound for
+// the second pass. The first pass is written to a temporary file, as some
+// output formats need to seek, and removed afterwards.
+func (m *Media) EncodeTwoPass(ctx context.Context, r io.ReadSeeker, w io.Writer, fn EncodeFn) (err error) {
+	ctx, endSpan := otel.StartSpan(m.tracer, ctx, "EncodeTwoPass")
+	defer func() { endSpan(err) }()
+
+	if r == nil || w == nil {
+		return gomedia.ErrBadParameter.With("nil reader or writer")
+	} else if fn == nil {
+		return gomedia.ErrBadParameter.With("nil encode function")
+	}
+
+	// Statistics are shared between the passes
+	log := ffmpeg.NewPassLog()
+	defer log.Close()
+
+	// Run the first pass, then rewind the input for the second pass
+	start, err := r.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if err := m.encodePass(ctx, 1, r, nil, log, fn); err != nil {
+		return err
+	}
+	if _, err := r.Seek(start, io.SeekStart); err != nil {
+		return err
+	}
+	return m.encodePass(ctx, 2, r, w, log, fn)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// encodePass runs one pass of a two-pass encode. When w is nil, the output
+// is written to a temporary file which is then removed.
+func (m *Media) encodePass(ctx context.Context, pass int, r io.Reader, w io.Writer, log *ffmpeg.PassLog, fn EncodeFn) (err error) {
+	ctx, endSpan := otel.StartSpan(m.tracer, ctx, "EncodePass", attribute.Int("pass", pass))
+	defer func() { endSpan(err) }()
+
+	if w == nil {
+		f, err := os.CreateTemp("", "gomedia-pass-")
+		if err != nil {
+			return err
+		}
+		defer os.Remove(f.Name())
+		defer f.Close()
+		w = f
+	}
+	return fn(ctx, r, w, ffmpeg.OptPass(pass, log))
+}
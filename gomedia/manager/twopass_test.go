@@ -0,0 +1,82 @@
+package manager_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	// Packages
+	test "github.com/mutablelogic/go-media/gomedia/test"
+	ffmpeg "github.com/mutablelogic/go-media/pkg/ffmpeg"
+)
+
+func TestEncodeTwoPass(t *testing.T) {
+	m, ctx := test.Begin(t)
+
+	// The input is read in full by each pass
+	input := []byte("input")
+	var passes int
+	fn := func(ctx context.Context, r io.Reader, w io.Writer, opts ...ffmpeg.Opt) error {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(data, input) {
+			t.Errorf("pass %d: unexpected input %q", passes+1, data)
+		}
+		passes++
+
+		par, err := ffmpeg.NewVideoPar("yuv420p", "160x120", 25)
+		if err != nil {
+			return err
+		}
+		par.SetBitRate(100000)
+		writer, err := ffmpeg.NewWriter(w, append([]ffmpeg.Opt{ffmpeg.OptOutputFormat("avi"), ffmpeg.OptStream(0, par)}, opts...)...)
+		if err != nil {
+			return err
+		}
+		defer writer.Close()
+
+		frame, err := ffmpeg.NewFrame(par)
+		if err != nil {
+			return err
+		}
+		defer frame.Close()
+		if err := frame.AllocateBuffers(); err != nil {
+			return err
+		}
+		for i := 0; i < 10; i++ {
+			frame.SetPts(int64(i))
+			if err := writer.EncodeFrame(0, frame); err != nil {
+				return err
+			}
+		}
+		if err := writer.EncodeFrame(0, nil); err != nil {
+			return err
+		}
+		return writer.Close()
+	}
+
+	var out bytes.Buffer
+	if err := m.EncodeTwoPass(ctx, bytes.NewReader(input), &out, fn); err != nil {
+		t.Fatal(err)
+	}
+	if passes != 2 {
+		t.Fatalf("expected two passes, got %d", passes)
+	}
+	if out.Len() == 0 {
+		t.Fatal("expected output from the second pass")
+	}
+}
+
+func TestEncodeTwoPass_Nil(t *testing.T) {
+	m, ctx := test.Begin(t)
+
+	if err := m.EncodeTwoPass(ctx, nil, io.Discard, nil); err == nil {
+		t.Fatal("expected an error for a nil reader")
+	}
+	if err := m.EncodeTwoPass(ctx, bytes.NewReader(nil), io.Discard, nil); err == nil {
+		t.Fatal("expected an error for a nil encode function")
+	}
+}
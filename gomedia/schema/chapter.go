@@ -0,0 +1,77 @@
+package schema
+
+import (
+	"encoding/json"
+	"strconv"
+
+	// Packages
+	ffschema "github.com/mutablelogic/go-media/pkg/ffmpeg/schema"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// Chapter wraps pkg/ffmpeg/schema Chapter and adds CLI table formatting helpers.
+type Chapter struct {
+	*ffschema.Chapter
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+func WrapChapter(c *ffschema.Chapter) *Chapter {
+	if c == nil {
+		return nil
+	}
+	return &Chapter{Chapter: c}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (c Chapter) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.Chapter)
+}
+
+func (c Chapter) String() string {
+	if c.Chapter == nil {
+		return "<nil>"
+	}
+	return c.Chapter.String()
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// TABLE WRITER
+
+func (Chapter) Header() []string {
+	return []string{"Id", "Start", "End", "Title"}
+}
+
+func (c Chapter) Cell(col int) string {
+	if c.Chapter == nil {
+		return ""
+	}
+	switch col {
+	case 0:
+		return strconv.FormatInt(c.Id, 10)
+	case 1:
+		return strconv.FormatFloat(c.Start.Seconds(), 'f', 3, 64)
+	case 2:
+		return strconv.FormatFloat(c.End.Seconds(), 'f', 3, 64)
+	case 3:
+		return c.Title
+	default:
+		return ""
+	}
+}
+
+func (Chapter) Width(col int) int {
+	switch col {
+	case 0:
+		return 8
+	case 1, 2:
+		return 12
+	default:
+		return 0
+	}
+}
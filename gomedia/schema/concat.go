@@ -0,0 +1,33 @@
+package schema
+
+import (
+	"io"
+	"time"
+
+	// Packages
+	types "github.com/mutablelogic/go-server/pkg/types"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type ConcatRequest struct {
+	Readers []io.Reader `json:"-" kong:"-"` // Readers for the inputs, in the order they are joined
+	Mode    string      `json:"mode,omitempty" name:"mode" help:"Copy the packets when the inputs match and re-encode otherwise, or always copy or re-encode." enum:"auto,copy,encode" default:"auto"`
+	Format  string      `json:"format,omitempty" name:"format" help:"Output format name or file name. Defaults to the format of the first input."`
+}
+
+type ConcatResponse struct {
+	Duration time.Duration `json:"duration"`           // Duration of the output
+	Copy     bool          `json:"copy"`               // Packets were copied, rather than re-encoded
+	Packets  int           `json:"packets,omitempty"`  // Number of packets copied
+	Frames   int           `json:"frames,omitempty"`   // Number of frames re-encoded
+	Chapters []*Chapter    `json:"chapters,omitempty"` // A chapter for each input
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (r ConcatResponse) String() string {
+	return types.Stringify(r)
+}
@@ -5,7 +5,7 @@ import (
 	"strconv"
 
 	// Packages
-	ff "github.com/mutablelogic/go-media/sys/ffmpeg80"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
 )
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -6,7 +6,7 @@ import (
 	"strings"
 
 	// Packages
-	ff "github.com/mutablelogic/go-media/sys/ffmpeg80"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
 )
 
 ////////////////////////////////////////////////////////////////////////////////
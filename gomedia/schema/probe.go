@@ -14,14 +14,25 @@ type ProbeRequest struct {
 	Reader      io.Reader `json:"-" kong:"-"` // Reader for media data
 	InputFormat string    `json:"input_format,omitempty" name:"input-format" help:"Input format name (e.g. mpegts)"`
 	InputOpts   []string  `json:"input_opts,omitempty" name:"input-opt" help:"Input format option key=value (repeatable)"`
+	Captions    bool      `json:"captions,omitempty" name:"captions" help:"Decode the start of each video stream to detect closed captions"`
 }
 
 type ProbeResponse struct {
-	Format      string    `json:"format"`                // Format name (e.g., "mov,mp4,m4a,3gp,3g2,mj2")
-	Description string    `json:"description,omitempty"` // Format description (e.g., "QuickTime / MOV")
-	MimeTypes   []string  `json:"mime_types,omitempty"`  // MIME types
-	Duration    float64   `json:"duration"`              // Duration in seconds
-	Streams     []*Stream `json:"streams,omitempty"`     // Stream information
+	Format      string     `json:"format"`                // Format name (e.g., "mov,mp4,m4a,3gp,3g2,mj2")
+	Description string     `json:"description,omitempty"` // Format description (e.g., "QuickTime / MOV")
+	MimeTypes   []string   `json:"mime_types,omitempty"`  // MIME types
+	Duration    float64    `json:"duration"`              // Duration in seconds
+	Streams     []*Stream  `json:"streams,omitempty"`     // Stream information
+	Chapters    []*Chapter `json:"chapters,omitempty"`    // Chapters, in order
+	Captions    []Captions `json:"captions,omitempty"`    // Closed captions, when requested
+}
+
+// Captions describes the closed captions carried in the side data of a
+// video stream
+type Captions struct {
+	Stream int  `json:"stream"`
+	CEA608 bool `json:"cea608"`
+	CEA708 bool `json:"cea708"`
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,33 @@
+package schema
+
+import (
+	"io"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// RAWDevelopParams are the develop settings shared by RAW develop and export
+type RAWDevelopParams struct {
+	WhiteBalance string    `json:"white_balance,omitempty" name:"wb" help:"White balance." enum:"as-shot,auto,custom" default:"as-shot"`
+	Multipliers  []float32 `json:"multipliers,omitempty" name:"wb-mul" help:"Custom white balance multipliers R,G,B[,G2]. Implies --wb=custom."`
+	Exposure     float64   `json:"exposure,omitempty" name:"exposure" help:"Exposure correction in EV (-2 to +3)."`
+	Highlight    string    `json:"highlight,omitempty" name:"highlight" help:"Highlight recovery mode." enum:"clip,unclip,blend,rebuild" default:"clip"`
+	ColorSpace   string    `json:"color_space,omitempty" name:"color-space" help:"Output colour space." enum:"srgb,adobergb,prophoto" default:"srgb"`
+	HalfSize     bool      `json:"half_size,omitempty" name:"half" help:"Develop at half resolution, which is much faster."`
+}
+
+type RAWDevelopRequest struct {
+	Reader io.Reader `json:"-" kong:"-"` // Reader for RAW data
+	Format string    `json:"format,omitempty" name:"format" help:"Output image format." enum:"jpeg,png,tiff" default:"jpeg"`
+	Bits   int       `json:"bits,omitempty" name:"bits" help:"Bits per sample (8 or 16). 16-bit output requires png or tiff." enum:"8,16" default:"8"`
+	RAWDevelopParams
+}
+
+type RAWExportRequest struct {
+	Reader  io.Reader `json:"-" kong:"-"` // Reader for RAW data
+	Format  string    `json:"format,omitempty" name:"format" help:"Archive format, a 16-bit TIFF or linear DNG." enum:"tiff,dng" default:"dng"`
+	Linear  bool      `json:"linear,omitempty" name:"linear" help:"Write linear samples without a transfer curve. Always set for DNG."`
+	Preview bool      `json:"preview,omitempty" name:"preview" help:"Embed the camera's JPEG preview." negatable:"" default:"true"`
+	RAWDevelopParams
+}
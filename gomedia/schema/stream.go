@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 
 	// Packages
 	media "github.com/mutablelogic/go-media"
@@ -99,8 +100,29 @@ func (s Stream) Details() string {
 
 	codecPar := s.CodecPar()
 	if s.Type().Is(media.VIDEO) {
+		var details []string
 		if codecPar.Width() > 0 && codecPar.Height() > 0 {
-			return fmt.Sprintf("%dx%d", codecPar.Width(), codecPar.Height())
+			details = append(details, fmt.Sprintf("%dx%d", codecPar.Width(), codecPar.Height()))
+		}
+		if sd := s.SideData(); sd != nil {
+			if rotate := sd.DisplayMatrix.Rotate(); rotate != 0 {
+				details = append(details, fmt.Sprintf("rotate %.0f", rotate))
+			}
+			if sd.DisplayMatrix != nil && sd.DisplayMatrix.Flipped {
+				details = append(details, "flipped")
+			}
+			if hdr := sd.HDR(); hdr != "" {
+				details = append(details, hdr)
+			}
+			if sd.Stereo3D != nil {
+				details = append(details, sd.Stereo3D.Type)
+			}
+			if sd.Spherical != nil {
+				details = append(details, sd.Spherical.Projection)
+			}
+		}
+		if len(details) > 0 {
+			return strings.Join(details, ", ")
 		}
 	}
 
@@ -0,0 +1,148 @@
+package schema
+
+import (
+	"io"
+	"strings"
+	"time"
+
+	// Packages
+	gomedia "github.com/mutablelogic/go-media"
+	ffmpeg "github.com/mutablelogic/go-media/pkg/ffmpeg"
+	types "github.com/mutablelogic/go-server/pkg/types"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type ExtractSubtitlesRequest struct {
+	Reader  io.Reader `json:"-" kong:"-"`
+	Streams []int     `json:"streams,omitempty" name:"stream" help:"Subtitle streams to extract, by index. Defaults to all subtitle streams."`
+	Format  string    `json:"format,omitempty" name:"format" help:"Subtitle file format." enum:"srt,vtt,ass" default:"srt"`
+}
+
+type ExtractSubtitlesResponse struct {
+	Tracks []*SubtitleTrack `json:"tracks"`
+}
+
+type AddSubtitlesRequest struct {
+	Reader    io.Reader        `json:"-" kong:"-"`
+	Subtitles []*SubtitleInput `json:"subtitles" kong:"-"`
+	Format    string           `json:"format,omitempty" name:"format" help:"Output format. Defaults to the format of the input."`
+}
+
+type AddSubtitlesResponse struct {
+	Packets   int              `json:"packets"`   // Packets copied from the input
+	Subtitles int              `json:"subtitles"` // Subtitles written to the added streams
+	Tracks    []*SubtitleTrack `json:"tracks"`    // Subtitle streams added to the output
+}
+
+// SubtitleInput is a subtitle file to add as a stream, with the language
+// and dispositions of the stream
+type SubtitleInput struct {
+	Reader          io.Reader `json:"-"`
+	Language        string    `json:"language,omitempty"`
+	Title           string    `json:"title,omitempty"`
+	Default         bool      `json:"default,omitempty"`
+	Forced          bool      `json:"forced,omitempty"`
+	HearingImpaired bool      `json:"hearing_impaired,omitempty"`
+}
+
+type BurnSubtitlesRequest struct {
+	Reader    io.Reader `json:"-" kong:"-"`
+	Subtitles io.Reader `json:"-" kong:"-"`
+	Streams   []int     `json:"streams,omitempty" name:"stream" help:"Subtitle stream of the subtitle file to burn in, by index. Defaults to the first text subtitle stream."`
+	Format    string    `json:"format,omitempty" name:"format" help:"Output format. Defaults to the format of the input."`
+}
+
+type BurnSubtitlesResponse struct {
+	Duration  time.Duration  `json:"duration"`
+	Frames    int            `json:"frames"`    // Frames encoded
+	Subtitles int            `json:"subtitles"` // Subtitles burned in
+	Track     *SubtitleTrack `json:"track"`     // Subtitle stream which was burned in
+}
+
+type ExtractCaptionsRequest struct {
+	Reader  io.Reader `json:"-" kong:"-"`
+	Streams []int     `json:"streams,omitempty" name:"stream" help:"Video stream with the captions, by index. Defaults to the best video stream."`
+	Field   int       `json:"field,omitempty" name:"field" help:"Caption field, 1 for CC1 or 2 for CC3." enum:"1,2" default:"1"`
+	Format  string    `json:"format,omitempty" name:"format" help:"Subtitle file format." enum:"srt,vtt" default:"srt"`
+}
+
+type ExtractCaptionsResponse struct {
+	Captions
+	Frames int            `json:"frames"` // Video frames decoded
+	Track  *SubtitleTrack `json:"track"`  // Captions of the field, as subtitles
+}
+
+// SubtitleTrack is a decoded subtitle stream
+type SubtitleTrack struct {
+	Stream          int                `json:"stream"`
+	Codec           string             `json:"codec"`
+	Language        string             `json:"language,omitempty"`
+	Title           string             `json:"title,omitempty"`
+	Forced          bool               `json:"forced,omitempty"`
+	HearingImpaired bool               `json:"hearing_impaired,omitempty"`
+	Bitmap          bool               `json:"bitmap,omitempty"` // Subtitles are images, which have no text to export
+	Header          string             `json:"-"`                // Script info and styles of an ASS stream
+	Subtitles       []*ffmpeg.Subtitle `json:"subtitles,omitempty"`
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (r ExtractSubtitlesResponse) String() string {
+	return types.Stringify(r)
+}
+
+func (r ExtractCaptionsResponse) String() string {
+	return types.Stringify(r)
+}
+
+func (r AddSubtitlesResponse) String() string {
+	return types.Stringify(r)
+}
+
+func (r BurnSubtitlesResponse) String() string {
+	return types.Stringify(r)
+}
+
+func (t SubtitleTrack) String() string {
+	return types.Stringify(t)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Write the subtitles in a format, which is srt, vtt or ass
+func (t *SubtitleTrack) Write(w io.Writer, format string) error {
+	switch format {
+	case "", "srt":
+		return ffmpeg.WriteSRT(w, t.Subtitles)
+	case "vtt":
+		return ffmpeg.WriteWebVTT(w, t.Subtitles)
+	case "ass":
+		return ffmpeg.WriteASS(w, t.Header, t.Subtitles)
+	default:
+		return gomedia.ErrBadParameter.Withf("invalid subtitle format %q", format)
+	}
+}
+
+// Return a file name for the subtitles, with the language and whether they
+// are forced or for the hearing impaired, as media players expect. For
+// example, "movie.en.sdh.srt".
+func (t *SubtitleTrack) Filename(base, format string) string {
+	if format == "" {
+		format = "srt"
+	}
+	parts := []string{base}
+	if t.Language != "" {
+		parts = append(parts, t.Language)
+	}
+	if t.Forced {
+		parts = append(parts, "forced")
+	}
+	if t.HearingImpaired {
+		parts = append(parts, "sdh")
+	}
+	return strings.Join(append(parts, format), ".")
+}
@@ -0,0 +1,35 @@
+package schema
+
+import (
+	"io"
+	"time"
+
+	// Packages
+	types "github.com/mutablelogic/go-server/pkg/types"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type TrimRequest struct {
+	Reader io.Reader     `json:"-" kong:"-"` // Reader for the input, which must implement io.Seeker as it is read more than once
+	Start  time.Duration `json:"start,omitempty" name:"start" help:"Start of the cut, from the beginning of the input (e.g. 1m30s)."`
+	End    time.Duration `json:"end,omitempty" name:"end" help:"End of the cut, from the beginning of the input. Zero cuts to the end."`
+	Mode   string        `json:"mode,omitempty" name:"mode" help:"Snap the cut to keyframes, or re-encode the video at the cut points." enum:"keyframe,smart" default:"keyframe"`
+	Format string        `json:"format,omitempty" name:"format" help:"Output format name or file name. Defaults to the input format."`
+}
+
+type TrimResponse struct {
+	Start    time.Duration `json:"start"`    // Start of the cut in the input, after snapping to a keyframe
+	End      time.Duration `json:"end"`      // End of the cut in the input, after snapping to a keyframe
+	Duration time.Duration `json:"duration"` // Duration of the output
+	Packets  int           `json:"packets"`  // Number of packets copied
+	Encoded  int           `json:"encoded"`  // Number of video frames re-encoded at the cut points
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (r TrimResponse) String() string {
+	return types.Stringify(r)
+}
@@ -119,9 +119,10 @@ func ExtractArtwork(data []byte, key string) (gomedia.Metadata, error) {
 	return types.Ptr(artworkMetadata{key: key, mimeType: mimeType, data: buf.Bytes(), img: img}), nil
 }
 
-// thumbnailArtwork encodes an already-decoded image.Image as artwork metadata.
-// It is used for HEIF/AVIF thumbnails, which are already available as images.
-func thumbnailArtwork(img image.Image) (gomedia.Metadata, error) {
+// imageArtwork encodes an already-decoded image.Image as artwork metadata
+// under key. It is used for HEIF/AVIF thumbnails and auxiliary images, which
+// are already available as images.
+func imageArtwork(img image.Image, key string) (gomedia.Metadata, error) {
 	if img == nil {
 		return nil, nil
 	}
@@ -130,7 +131,7 @@ func thumbnailArtwork(img image.Image) (gomedia.Metadata, error) {
 	if err := png.Encode(&buf, img); err != nil {
 		return nil, err
 	}
-	return types.Ptr(artworkMetadata{key: "artwork:thumbnail", mimeType: "image/png", data: buf.Bytes(), img: img}), nil
+	return types.Ptr(artworkMetadata{key: key, mimeType: "image/png", data: buf.Bytes(), img: img}), nil
 }
 
 ////////////////////////////////////////////////////////////////////////////////
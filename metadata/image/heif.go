@@ -2,13 +2,16 @@ package image
 
 import (
 	"context"
+	"image"
 	"io"
 	"regexp"
+	"strings"
 
 	// Packages
 	gomedia "github.com/mutablelogic/go-media"
 	metadata "github.com/mutablelogic/go-media/metadata"
 	heif "github.com/mutablelogic/go-media/pkg/heif"
+	types "github.com/mutablelogic/go-server/pkg/types"
 )
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -32,11 +35,28 @@ func init() {
 			entries[m.Key()] = m
 		}
 
+		// Describe the file structure: the number of top-level images, the
+		// auxiliary images attached to the primary image and any sequences
+		if n := h.NumImages(); n > 0 {
+			entries["heif:images"] = types.Ptr(imageMetadata{"heif:images", n})
+		}
+		if auxTypes := h.AuxiliaryTypes(); len(auxTypes) > 0 {
+			entries["heif:auxiliary"] = types.Ptr(imageMetadata{"heif:auxiliary", auxTypes})
+		}
+		if info := h.DepthInfo(); info != nil {
+			entries["heif:depth"] = types.Ptr(imageMetadata{"heif:depth", info})
+		}
+		if tracks := h.Tracks(); len(tracks) > 0 {
+			entries["heif:tracks"] = types.Ptr(imageMetadata{"heif:tracks", len(tracks)})
+			entries["heif:duration"] = types.Ptr(imageMetadata{"heif:duration", h.Duration().String()})
+		}
+
 		return metadata.FilterMetadata(entries, filter), nil
-	}, "tiff", "exif", "dc", "xmp")
+	}, "tiff", "exif", "dc", "xmp", "heif")
 
 	metadata.AddHandler(regexp.MustCompile(`^image/(?:heic|heics|heif|heifs|avif|avis)$`), func(_ context.Context, r io.Reader, filter string) ([]gomedia.Metadata, error) {
-		if filter != "artwork:" && filter != "artwork:thumbnail" {
+		name, ok := heifArtworkFilter(filter)
+		if !ok {
 			return nil, nil
 		}
 
@@ -51,26 +71,95 @@ func init() {
 		}
 		defer h.Close()
 
-		thumbs := h.Thumbnails()
-		if len(thumbs) == 0 {
-			return nil, nil
-		}
-
-		entries := make([]gomedia.Metadata, 0, len(thumbs))
-		for _, thumb := range thumbs {
-			m, err := thumbnailArtwork(thumb)
+		var entries []gomedia.Metadata
+		add := func(img image.Image, key string) error {
+			if name != "" && key != "artwork:"+name {
+				return nil
+			}
+			m, err := imageArtwork(img, key)
 			if err != nil {
-				return nil, err
+				return err
 			}
 			if m != nil {
 				entries = append(entries, m)
 			}
+			return nil
+		}
+
+		// Embedded thumbnails
+		if name == "" || name == "thumbnail" {
+			for _, thumb := range h.Thumbnails() {
+				if err := add(thumb, "artwork:thumbnail"); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		// All top-level images, when there is more than the primary image
+		// (for example burst frames or stereo pairs)
+		if name == "" || name == "image" {
+			if h.NumImages() > 1 {
+				for _, img := range h.Images() {
+					if err := add(img, "artwork:image"); err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+
+		// Depth maps
+		if name == "" || name == "depth" {
+			for _, depth := range h.Depth() {
+				if err := add(depth.Image, "artwork:depth"); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		// Gain maps and mattes. Alpha and depth planes are covered above or
+		// are part of the primary image
+		if name == "" || name == "gainmap" || name == "matte" {
+			for _, aux := range h.Auxiliary("") {
+				key := heifAuxiliaryKey(aux.Type)
+				if key == "" {
+					continue
+				}
+				if err := add(aux.Image, key); err != nil {
+					return nil, err
+				}
+			}
 		}
 
 		return entries, nil
 	}, "artwork")
 }
 
+// heifArtworkFilter returns the artwork name requested by filter, or an
+// empty name when all artwork was requested.
+func heifArtworkFilter(filter string) (string, bool) {
+	switch filter {
+	case "artwork:":
+		return "", true
+	case "artwork:thumbnail", "artwork:image", "artwork:depth", "artwork:gainmap", "artwork:matte":
+		return strings.TrimPrefix(filter, "artwork:"), true
+	default:
+		return "", false
+	}
+}
+
+// heifAuxiliaryKey maps an auxiliary image type URN onto an artwork key, or
+// returns an empty string for auxiliary images which are not reported.
+func heifAuxiliaryKey(urn string) string {
+	switch urn {
+	case heif.AuxHDRGainMap:
+		return "artwork:gainmap"
+	case heif.AuxPortraitEffectsMatte, heif.AuxSkinMatte, heif.AuxHairMatte, heif.AuxTeethMatte:
+		return "artwork:matte"
+	default:
+		return ""
+	}
+}
+
 func isHEIFContainer(data []byte) bool {
 	if len(data) < 12 {
 		return false
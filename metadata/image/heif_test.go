@@ -46,3 +46,24 @@ func Test_heif_metadata_000(t *testing.T) {
 		t.Fatal("expected tiff:Software to be present")
 	}
 }
+
+func Test_heif_metadata_001(t *testing.T) {
+	path := filepath.Join(TEST_DIR, heifTestFile)
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	meta, err := metadata.GetMetadata(context.Background(), f, contentTypeForFile(t, path), "heif:images")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(meta) != 1 {
+		t.Fatalf("heif:images entries=%d want=1", len(meta))
+	}
+	if meta[0].Value() == "" || meta[0].Value() == "0" {
+		t.Fatalf("heif:images=%q", meta[0].Value())
+	}
+}
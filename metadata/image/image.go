@@ -6,6 +6,7 @@ import (
 	"image"
 	"io"
 	"regexp"
+	"strings"
 
 	// Packages
 	gomedia "github.com/mutablelogic/go-media"
@@ -41,6 +42,10 @@ func (m *imageMetadata) Value() string {
 		return v
 	case int:
 		return fmt.Sprint(v)
+	case []string:
+		return strings.Join(v, ", ")
+	case fmt.Stringer:
+		return v.String()
 	default:
 		return ""
 	}
@@ -68,5 +68,5 @@ func init() {
 		}
 
 		return metadata.FilterMetadata(entries, filter), nil
-	}, "tiff", "exif", "image", "dc", "artwork")
+	}, "tiff", "exif", "aux", "raw", "image", "dc", "artwork")
 }
@@ -14,13 +14,14 @@ import (
 	gomedia "github.com/mutablelogic/go-media"
 	metadata "github.com/mutablelogic/go-media/metadata"
 	ffmpeg "github.com/mutablelogic/go-media/pkg/ffmpeg"
+	ffschema "github.com/mutablelogic/go-media/pkg/ffmpeg/schema"
 )
 
 ////////////////////////////////////////////////////////////////////////////////
 // TYPES
 
 // meta is a generic gomedia.Metadata for a scalar video tag value (string,
-// time.Duration, or float64).
+// time.Duration, or float64) or the list of chapters.
 type meta struct {
 	key   string
 	value any
@@ -39,6 +40,8 @@ func (m meta) Value() string {
 		return strconv.FormatFloat(v.Seconds(), 'f', -1, 64)
 	case float64:
 		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []*ffschema.Chapter:
+		return ffschema.Chapters(v).String()
 	default:
 		return fmt.Sprint(v)
 	}
@@ -48,12 +51,14 @@ func (m meta) Value() string {
 // LIFECYCLE
 
 func init() {
-	// Suppress ffmpeg's own logging
-	ffmpeg.SetLogging(false, nil)
-
 	// Add metadata handler for video files
-	metadata.AddHandler(regexp.MustCompile(`^video/.*$`), func(_ context.Context, r io.Reader, filter string) ([]gomedia.Metadata, error) {
-		reader, err := ffmpeg.NewReader(r)
+	metadata.AddHandler(regexp.MustCompile(`^video/.*$`), func(ctx context.Context, r io.Reader, filter string) ([]gomedia.Metadata, error) {
+		// Log messages from the demuxer with the context of the caller
+		var opts []ffmpeg.Opt
+		if ctx != nil {
+			opts = append(opts, ffmpeg.OptContext(ctx))
+		}
+		reader, err := ffmpeg.NewReader(r, opts...)
 		if err != nil {
 			return nil, err
 		}
@@ -64,6 +69,11 @@ func init() {
 		// Duration
 		entries["video:Duration"] = meta{key: "video:Duration", value: reader.Duration()}
 
+		// Chapters
+		if chapters := reader.Chapters(); len(chapters) > 0 {
+			entries["video:Chapters"] = meta{key: "video:Chapters", value: chapters}
+		}
+
 		// Tags, normalized and mapped onto dc:/video: keys where a
 		// canonical mapping exists; noisy or uninteresting tags are dropped
 		for _, tag := range reader.Metadata() {
@@ -9,6 +9,7 @@ import (
 
 	// Packages
 	metadata "github.com/mutablelogic/go-media/metadata"
+	ffschema "github.com/mutablelogic/go-media/pkg/ffmpeg/schema"
 )
 
 const testDir = "../../etc/test"
@@ -71,6 +72,21 @@ func Test_handler_000(t *testing.T) {
 	t.Logf("video:Duration = %s", durVal)
 }
 
+// Test_meta_000 checks that chapters are formatted one per line, as start
+// and end seconds followed by the title.
+func Test_meta_000(t *testing.T) {
+	m := meta{key: "video:Chapters", value: []*ffschema.Chapter{
+		{Start: 0, End: 1500 * time.Millisecond, Title: "Opening"},
+		{Start: 1500 * time.Millisecond, End: 3 * time.Second},
+	}}
+	if got, want := m.Value(), "0-1.5 Opening\n1.5-3"; got != want {
+		t.Errorf("Value() = %q, want %q", got, want)
+	}
+	if _, ok := m.Any().([]*ffschema.Chapter); !ok {
+		t.Errorf("Any() = %T, want []*schema.Chapter", m.Any())
+	}
+}
+
 // Test_sanitizeKey_000 checks that common tag key variants are mapped onto
 // their canonical dc:/video: key.
 func Test_sanitizeKey_000(t *testing.T) {
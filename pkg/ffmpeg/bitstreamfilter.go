@@ -0,0 +1,187 @@
+package ffmpeg
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"slices"
+	"syscall"
+
+	// Packages
+	media "github.com/mutablelogic/go-media"
+	schema "github.com/mutablelogic/go-media/pkg/ffmpeg/schema"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// BitstreamFilter rewrites packets without decoding them, for example to
+// convert H.264 from the length-prefixed form used in MP4 to the Annex B
+// form used in MPEG-TS. Place it between the Reader.Decode packet callback
+// and Writer.Write when copying streams.
+type BitstreamFilter struct {
+	ctx  *ff.AVBSFContext
+	spec string
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+var (
+	// Output formats which need H.264 and HEVC in Annex B form
+	annexbFormats = []string{"mpegts", "hls", "h264", "hevc", "rtp_mpegts"}
+
+	// Output formats which need AAC with an AudioSpecificConfig rather
+	// than ADTS headers
+	ascFormats = []string{"mp4", "mov", "ipod", "ismv", "3gp", "3g2", "psp", "f4v", "flv", "matroska"}
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+// Create a bitstream filter for packets with the given parameters. The spec
+// is one or more filters separated by commas, with options, for example
+// "h264_mp4toannexb" or "setts=ts=PTS-STARTPTS,dump_extra".
+func NewBitstreamFilter(spec string, par *Par) (*BitstreamFilter, error) {
+	if par == nil {
+		return nil, media.ErrBadParameter.With("nil parameters")
+	}
+	ctx, err := ff.AVCodec_bsf_list_parse_str(spec)
+	if err != nil {
+		return nil, media.ErrBadParameter.Withf("invalid bitstream filter %q: %v", spec, err)
+	}
+
+	// Set the input parameters and initialize, which checks the codec is
+	// supported by the filter
+	if err := ff.AVCodec_parameters_copy(ctx.ParIn(), &par.AVCodecParameters); err != nil {
+		ff.AVCodec_bsf_free(ctx)
+		return nil, err
+	}
+	if par.timebase.Num() != 0 && par.timebase.Den() != 0 {
+		ctx.SetTimeBaseIn(par.timebase)
+	}
+	if err := ff.AVCodec_bsf_init(ctx); err != nil {
+		ff.AVCodec_bsf_free(ctx)
+		return nil, media.ErrBadParameter.Withf("bitstream filter %q: %v", spec, err)
+	}
+
+	// Return success
+	return &BitstreamFilter{ctx: ctx, spec: spec}, nil
+}
+
+// Release the filter
+func (f *BitstreamFilter) Close() error {
+	if f.ctx != nil {
+		ff.AVCodec_bsf_free(f.ctx)
+	}
+	f.ctx = nil
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (f *BitstreamFilter) MarshalJSON() ([]byte, error) {
+	type jsonBitstreamFilter struct {
+		Spec string           `json:"spec"`
+		Ctx  *ff.AVBSFContext `json:"context,omitempty"`
+	}
+	return json.Marshal(jsonBitstreamFilter{Spec: f.spec, Ctx: f.ctx})
+}
+
+func (f *BitstreamFilter) String() string {
+	data, _ := json.MarshalIndent(f, "", "  ")
+	return string(data)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Return the parameters of the filtered packets, which should be used for
+// the output stream. Returns nil if the filter is closed.
+func (f *BitstreamFilter) Par() *Par {
+	if f.ctx == nil {
+		return nil
+	}
+	par := new(Par)
+	if err := ff.AVCodec_parameters_copy(&par.AVCodecParameters, f.ctx.ParOut()); err != nil {
+		return nil
+	}
+	par.timebase = f.ctx.TimeBaseOut()
+	return par
+}
+
+// Filter a packet and pass the filtered packets to fn, which may be called
+// zero or more times. The packet is not modified. Pass nil to flush the
+// filter at the end of the stream.
+func (f *BitstreamFilter) Filter(pkt *Packet, fn EncoderPacketFn) error {
+	if fn == nil {
+		return media.ErrBadParameter.With("nil callback function")
+	}
+	if f.ctx == nil {
+		return errors.New("bitstream filter is closed")
+	}
+
+	// The filter takes ownership of the data, so send a new reference
+	var in *ff.AVPacket
+	if pkt != nil && pkt.AVPacket != nil {
+		if in = ff.AVCodec_packet_clone(pkt.AVPacket); in == nil {
+			return errors.New("failed to allocate packet")
+		}
+		defer ff.AVCodec_packet_free(in)
+	}
+	if err := ff.AVCodec_bsf_send_packet(f.ctx, in); err != nil {
+		return err
+	}
+
+	// Receive the filtered packets
+	for {
+		out := ff.AVCodec_packet_alloc()
+		if out == nil {
+			return errors.New("failed to allocate packet")
+		}
+		if err := ff.AVCodec_bsf_receive_packet(f.ctx, out); errors.Is(err, syscall.EAGAIN) || errors.Is(err, io.EOF) {
+			ff.AVCodec_packet_free(out)
+			return nil
+		} else if err != nil {
+			ff.AVCodec_packet_free(out)
+			return err
+		}
+		out.SetTimeBase(f.ctx.TimeBaseOut())
+		err := fn(schema.NewPacket(out))
+		ff.AVCodec_packet_free(out)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE
+
+// autoBitstreamFilter returns the filter needed to copy a stream with the
+// given parameters into the output format, or an empty string
+func autoBitstreamFilter(oformat *ff.AVOutputFormat, par *Par) string {
+	if oformat == nil || par == nil {
+		return ""
+	}
+	extradata := par.Extradata()
+	switch par.CodecID() {
+	case ff.AV_CODEC_ID_H264, ff.AV_CODEC_ID_HEVC:
+		// avcC and hvcC records start with a version of 1, where Annex B
+		// starts with a start code
+		if slices.Contains(annexbFormats, oformat.Name()) && len(extradata) > 0 && extradata[0] == 1 {
+			if par.CodecID() == ff.AV_CODEC_ID_H264 {
+				return "h264_mp4toannexb"
+			}
+			return "hevc_mp4toannexb"
+		}
+	case ff.AV_CODEC_ID_AAC:
+		// ADTS streams carry their configuration in each packet
+		if slices.Contains(ascFormats, oformat.Name()) && len(extradata) == 0 {
+			return "aac_adtstoasc"
+		}
+	}
+	return ""
+}
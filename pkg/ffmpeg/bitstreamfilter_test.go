@@ -0,0 +1,136 @@
+package ffmpeg_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	// Packages
+	media "github.com/mutablelogic/go-media"
+	ffmpeg "github.com/mutablelogic/go-media/pkg/ffmpeg"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
+	assert "github.com/stretchr/testify/assert"
+)
+
+func Test_bitstreamfilter_001(t *testing.T) {
+	assert := assert.New(t)
+
+	reader, err := ffmpeg.Open(testInputMP4)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer reader.Close()
+
+	// Find the H.264 video stream
+	var par *ffmpeg.Par
+	var index int
+	for _, stream := range reader.Streams(media.VIDEO) {
+		if stream.CodecPar().CodecID() == ff.AV_CODEC_ID_H264 {
+			par = &ffmpeg.Par{AVCodecParameters: *stream.CodecPar()}
+			index = stream.Index()
+		}
+	}
+	if par == nil {
+		t.Skip("no H.264 stream in sample")
+	}
+
+	// Convert to Annex B, which changes the extradata to start codes
+	bsf, err := ffmpeg.NewBitstreamFilter("h264_mp4toannexb", par)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer bsf.Close()
+	if extradata := bsf.Par().Extradata(); assert.NotEmpty(extradata) {
+		assert.NotEqual(byte(1), extradata[0])
+	}
+
+	var in, out int
+	err = reader.Decode(context.Background(), func(stream int, pkt *ffmpeg.Packet) error {
+		if stream != index {
+			return nil
+		}
+		in++
+		return bsf.Filter(pkt, func(pkt *ffmpeg.Packet) error {
+			data := pkt.Bytes()
+			if assert.GreaterOrEqual(len(data), 4) {
+				assert.Equal([]byte{0, 0, 0, 1}, data[:4])
+			}
+			out++
+			return nil
+		})
+	})
+	assert.NoError(err)
+	assert.NoError(bsf.Filter(nil, func(*ffmpeg.Packet) error {
+		out++
+		return nil
+	}))
+	assert.Greater(in, 0)
+	assert.Equal(in, out)
+}
+
+func Test_bitstreamfilter_002(t *testing.T) {
+	assert := assert.New(t)
+	audio := ffmpeg.AudioPar("fltp", "stereo", 44100)
+
+	// Unknown filter
+	_, err := ffmpeg.NewBitstreamFilter("not_a_filter", audio)
+	assert.Error(err)
+
+	// Filter which doesn't support the codec
+	par := ffmpeg.VideoPar("yuv420p", "320x240", 25)
+	par.SetCodecID(ff.AV_CODEC_ID_MJPEG)
+	_, err = ffmpeg.NewBitstreamFilter("h264_mp4toannexb", par)
+	assert.Error(err)
+
+	// A chain with options
+	bsf, err := ffmpeg.NewBitstreamFilter("setts=ts=PTS-STARTPTS,dump_extra", par)
+	if assert.NoError(err) {
+		assert.NoError(bsf.Close())
+		assert.Error(bsf.Filter(nil, func(*ffmpeg.Packet) error { return nil }))
+	}
+}
+
+func Test_bitstreamfilter_003(t *testing.T) {
+	assert := assert.New(t)
+
+	reader, err := ffmpeg.Open(testInputMP4)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer reader.Close()
+
+	// Copy MP4 into MPEG-TS, which inserts h264_mp4toannexb
+	outputFile := filepath.Join(t.TempDir(), "remux.ts")
+	opts := []ffmpeg.Opt{ffmpeg.OptCopy()}
+	streams := make(map[int]int)
+	for _, stream := range reader.Streams(media.ANY) {
+		if !stream.Type().Is(media.AUDIO) && !stream.Type().Is(media.VIDEO) {
+			continue
+		}
+		opts = append(opts, ffmpeg.OptStream(0, &ffmpeg.Par{AVCodecParameters: *stream.CodecPar()}))
+		streams[stream.Index()] = len(streams)
+	}
+	writer, err := ffmpeg.Create(outputFile, opts...)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	err = reader.Decode(context.Background(), func(stream int, pkt *ffmpeg.Packet) error {
+		index, exists := streams[stream]
+		if !exists {
+			return nil
+		}
+		pkt.SetStreamIndex(index)
+		return writer.Write(pkt)
+	})
+	assert.NoError(err)
+	assert.NoError(writer.Close())
+
+	// The output has the same streams
+	output, err := ffmpeg.Open(outputFile)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer output.Close()
+	assert.Len(output.Streams(media.AUDIO), len(reader.Streams(media.AUDIO)))
+	assert.Len(output.Streams(media.VIDEO), len(reader.Streams(media.VIDEO)))
+}
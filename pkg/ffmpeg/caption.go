@@ -0,0 +1,146 @@
+package ffmpeg
+
+import (
+	"errors"
+
+	// Packages
+	media "github.com/mutablelogic/go-media"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// CaptionDecoder decodes CEA-608 closed captions into subtitles. Captions
+// are carried as ATSC A53 cc_data in the side data of video frames, for
+// example in the SEI messages of H.264 and HEVC, rather than as a subtitle
+// stream. Captions in CEA-708 services are not decoded.
+type CaptionDecoder struct {
+	ctx   *ff.AVCodecContext
+	pkt   *ff.AVPacket
+	field int
+}
+
+// CaptionFn is a function which is called with each decoded caption. It
+// should return nil to continue decoding.
+type CaptionFn func(*Subtitle) error
+
+////////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+// Erase Displayed Memory commands for each field, with odd parity, which
+// end the caption shown at the end of the stream
+var captionErase = [][]byte{
+	{0xFC, 0x94, 0x2C},
+	{0xFD, 0x15, 0x2C},
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+// Create a decoder for the captions in a field, which is 1 for the CC1 and
+// CC2 channels or 2 for the CC3 and CC4 channels. Timestamps are in the
+// timebase, which is usually the timebase of the video stream.
+func NewCaptionDecoder(field int, tb ff.AVRational) (*CaptionDecoder, error) {
+	if field != 1 && field != 2 {
+		return nil, media.ErrBadParameter.Withf("invalid caption field %d", field)
+	}
+	if tb.Num() <= 0 || tb.Den() <= 0 {
+		return nil, media.ErrBadParameter.With("invalid timebase")
+	}
+	codec := ff.AVCodec_find_decoder(ff.AV_CODEC_ID_EIA_608)
+	if codec == nil {
+		return nil, errors.Join(ErrCodecNotFound, errors.New("codec: "+ff.AV_CODEC_ID_EIA_608.Name()))
+	}
+	d := &CaptionDecoder{field: field}
+	if d.ctx = ff.AVCodec_alloc_context(codec); d.ctx == nil {
+		return nil, errors.New("failed to allocate codec context")
+	}
+	if d.pkt = ff.AVCodec_packet_alloc(); d.pkt == nil {
+		d.Close()
+		return nil, errors.New("failed to allocate packet")
+	}
+
+	// Captions take their presentation time from the packets
+	d.ctx.SetPktTimeBase(tb)
+
+	// Decode the captions of one field
+	opts := ff.AVUtil_dict_alloc()
+	if opts == nil {
+		d.Close()
+		return nil, errors.New("could not allocate options dictionary")
+	}
+	defer ff.AVUtil_dict_free(opts)
+	value := "first"
+	if field == 2 {
+		value = "second"
+	}
+	if err := ff.AVUtil_dict_set(opts, "data_field", value, 0); err != nil {
+		d.Close()
+		return nil, err
+	}
+	if err := ff.AVCodec_open(d.ctx, codec, opts); err != nil {
+		d.Close()
+		return nil, err
+	}
+
+	// Return success
+	return d, nil
+}
+
+// Release the decoder
+func (d *CaptionDecoder) Close() error {
+	if d.pkt != nil {
+		ff.AVCodec_packet_free(d.pkt)
+	}
+	if d.ctx != nil {
+		ff.AVCodec_free_context(d.ctx)
+	}
+	d.pkt = nil
+	d.ctx = nil
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Decode the cc_data triplets of a frame, which is presented at pts, and
+// pass each caption which has ended to fn. A caption is passed when it is
+// removed from the screen, so it has a start and end time. Pass nil data to
+// flush the caption shown at pts at the end of the stream.
+func (d *CaptionDecoder) Decode(pts int64, data []byte, fn CaptionFn) error {
+	if fn == nil {
+		return media.ErrBadParameter.With("nil callback function")
+	}
+	if d.ctx == nil {
+		return media.ErrBadParameter.With("caption decoder is closed")
+	}
+	if data == nil {
+		data = captionErase[d.field-1]
+	}
+	if len(data) < 3 {
+		return nil
+	}
+
+	// Make a packet with a copy of the data, as the decoder rewrites the
+	// bytes which fail the parity check
+	ff.AVCodec_packet_unref(d.pkt)
+	if err := ff.AVCodec_packet_from_data(d.pkt, data[:len(data)-len(data)%3]); err != nil {
+		return err
+	}
+	d.pkt.SetPts(pts)
+	d.pkt.SetDts(pts)
+	defer ff.AVCodec_packet_unref(d.pkt)
+
+	sub, err := ff.AVCodec_decode_subtitle(d.ctx, d.pkt)
+	if err != nil {
+		return err
+	} else if sub == nil {
+		return nil
+	}
+	defer ff.AVSubtitle_free(sub)
+	if caption := NewSubtitle(sub); caption != nil && !caption.Empty() {
+		return fn(caption)
+	}
+	return nil
+}
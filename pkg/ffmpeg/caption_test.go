@@ -0,0 +1,82 @@
+package ffmpeg
+
+import (
+	"math/bits"
+	"strings"
+	"testing"
+	"time"
+
+	// Packages
+	schema "github.com/mutablelogic/go-media/pkg/ffmpeg/schema"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
+	assert "github.com/stretchr/testify/assert"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TEST CAPTIONS
+
+// Return a valid CEA-608 cc_data triplet for the first field, with odd parity
+func testCC(hi, lo byte) []byte {
+	parity := func(b byte) byte {
+		if bits.OnesCount8(b)%2 == 0 {
+			return b | 0x80
+		}
+		return b
+	}
+	return []byte{0xFC, parity(hi), parity(lo)}
+}
+
+func Test_caption_services(t *testing.T) {
+	assert := assert.New(t)
+
+	var sd *schema.SideData
+	cea608, cea708 := sd.CaptionServices()
+	assert.False(cea608)
+	assert.False(cea708)
+
+	// Padding only
+	sd = &schema.SideData{Captions: []byte{0xFC, 0x80, 0x80, 0xF9, 0x00, 0x00, 0xFA, 0x00, 0x00}}
+	cea608, cea708 = sd.CaptionServices()
+	assert.False(cea608)
+	assert.False(cea708)
+
+	// CEA-608 and a DTVCC packet
+	sd.Captions = append(testCC(0x14, 0x20), 0xFF, 0x02, 0x21)
+	cea608, cea708 = sd.CaptionServices()
+	assert.True(cea608)
+	assert.True(cea708)
+}
+
+func Test_caption_decode(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := NewCaptionDecoder(3, ff.AVUtil_rational(1, 1000))
+	assert.Error(err)
+
+	dec, err := NewCaptionDecoder(1, ff.AVUtil_rational(1, 1000))
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer dec.Close()
+
+	var captions []*Subtitle
+	fn := func(caption *Subtitle) error {
+		captions = append(captions, caption)
+		return nil
+	}
+
+	// Load a pop-on caption, and show it at one second
+	var data []byte
+	data = append(data, testCC(0x14, 0x20)...)
+	data = append(data, testCC('H', 'I')...)
+	data = append(data, testCC(0x14, 0x2F)...)
+	assert.NoError(dec.Decode(1000, data, fn))
+
+	// Flush the caption at three seconds
+	assert.NoError(dec.Decode(3000, nil, fn))
+	if assert.Len(captions, 1) {
+		assert.True(strings.Contains(captions[0].Text, "HI"))
+		assert.Equal(time.Second, captions[0].Start)
+		assert.Equal(3*time.Second, captions[0].End)
+	}
+}
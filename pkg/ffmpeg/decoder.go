@@ -3,14 +3,18 @@ package ffmpeg
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
+	"math"
+	"strings"
 	"sync"
 	"syscall"
+	"unsafe"
 
 	// Packages
 	media "github.com/mutablelogic/go-media"
 	schema "github.com/mutablelogic/go-media/pkg/ffmpeg/schema"
-	ff "github.com/mutablelogic/go-media/sys/ffmpeg80"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
 )
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -51,11 +55,15 @@ type decoder struct {
 
 // Per-stream decoder that handles individual stream decoding
 type streamDecoder struct {
-	stream    int
-	codec     *ff.AVCodecContext
-	resampler *Resampler
-	frame     *ff.AVFrame
-	timeBase  ff.AVRational
+	stream     int
+	codec      *ff.AVCodecContext
+	resampler  *Resampler
+	frame      *ff.AVFrame
+	timeBase   ff.AVRational
+	autorotate string  // Filters which rotate video upright, or empty
+	rotate     *Filter // Created from the first decoded frame
+	start, end int64   // Range of timestamps to output, or AV_NOPTS_VALUE
+	done       bool    // Set when the end of the range is reached
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -118,6 +126,9 @@ func (d *decoder) readPackets(ctx context.Context, packetfn DecoderPacketFn) err
 		d.mu.Unlock()
 	}()
 
+	// Log messages from the demuxer with the context of the operation
+	defer setLogContext(unsafe.Pointer(d.reader.input), ctx)()
+
 	// Read packets until EOF or error
 	for {
 		// Check context cancellation
@@ -130,14 +141,21 @@ func (d *decoder) readPackets(ctx context.Context, packetfn DecoderPacketFn) err
 		// Unref any previous packet data before reading
 		ff.AVCodec_packet_unref(d.pkt)
 
-		// Read next packet from any stream
-		if err := ff.AVFormat_read_frame(d.reader.input, d.pkt); err != nil {
+		// Read next packet from any stream, which is aborted when the context
+		// is cancelled or the read timeout expires
+		d.reader.intr.begin(ctx, d.reader.timeout)
+		if err := d.reader.intr.end(ff.AVFormat_read_frame(d.reader.input, d.pkt)); err != nil {
 			if errors.Is(err, io.EOF) {
 				return nil
 			}
 			return err
 		}
 
+		// Skip packets from streams outside the selected program
+		if d.discarded(d.pkt.StreamIndex()) {
+			continue
+		}
+
 		// Wrap the packet and call user function
 		packet := schema.NewPacket(d.pkt)
 		if err := packetfn(packet.StreamIndex(), packet); err != nil {
@@ -152,7 +170,9 @@ func (d *decoder) readPackets(ctx context.Context, packetfn DecoderPacketFn) err
 // Decode and demux the media stream into frames and subtitles. The map function determines which
 // streams to decode and what output parameters to use. The framefn is called for each
 // decoded frame from any mapped stream. The subtitlefn is called for each decoded subtitle.
-func (d *decoder) decodeFrames(ctx context.Context, mapfn DecoderMapFunc, framefn DecoderFrameFn, subtitlefn DecoderSubtitleFn) error {
+// Frames outside the range from start to end, in AV_TIME_BASE units, are dropped, and
+// either can be AV_NOPTS_VALUE.
+func (d *decoder) decodeFrames(ctx context.Context, mapfn DecoderMapFunc, framefn DecoderFrameFn, subtitlefn DecoderSubtitleFn, start, end int64) error {
 	d.mu.Lock()
 	if d.busy {
 		d.mu.Unlock()
@@ -182,6 +202,18 @@ func (d *decoder) decodeFrames(ctx context.Context, mapfn DecoderMapFunc, framef
 		return errors.New("no streams to decode")
 	}
 
+	// Log messages from the demuxer and decoders with the context of the
+	// operation
+	defer setLogContext(unsafe.Pointer(d.reader.input), ctx)()
+	for _, dec := range d.decoders {
+		defer setLogContext(unsafe.Pointer(dec.codec), ctx)()
+	}
+
+	// Set the range of each stream
+	for _, dec := range d.decoders {
+		dec.setRange(start, end)
+	}
+
 	// Read and decode packets
 	for {
 		// Check context cancellation
@@ -194,8 +226,10 @@ func (d *decoder) decodeFrames(ctx context.Context, mapfn DecoderMapFunc, framef
 		// Unref any previous packet data before reading
 		ff.AVCodec_packet_unref(d.pkt)
 
-		// Read next packet from any stream
-		if err := ff.AVFormat_read_frame(d.reader.input, d.pkt); err != nil {
+		// Read next packet from any stream, which is aborted when the context
+		// is cancelled or the read timeout expires
+		d.reader.intr.begin(ctx, d.reader.timeout)
+		if err := d.reader.intr.end(ff.AVFormat_read_frame(d.reader.input, d.pkt)); err != nil {
 			if errors.Is(err, io.EOF) {
 				break
 			}
@@ -205,8 +239,8 @@ func (d *decoder) decodeFrames(ctx context.Context, mapfn DecoderMapFunc, framef
 		// Get the stream decoder for this packet
 		streamIndex := d.pkt.StreamIndex()
 		dec := d.decoders[streamIndex]
-		if dec == nil {
-			// Skip packets from unmapped streams
+		if dec == nil || dec.done {
+			// Skip packets from unmapped streams, or after the end of the range
 			continue
 		}
 
@@ -217,6 +251,11 @@ func (d *decoder) decodeFrames(ctx context.Context, mapfn DecoderMapFunc, framef
 			}
 			return err
 		}
+
+		// Stop reading at the end of the range
+		if d.finished() {
+			break
+		}
 	}
 
 	// Flush all decoders
@@ -251,12 +290,32 @@ func (d *decoder) mapStreams(fn DecoderMapFunc) error {
 	for _, stream := range d.reader.input.Streams() {
 		streamIndex := stream.Index()
 
+		// Ignore streams outside the selected program
+		if stream.Discard() == ff.AVDISCARD_ALL {
+			continue
+		}
+
 		// Get decoder parameters and map to a decoder
 		srcPar := &Par{
 			AVCodecParameters: *stream.CodecPar(),
 			timebase:          stream.TimeBase(),
 		}
 
+		// Describe video in the upright orientation when rotating
+		var autorotate string
+		if d.reader.rotate && srcPar.CodecType() == ff.AVMEDIA_TYPE_VIDEO {
+			var transpose bool
+			autorotate, transpose = autorotateFilter(schema.NewPacketSideData(stream.CodecPar().CodedSideData()))
+			if transpose {
+				w, h, sar := srcPar.Width(), srcPar.Height(), srcPar.SampleAspectRatio()
+				srcPar.SetWidth(h)
+				srcPar.SetHeight(w)
+				if sar.Num() != 0 {
+					srcPar.SetSampleAspectRatio(ff.AVUtil_rational(sar.Den(), sar.Num()))
+				}
+			}
+		}
+
 		destPar, err := fn(streamIndex, srcPar)
 		if err != nil {
 			result = errors.Join(result, err)
@@ -268,7 +327,7 @@ func (d *decoder) mapStreams(fn DecoderMapFunc) error {
 		}
 
 		// Create decoder for this stream
-		dec, err := newStreamDecoder(stream, srcPar, destPar, d.reader.force)
+		dec, err := newStreamDecoder(stream, srcPar, destPar, d.reader.force, autorotate)
 		if err != nil {
 			// Skip unsupported codecs rather than failing entirely
 			if errors.Is(err, ErrCodecNotFound) {
@@ -291,14 +350,64 @@ func (d *decoder) mapStreams(fn DecoderMapFunc) error {
 	return result
 }
 
+// Return true when all streams have reached the end of the range. Subtitle
+// streams are sparse, and attached pictures have a single packet, so they are
+// only considered when there are no other audio or video streams.
+func (d *decoder) finished() bool {
+	var frames bool
+	for _, dec := range d.decoders {
+		if d.sparse(dec) {
+			continue
+		}
+		if !dec.done {
+			return false
+		}
+		frames = true
+	}
+	if frames {
+		return true
+	}
+	for _, dec := range d.decoders {
+		if !dec.done {
+			return false
+		}
+	}
+	return true
+}
+
+// Return true if a stream is a subtitle stream or an attached picture, which
+// may never have a packet past the end of the range
+func (d *decoder) sparse(dec *streamDecoder) bool {
+	if dec.codec.CodecType() == ff.AVMEDIA_TYPE_SUBTITLE {
+		return true
+	}
+	if ctx := d.reader.input.Stream(dec.stream); ctx != nil {
+		return ctx.Disposition().Is(ff.AV_DISPOSITION_ATTACHED_PIC)
+	}
+	return false
+}
+
+// Return true if packets from a stream are discarded, because the stream is
+// not in the selected program. Demuxers do not always drop these packets.
+func (d *decoder) discarded(stream int) bool {
+	if ctx := d.reader.input.Stream(stream); ctx != nil {
+		return ctx.Discard() == ff.AVDISCARD_ALL
+	}
+	return false
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 // PRIVATE METHODS - STREAM DECODER
 
-// Create a new stream decoder
-func newStreamDecoder(stream *ff.AVStream, srcPar, destPar *Par, force bool) (*streamDecoder, error) {
+// Create a new stream decoder. The source parameters describe the decoded
+// frames after any rotation with the autorotate filters.
+func newStreamDecoder(stream *ff.AVStream, srcPar, destPar *Par, force bool, autorotate string) (*streamDecoder, error) {
 	dec := &streamDecoder{
-		stream:   stream.Index(),
-		timeBase: stream.TimeBase(),
+		stream:     stream.Index(),
+		timeBase:   stream.TimeBase(),
+		autorotate: autorotate,
+		start:      int64(ff.AV_NOPTS_VALUE),
+		end:        int64(ff.AV_NOPTS_VALUE),
 	}
 
 	// Allocate frame for decoder output
@@ -329,6 +438,9 @@ func newStreamDecoder(stream *ff.AVStream, srcPar, destPar *Par, force bool) (*s
 		return nil, err
 	}
 
+	// Decoded subtitles take their presentation time from the packets
+	dec.codec.SetPktTimeBase(stream.TimeBase())
+
 	// Open codec
 	if err := ff.AVCodec_open(dec.codec, codec, nil); err != nil {
 		dec.close()
@@ -386,8 +498,50 @@ func parametersNeedResampling(src, dest *Par) bool {
 	}
 }
 
+// Return the filters which display video upright according to the display
+// matrix, and whether they swap the width and height. This follows the
+// ffmpeg command line tool. The filters are empty if the video is upright.
+func autorotateFilter(sd *schema.SideData) (string, bool) {
+	if sd == nil || sd.DisplayMatrix == nil {
+		return "", false
+	}
+	m := sd.DisplayMatrix.Matrix
+	theta := sd.DisplayMatrix.Rotate()
+	switch {
+	case math.Abs(theta-90) < 1:
+		if m[3] > 0 {
+			return "transpose=cclock_flip", true
+		}
+		return "transpose=clock", true
+	case math.Abs(theta-180) < 1:
+		var filters []string
+		if m[0] < 0 {
+			filters = append(filters, "hflip")
+		}
+		if m[4] < 0 {
+			filters = append(filters, "vflip")
+		}
+		return strings.Join(filters, ","), false
+	case math.Abs(theta-270) < 1:
+		if m[3] < 0 {
+			return "transpose=clock_flip", true
+		}
+		return "transpose=cclock", true
+	case math.Abs(theta) > 1:
+		return fmt.Sprintf("rotate=%f", theta*math.Pi/180), false
+	case m[4] < 0:
+		return "vflip", false
+	default:
+		return "", false
+	}
+}
+
 // Close and free stream decoder resources
 func (dec *streamDecoder) close() {
+	if dec.rotate != nil {
+		dec.rotate.Close()
+		dec.rotate = nil
+	}
 	if dec.resampler != nil {
 		dec.resampler.Close()
 		dec.resampler = nil
@@ -434,20 +588,21 @@ func (dec *streamDecoder) decode(pkt *ff.AVPacket, framefn DecoderFrameFn, subti
 		// Set frame timebase
 		dec.frame.SetTimeBase(dec.timeBase)
 
-		// Resample/rescale if needed
-		srcFrame := (*Frame)(dec.frame)
-		if dec.resampler != nil {
-			if err := dec.resampler.Resample(srcFrame, func(destFrame *Frame) error {
-				if destFrame != nil {
-					return framefn(dec.stream, destFrame)
-				}
-				return nil
-			}); err != nil {
-				return err
+		// Drop frames outside the range, or trim audio to the range
+		frame, err := dec.trimFrame((*Frame)(dec.frame))
+		if err != nil {
+			return err
+		}
+
+		// Rotate upright, then resample/rescale if needed
+		if frame != nil {
+			err := dec.rotateFrame(frame, func(frame *Frame) error {
+				return dec.resampleFrame(frame, framefn)
+			})
+			if frame != (*Frame)(dec.frame) {
+				frame.Close()
 			}
-		} else {
-			// No resampling, pass frame directly
-			if err := framefn(dec.stream, srcFrame); err != nil {
+			if err != nil {
 				return err
 			}
 		}
@@ -456,9 +611,172 @@ func (dec *streamDecoder) decode(pkt *ff.AVPacket, framefn DecoderFrameFn, subti
 		ff.AVUtil_frame_unref(dec.frame)
 	}
 
+	// Flush any frames held by the rotation filter
+	if pkt == nil && dec.rotate != nil {
+		return dec.rotateFrame(nil, func(frame *Frame) error {
+			return dec.resampleFrame(frame, framefn)
+		})
+	}
+
 	return nil
 }
 
+// Rotate a video frame upright and pass the result to fn, or pass the frame
+// directly when there is no rotation. A nil frame flushes the filter.
+func (dec *streamDecoder) rotateFrame(src *Frame, fn func(*Frame) error) error {
+	if dec.autorotate == "" {
+		if src == nil {
+			return nil
+		}
+		return fn(src)
+	}
+
+	// Create the filter from the first frame, as the decoder may output a
+	// different pixel format to the one in the stream parameters
+	if dec.rotate == nil {
+		if src == nil {
+			return nil
+		}
+		par := &Par{timebase: dec.timeBase}
+		par.SetCodecType(ff.AVMEDIA_TYPE_VIDEO)
+		par.SetPixelFormat(src.PixelFormat())
+		par.SetWidth(src.Width())
+		par.SetHeight(src.Height())
+		par.SetSampleAspectRatio(src.SampleAspectRatio())
+		par.ensureSampleAspectRatio()
+		filter, err := NewFilter(dec.autorotate, par, par)
+		if err != nil {
+			return err
+		}
+		dec.rotate = filter
+	}
+
+	return dec.rotate.Process(src, func(frame *Frame) error {
+		if frame == nil {
+			return nil
+		}
+		// The frame is now upright, so the display matrix no longer applies
+		ff.AVUtil_frame_remove_side_data((*ff.AVFrame)(frame), ff.AV_FRAME_DATA_DISPLAYMATRIX)
+		(*ff.AVFrame)(frame).SetTimeBase(dec.timeBase)
+		return fn(frame)
+	})
+}
+
+// Resample or rescale a frame if needed and pass the result to the callback
+func (dec *streamDecoder) resampleFrame(frame *Frame, framefn DecoderFrameFn) error {
+	if dec.resampler == nil {
+		return framefn(dec.stream, frame)
+	}
+	return dec.resampler.Resample(frame, func(destFrame *Frame) error {
+		if destFrame != nil {
+			return framefn(dec.stream, destFrame)
+		}
+		return nil
+	})
+}
+
+// Set the range of timestamps to output, in AV_TIME_BASE units
+func (dec *streamDecoder) setRange(start, end int64) {
+	tb := ff.AVUtil_rational(1, ff.AV_TIME_BASE)
+	if start != int64(ff.AV_NOPTS_VALUE) {
+		dec.start = ff.AVUtil_rational_rescale_q(start, tb, dec.timeBase)
+	}
+	if end != int64(ff.AV_NOPTS_VALUE) {
+		dec.end = ff.AVUtil_rational_rescale_q(end, tb, dec.timeBase)
+	}
+}
+
+// Return the frame if it is within the range, or nil if it should be dropped.
+// Audio frames which overlap the start or end of the range are trimmed to the
+// sample, which returns a new frame which the caller should release.
+func (dec *streamDecoder) trimFrame(frame *Frame) (*Frame, error) {
+	if dec.start == int64(ff.AV_NOPTS_VALUE) && dec.end == int64(ff.AV_NOPTS_VALUE) {
+		return frame, nil
+	}
+
+	// Frames without a timestamp are passed through
+	pts := (*ff.AVFrame)(frame).BestEffortTimestamp()
+	if pts == int64(ff.AV_NOPTS_VALUE) {
+		pts = frame.Pts()
+	}
+	if pts == int64(ff.AV_NOPTS_VALUE) {
+		return frame, nil
+	}
+	if frame.Type() == media.AUDIO {
+		return dec.trimAudio(frame, pts)
+	}
+
+	// Video frames are output in presentation order, so the end of the range
+	// is reached with the first frame at or after the end
+	if dec.end != int64(ff.AV_NOPTS_VALUE) && pts >= dec.end {
+		dec.done = true
+		return nil, nil
+	}
+	if dec.start != int64(ff.AV_NOPTS_VALUE) && pts < dec.start {
+		return nil, nil
+	}
+	return frame, nil
+}
+
+// Trim the samples of an audio frame outside the range
+func (dec *streamDecoder) trimAudio(frame *Frame, pts int64) (*Frame, error) {
+	samples := ff.AVUtil_rational(1, frame.SampleRate())
+	first := ff.AVUtil_rational_rescale_q(pts, dec.timeBase, samples)
+	n := frame.NumSamples()
+
+	// Count the samples to skip at the start, and the samples to keep up to the end
+	skip, keep := 0, n
+	if dec.start != int64(ff.AV_NOPTS_VALUE) {
+		if start := ff.AVUtil_rational_rescale_q(dec.start, dec.timeBase, samples); start > first {
+			skip = int(min(start-first, int64(n)))
+		}
+	}
+	if dec.end != int64(ff.AV_NOPTS_VALUE) {
+		if end := ff.AVUtil_rational_rescale_q(dec.end, dec.timeBase, samples); end <= first+int64(n) {
+			keep = int(max(end-first, 0))
+			dec.done = true
+		}
+	}
+
+	switch {
+	case skip >= keep:
+		return nil, nil
+	case skip == 0 && keep == n:
+		return frame, nil
+	}
+
+	// Copy the samples into a new frame
+	dest, err := NewFrame(nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := frame.copyParameters((*ff.AVFrame)(dest), media.AUDIO); err != nil {
+		dest.Close()
+		return nil, err
+	}
+	(*ff.AVFrame)(dest).SetNumSamples(keep - skip)
+	if err := dest.AllocateBuffers(); err != nil {
+		dest.Close()
+		return nil, err
+	}
+	if err := dest.CopyPropsFromFrame(frame); err != nil {
+		dest.Close()
+		return nil, err
+	}
+	size := ff.AVUtil_get_bytes_per_sample(frame.SampleFormat())
+	if !ff.AVUtil_sample_fmt_is_planar(frame.SampleFormat()) {
+		size *= frame.ChannelLayout().NumChannels()
+	}
+	for plane := 0; plane < ff.AVUtil_frame_get_num_planes((*ff.AVFrame)(dest)); plane++ {
+		copy(dest.Bytes(plane), frame.Bytes(plane)[skip*size:keep*size])
+	}
+
+	// Set the timestamp and duration of the samples which are kept
+	dest.SetPts(pts + ff.AVUtil_rational_rescale_q(int64(skip), samples, dec.timeBase))
+	(*ff.AVFrame)(dest).SetDuration(ff.AVUtil_rational_rescale_q(int64(keep-skip), samples, dec.timeBase))
+	return dest, nil
+}
+
 // Decode a subtitle packet using the legacy subtitle API
 func (dec *streamDecoder) decodeSubtitle(pkt *ff.AVPacket, subtitlefn DecoderSubtitleFn) error {
 	// Subtitles don't support flushing (nil packet)
@@ -474,6 +792,17 @@ func (dec *streamDecoder) decodeSubtitle(pkt *ff.AVPacket, subtitlefn DecoderSub
 	// Set packet timebase
 	pkt.SetTimeBase(dec.timeBase)
 
+	// Drop subtitles which end before the range, or start after it
+	if pts := pkt.Pts(); pts != int64(ff.AV_NOPTS_VALUE) {
+		if dec.end != int64(ff.AV_NOPTS_VALUE) && pts >= dec.end {
+			dec.done = true
+			return nil
+		}
+		if dec.start != int64(ff.AV_NOPTS_VALUE) && pkt.Duration() > 0 && pts+pkt.Duration() <= dec.start {
+			return nil
+		}
+	}
+
 	// Decode subtitle using legacy API
 	sub, err := ff.AVCodec_decode_subtitle(dec.codec, pkt)
 	if err != nil {
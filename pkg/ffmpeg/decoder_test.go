@@ -2,23 +2,27 @@ package ffmpeg_test
 
 import (
 	"context"
+	"image"
 	"io"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	// Packages
 	media "github.com/mutablelogic/go-media"
 	ffmpeg "github.com/mutablelogic/go-media/pkg/ffmpeg"
-	ff "github.com/mutablelogic/go-media/sys/ffmpeg80"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
 	assert "github.com/stretchr/testify/assert"
 )
 
 const (
-	TEST_MP4 = "../../etc/test/sample.mp4"
-	TEST_MP3 = "../../etc/test/sample.mp3"
-	TEST_WAV = "../../etc/test/jfk.wav"
-	TEST_JPG = "../../etc/test/sample.jpg"
-	TEST_PNG = "../../etc/test/sample.png"
+	TEST_MP4         = "../../etc/test/sample.mp4"
+	TEST_MP3         = "../../etc/test/sample.mp3"
+	TEST_MP3_ARTWORK = "../../etc/test/sample_with_artwork.mp3"
+	TEST_WAV         = "../../etc/test/jfk.wav"
+	TEST_JPG         = "../../etc/test/sample.jpg"
+	TEST_PNG         = "../../etc/test/sample.png"
 )
 
 func Test_Decode_001(t *testing.T) {
@@ -892,3 +896,264 @@ func Test_Demux_017(t *testing.T) {
 	assert.Equal(10, frameCount)
 	t.Logf("Decoded %d frames with force flag (resampler created even for matching formats)", frameCount)
 }
+
+func Test_Demux_018(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	// Remux the sample with a display matrix which rotates the video by 90 degrees
+	output, width, height := remuxRotated(t, TEST_MP4, 90)
+
+	// The stream reports the rotation
+	rotated, err := ffmpeg.Open(output, ffmpeg.OptAutorotate())
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer rotated.Close()
+
+	streams := rotated.Streams(media.VIDEO)
+	if assert.NotEmpty(streams) {
+		if sd := streams[0].SideData(); assert.NotNil(sd) && assert.NotNil(sd.DisplayMatrix) {
+			assert.Equal(270.0, sd.DisplayMatrix.Rotate())
+		}
+	}
+
+	// Video is decoded upright, without the display matrix
+	frameCount := 0
+	err = rotated.Demux(ctx, func(stream int, par *ffmpeg.Par) (*ffmpeg.Par, error) {
+		if par.Type() != media.VIDEO {
+			return nil, nil
+		}
+		assert.Equal(height, par.Width())
+		assert.Equal(width, par.Height())
+		return par, nil
+	}, func(stream int, frame *ffmpeg.Frame) error {
+		assert.Equal(height, frame.Width())
+		assert.Equal(width, frame.Height())
+		if sd := frame.SideData(); sd != nil {
+			assert.Nil(sd.DisplayMatrix)
+		}
+		frameCount++
+		if frameCount >= 10 {
+			return io.EOF
+		}
+		return nil
+	}, nil)
+
+	assert.NoError(err)
+	assert.Equal(10, frameCount)
+	t.Logf("Decoded %d frames upright at %dx%d", frameCount, height, width)
+}
+
+func Test_Demux_019(t *testing.T) {
+	assert := assert.New(t)
+
+	// Open video file
+	reader, err := ffmpeg.Open(TEST_MP4)
+	assert.NoError(err)
+	assert.NotNil(reader)
+	defer reader.Close()
+
+	// Decode one second from the middle of the file
+	ctx := context.Background()
+	samples := make(map[int]int)
+	first := make(map[int]float64)
+	err = reader.Demux(ctx, nil, func(stream int, frame *ffmpeg.Frame) error {
+		if _, exists := first[stream]; !exists {
+			first[stream] = frame.Ts()
+		}
+		assert.GreaterOrEqual(frame.Ts(), 1.0)
+		assert.Less(frame.Ts(), 2.0)
+		if frame.Type() == media.AUDIO {
+			samples[stream] += frame.NumSamples()
+		}
+		return nil
+	}, nil, ffmpeg.OptRange(time.Second, 2*time.Second))
+	assert.NoError(err)
+	assert.NotEmpty(first)
+
+	// Audio is trimmed to the sample
+	for _, stream := range reader.Streams(media.AUDIO) {
+		par := stream.CodecPar()
+		assert.InDelta(1.0, first[stream.Index()], 1.0/float64(par.SampleRate()))
+		assert.InDelta(par.SampleRate(), samples[stream.Index()], 1)
+	}
+
+	// Invalid ranges
+	assert.Error(reader.Demux(ctx, nil, func(int, *ffmpeg.Frame) error { return nil }, nil, ffmpeg.OptRange(2*time.Second, time.Second)))
+	assert.Error(reader.Demux(ctx, nil, func(int, *ffmpeg.Frame) error { return nil }, nil, ffmpeg.OptRange(-time.Second, 0)))
+}
+
+func Test_Demux_020(t *testing.T) {
+	assert := assert.New(t)
+
+	// Open video file
+	reader, err := ffmpeg.Open(TEST_MP4)
+	assert.NoError(err)
+	assert.NotNil(reader)
+	defer reader.Close()
+
+	stream := reader.BestStream(media.VIDEO)
+	if stream < 0 {
+		t.Skip("No video stream")
+	}
+
+	// The first frame is at or just after the time
+	const secs = 1.5
+	assert.NoError(reader.SeekExact(stream, secs))
+	ctx := context.Background()
+	var ts float64
+	err = reader.Demux(ctx, func(i int, par *ffmpeg.Par) (*ffmpeg.Par, error) {
+		if i != stream {
+			return nil, nil
+		}
+		return par, nil
+	}, func(_ int, frame *ffmpeg.Frame) error {
+		ts = frame.Ts()
+		return io.EOF
+	}, nil)
+	assert.NoError(err)
+	assert.GreaterOrEqual(ts, secs)
+	assert.Less(ts, secs+0.1)
+
+	// Seeking to the keyframe returns an earlier frame
+	assert.NoError(reader.Seek(stream, secs))
+	err = reader.Demux(ctx, func(i int, par *ffmpeg.Par) (*ffmpeg.Par, error) {
+		if i != stream {
+			return nil, nil
+		}
+		return par, nil
+	}, func(_ int, frame *ffmpeg.Frame) error {
+		ts = frame.Ts()
+		return io.EOF
+	}, nil)
+	assert.NoError(err)
+	assert.LessOrEqual(ts, secs)
+}
+
+func Test_Demux_021(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	// Remux the sample upside down, which is rotated with a vertical flip
+	output, width, height := remuxRotated(t, TEST_MP4, 180)
+	rotated, err := ffmpeg.Open(output, ffmpeg.OptAutorotate())
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer rotated.Close()
+
+	// The flipped frames have a negative stride, so the image is a copy
+	frameCount := 0
+	err = rotated.Demux(ctx, func(stream int, par *ffmpeg.Par) (*ffmpeg.Par, error) {
+		if par.Type() != media.VIDEO {
+			return nil, nil
+		}
+		return par, nil
+	}, func(stream int, frame *ffmpeg.Frame) error {
+		assert.Less(frame.Stride(0), 0)
+		img, err := frame.Image()
+		if !assert.NoError(err) {
+			return err
+		}
+		assert.IsType(&image.NRGBA{}, img)
+		assert.Equal(image.Rect(0, 0, width, height), img.Bounds())
+		frameCount++
+		if frameCount >= 10 {
+			return io.EOF
+		}
+		return nil
+	}, nil)
+
+	assert.NoError(err)
+	assert.Equal(10, frameCount)
+}
+
+func Test_Demux_022(t *testing.T) {
+	assert := assert.New(t)
+
+	// Open a file with artwork, counting the bytes read
+	file, err := os.Open(TEST_MP3_ARTWORK)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	counter := &countingReader{ReadSeeker: file}
+	reader, err := ffmpeg.NewReader(counter)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer reader.Close()
+
+	// The artwork does not prevent decoding stopping at the end of the range
+	counter.n = 0
+	err = reader.Demux(context.Background(), nil, func(stream int, frame *ffmpeg.Frame) error {
+		if frame.Type() == media.AUDIO {
+			assert.Less(frame.Ts(), 1.0)
+		}
+		return nil
+	}, nil, ffmpeg.OptRange(0, time.Second))
+	assert.NoError(err)
+	assert.Less(counter.n, info.Size()/2)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// Remux a file into a temporary directory with a display matrix on the video
+// stream, and return the path and the size of the video before rotation
+func remuxRotated(t *testing.T, path string, angle float64) (string, int, int) {
+	assert := assert.New(t)
+
+	reader, err := ffmpeg.Open(path)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer reader.Close()
+
+	matrix := new(ff.AVDisplayMatrix)
+	ff.AVUtil_display_rotation_set(matrix, angle)
+
+	var width, height int
+	opts := []ffmpeg.Opt{ffmpeg.OptCopy()}
+	for _, stream := range reader.Streams(media.ANY) {
+		par := ff.AVCodec_parameters_alloc()
+		defer ff.AVCodec_parameters_free(par)
+		if !assert.NoError(ff.AVCodec_parameters_copy(par, stream.CodecPar())) {
+			t.FailNow()
+		}
+		if stream.Type() == media.VIDEO {
+			width, height = par.Width(), par.Height()
+			assert.NoError(par.AddCodedSideData(ff.AV_PKT_DATA_DISPLAYMATRIX, matrix.Bytes()))
+		}
+		opts = append(opts, ffmpeg.OptStream(stream.Index()+1, &ffmpeg.Par{AVCodecParameters: *par}))
+	}
+
+	output := filepath.Join(t.TempDir(), "rotated"+filepath.Ext(path))
+	writer, err := ffmpeg.Create(output, opts...)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	assert.NoError(reader.Decode(context.Background(), func(_ int, pkt *ffmpeg.Packet) error {
+		return writer.Write(pkt)
+	}))
+	assert.NoError(writer.Close())
+
+	return output, width, height
+}
+
+// Count the bytes read from a file
+type countingReader struct {
+	io.ReadSeeker
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadSeeker.Read(p)
+	r.n += int64(n)
+	return n, err
+}
@@ -3,14 +3,14 @@ package ffmpeg
 import (
 	"encoding/json"
 	"errors"
-	"fmt"
 	"io"
+	"math"
 	"syscall"
 
 	// Packages
 	media "github.com/mutablelogic/go-media"
 	schema "github.com/mutablelogic/go-media/pkg/ffmpeg/schema"
-	ff "github.com/mutablelogic/go-media/sys/ffmpeg80"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
 )
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -20,14 +20,20 @@ type encoder struct {
 	ctx    *ff.AVCodecContext
 	stream *ff.AVStream
 	// packet *ff.AVPacket // Removed: allocate per frame to avoid race conditions
-	eof bool // We are flushing the encoder
+	eof  bool            // We are flushing the encoder
+	fifo *ff.AVAudioFifo // Re-chunks audio for codecs with a fixed frame size
+	next int64           // Pts of the first sample read from the fifo since it was empty
+	read int64           // Number of samples read from the fifo since then
+	log  *PassLog        // Collects first pass statistics
+	id   int             // Stream identifier for the pass log
 }
 
 ////////////////////////////////////////////////////////////////////////////////
 // LIFECYCLE
 
-// Create an encoder with the given parameters
-func newEncoder(ctx *ff.AVFormatContext, stream int, par *Par) (*encoder, error) {
+// Create an encoder with the given parameters. For two-pass encoding, pass
+// is 1 or 2 and the log holds the statistics, otherwise pass is zero.
+func newEncoder(ctx *ff.AVFormatContext, stream int, par *Par, pass int, log *PassLog) (*encoder, error) {
 	encoder := new(encoder)
 
 	// Get codec
@@ -38,7 +44,12 @@ func newEncoder(ctx *ff.AVFormatContext, stream int, par *Par) (*encoder, error)
 	case ff.AVMEDIA_TYPE_VIDEO:
 		codecID = ctx.Output().VideoCodec()
 	case ff.AVMEDIA_TYPE_SUBTITLE:
+		// Subtitles keep their codec when the format can store it, as text
+		// subtitle encoders convert from any text subtitle decoder
 		codecID = ctx.Output().SubtitleCodec()
+		if par.CodecID() != ff.AV_CODEC_ID_NONE && ff.AVFormat_query_codec(ctx.Output(), par.CodecID()) {
+			codecID = par.CodecID()
+		}
 	}
 	if codecID == ff.AV_CODEC_ID_NONE {
 		return nil, media.ErrBadParameter.With("no codec specified for stream")
@@ -66,6 +77,13 @@ func newEncoder(ctx *ff.AVFormatContext, stream int, par *Par) (*encoder, error)
 		return nil, err
 	}
 
+	// Subtitles are timed in milliseconds, and text subtitle encoders need
+	// the styles of the decoded subtitles
+	if codec.Type() == ff.AVMEDIA_TYPE_SUBTITLE {
+		encoder.ctx.SetTimeBase(ff.AVUtil_rational(1, 1000))
+		encoder.ctx.SetSubtitleHeader(subtitleHeader(par))
+	}
+
 	// Create the stream
 	streamctx := ff.AVFormat_new_stream(ctx, codec)
 	if streamctx == nil {
@@ -81,6 +99,19 @@ func newEncoder(ctx *ff.AVFormatContext, stream int, par *Par) (*encoder, error)
 		encoder.ctx.SetFlags(encoder.ctx.Flags() | ff.AV_CODEC_FLAG_GLOBAL_HEADER)
 	}
 
+	// Set up the pass for two-pass encoding
+	if pass != 0 {
+		collect, err := log.configure(encoder.ctx, stream, pass)
+		if err != nil {
+			ff.AVCodec_free_context(encoder.ctx)
+			return nil, err
+		}
+		if collect {
+			encoder.log = log
+			encoder.id = stream
+		}
+	}
+
 	// Get the options
 	opts := par.optionsToDict()
 	if opts == nil {
@@ -120,6 +151,17 @@ func newEncoder(ctx *ff.AVFormatContext, stream int, par *Par) (*encoder, error)
 	}
 	encoder.stream.SetTimeBase(tb)
 
+	// Audio codecs with a fixed frame size (AAC, MP3, Opus...) are fed from a
+	// fifo, so that callers can send any number of samples per frame
+	if codec.Type() == ff.AVMEDIA_TYPE_AUDIO && encoder.ctx.FrameSize() > 0 && !codec.Capabilities().Is(ff.AV_CODEC_CAP_VARIABLE_FRAME_SIZE) {
+		ch := encoder.ctx.ChannelLayout()
+		encoder.fifo = ff.AVUtil_audio_fifo_alloc(encoder.ctx.SampleFormat(), ch.NumChannels(), encoder.ctx.FrameSize())
+		if encoder.fifo == nil {
+			ff.AVCodec_free_context(encoder.ctx)
+			return nil, errors.New("could not allocate audio fifo")
+		}
+	}
+
 	// Return success
 	return encoder, nil
 }
@@ -134,9 +176,19 @@ func (e *encoder) Close() error {
 	// and ff_free_stream() (called by avformat_free_context) will call
 	// avcodec_free_context() on it.
 
+	// The fifo and second pass statistics are ours to free
+	if e.fifo != nil {
+		ff.AVUtil_audio_fifo_free(e.fifo)
+	}
+	if e.ctx != nil {
+		e.ctx.SetStatsIn("")
+	}
+
 	// Just nil out our references
 	e.ctx = nil
 	e.stream = nil
+	e.fifo = nil
+	e.log = nil
 
 	// Return success
 	return nil
@@ -167,6 +219,10 @@ func (e *encoder) String() string {
 // Encode a frame and pass packets to the EncoderPacketFn. If the frame is nil, then
 // the encoder will flush any remaining packets. If io.EOF is returned then
 // it indicates that the encoder has ended prematurely.
+//
+// Audio frames may contain any number of samples: for codecs with a fixed
+// frame size, samples are buffered until a full frame is available, and on
+// flush the remaining samples are padded with silence to a full frame.
 func (e *encoder) Encode(frame *Frame, fn EncoderPacketFn) error {
 	if fn == nil {
 		return media.ErrBadParameter.With("nil callback function")
@@ -174,6 +230,9 @@ func (e *encoder) Encode(frame *Frame, fn EncoderPacketFn) error {
 	if e.ctx == nil {
 		return errors.New("encoder is closed")
 	}
+	if e.fifo != nil {
+		return e.encodeFifo(frame, fn)
+	}
 	return e.encode(frame, fn)
 }
 
@@ -243,8 +302,13 @@ func (e *encoder) encode(frame *Frame, fn EncoderPacketFn) error {
 			return errors.New("failed to allocate packet")
 		}
 
-		// Receive the packet
-		if err := ff.AVCodec_receive_packet(e.ctx, packet); errors.Is(err, syscall.EAGAIN) || errors.Is(err, io.EOF) {
+		// Receive the packet. First pass statistics are updated with each
+		// packet and at the end of the stream
+		err := ff.AVCodec_receive_packet(e.ctx, packet)
+		if e.log != nil && (err == nil || errors.Is(err, io.EOF)) {
+			e.log.append(e.id, e.ctx.StatsOut())
+		}
+		if errors.Is(err, syscall.EAGAIN) || errors.Is(err, io.EOF) {
 			// Finished receiving packets or EOF
 			ff.AVCodec_packet_free(packet)
 			break
@@ -272,9 +336,10 @@ func (e *encoder) encode(frame *Frame, fn EncoderPacketFn) error {
 
 		// Set packet parameters
 		packet.SetStreamIndex(e.stream.Index())
+		packet.SetTimeBase(e.stream.TimeBase())
 
 		// Pass back to the caller
-		err := fn(schema.NewPacket(packet))
+		err = fn(schema.NewPacket(packet))
 
 		// After av_interleaved_write_frame returns, the packet data has been
 		// consumed (unreferenced). We can now safely free the packet structure.
@@ -300,7 +365,128 @@ func (e *encoder) encode(frame *Frame, fn EncoderPacketFn) error {
 	return result
 }
 
+// encodeFifo buffers the samples of an audio frame and encodes every full
+// frame in the fifo. When frame is nil, the remaining samples are encoded as
+// a final padded frame and the encoder is flushed.
+func (e *encoder) encodeFifo(frame *Frame, fn EncoderPacketFn) error {
+	frameSize := e.ctx.FrameSize()
+
+	// Flush the remaining samples, then the encoder
+	if frame == nil {
+		if n := ff.AVUtil_audio_fifo_size(e.fifo); n > 0 {
+			if err := e.encodeFromFifo(frameSize, n, fn); err != nil {
+				return err
+			}
+		}
+		return e.encode(nil, fn)
+	}
+
+	// Buffer the samples. When the fifo is empty the next frame starts with
+	// this frame, so take its timestamp, which preserves any gaps in the input
+	if frame.Type() != media.AUDIO {
+		return media.ErrBadParameter.With("frame is not an audio frame")
+	}
+	if err := e.matchesAudioFormat(frame); err != nil {
+		return err
+	}
+	if ff.AVUtil_audio_fifo_size(e.fifo) == 0 && frame.Pts() != int64(ff.AV_NOPTS_VALUE) {
+		e.next, e.read = frame.Pts(), 0
+	}
+	if _, err := ff.AVUtil_audio_fifo_write(e.fifo, (*ff.AVFrame)(frame)); err != nil {
+		return err
+	}
+
+	// Encode full frames
+	for ff.AVUtil_audio_fifo_size(e.fifo) >= frameSize {
+		if err := e.encodeFromFifo(frameSize, frameSize, fn); err != nil {
+			return err
+		}
+	}
+
+	// Return success
+	return nil
+}
+
+// encodeFromFifo reads n samples from the fifo into a frame of frameSize
+// samples, padding it with silence, and encodes it
+func (e *encoder) encodeFromFifo(frameSize, n int, fn EncoderPacketFn) error {
+	frame := ff.AVUtil_frame_alloc()
+	if frame == nil {
+		return errors.New("failed to allocate frame")
+	}
+	defer ff.AVUtil_frame_free(frame)
+
+	frame.SetSampleFormat(e.ctx.SampleFormat())
+	if err := frame.SetChannelLayout(e.ctx.ChannelLayout()); err != nil {
+		return err
+	}
+	frame.SetSampleRate(e.ctx.SampleRate())
+	frame.SetNumSamples(frameSize)
+	if err := ff.AVUtil_frame_get_buffer(frame, false); err != nil {
+		return err
+	}
+
+	// Pad a short frame with silence, which is zero except for unsigned 8-bit
+	if n < frameSize {
+		silence := byte(0)
+		if sampleFmt := e.ctx.SampleFormat(); sampleFmt == ff.AV_SAMPLE_FMT_U8 || sampleFmt == ff.AV_SAMPLE_FMT_U8P {
+			silence = 0x80
+		}
+		for plane := 0; plane < ff.AVUtil_frame_get_num_planes(frame); plane++ {
+			data := frame.Bytes(plane)
+			for i := range data {
+				data[i] = silence
+			}
+		}
+	}
+	if _, err := ff.AVUtil_audio_fifo_read(e.fifo, frame, n); err != nil {
+		return err
+	}
+
+	// The pts advances by the number of samples read, in the codec timebase,
+	// counted from the last timestamp so that rounding does not accumulate
+	frame.SetPts(e.next + ff.AVUtil_rational_rescale_q(e.read, ff.AVUtil_rational(1, e.ctx.SampleRate()), e.ctx.TimeBase()))
+	e.read += int64(n)
+
+	// Encode the frame
+	return e.encode((*Frame)(frame), fn)
+}
+
+// matchesAudioFormat returns an error when the samples of a frame are not in
+// the format of the encoder, as the fifo copies planes without converting them
+func (e *encoder) matchesAudioFormat(frame *Frame) error {
+	if frame.SampleFormat() != e.ctx.SampleFormat() {
+		return media.ErrBadParameter.Withf("sample format %v does not match encoder sample format %v", frame.SampleFormat(), e.ctx.SampleFormat())
+	}
+	if frame.SampleRate() != e.ctx.SampleRate() {
+		return media.ErrBadParameter.Withf("sample rate %d does not match encoder sample rate %d", frame.SampleRate(), e.ctx.SampleRate())
+	}
+	a, b := frame.ChannelLayout(), e.ctx.ChannelLayout()
+	if !ff.AVUtil_channel_layout_compare(&a, &b) {
+		return media.ErrBadParameter.With("channel layout does not match encoder channel layout")
+	}
+	return nil
+}
+
 func (e *encoder) encodeSubtitle(sub *ff.AVSubtitle, fn EncoderPacketFn) error {
+	// The presentation time is in AV_TIME_BASE units, and encoders need the
+	// display to start at the presentation time, so the start display time
+	// in milliseconds is moved into it
+	local := *sub
+	pts := local.PTS()
+	if pts == int64(ff.AV_NOPTS_VALUE) {
+		pts = 0
+	}
+	if start := local.StartDisplayTime(); start > 0 {
+		pts += ff.AVUtil_rational_rescale_q(int64(start), ff.AVUtil_rational(1, 1000), ff.AVUtil_rational(1, ff.AV_TIME_BASE))
+		if end := local.EndDisplayTime(); end != math.MaxUint32 {
+			local.SetEndDisplayTime(end - min(start, end))
+		}
+		local.SetStartDisplayTime(0)
+	}
+	local.SetPTS(pts)
+	sub = &local
+
 	// Allocate buffer for subtitle data (subtitles are typically small, 64KB should be sufficient)
 	buf := make([]byte, 65536)
 
@@ -326,31 +512,16 @@ func (e *encoder) encodeSubtitle(sub *ff.AVSubtitle, fn EncoderPacketFn) error {
 		return err
 	}
 
-	// Set packet parameters from subtitle
+	// Set packet parameters from subtitle, in the stream timebase
+	tb := e.stream.TimeBase()
+	pts = ff.AVUtil_rational_rescale_q(pts, ff.AVUtil_rational(1, ff.AV_TIME_BASE), tb)
 	packet.SetStreamIndex(e.stream.Index())
-	packet.SetPts(sub.PTS())
-	packet.SetDts(sub.PTS())
-
-	// Calculate duration from subtitle display times (convert ms to stream timebase)
-	startMs := int64(sub.StartDisplayTime())
-	endMs := int64(sub.EndDisplayTime())
-	durationMs := endMs - startMs
-	if durationMs > 0 {
-		// Convert ms to stream timebase units: duration = (durationMs * timebase.den) / (1000 * timebase.num)
-		streamTb := e.stream.TimeBase()
-		duration := (durationMs * int64(streamTb.Den())) / (1000 * int64(streamTb.Num()))
-		if duration <= 0 {
-			duration = 1
-		}
-		packet.SetDuration(duration)
+	packet.SetPts(pts)
+	packet.SetDts(pts)
+	if end := sub.EndDisplayTime(); end > 0 && end != math.MaxUint32 {
+		packet.SetDuration(max(ff.AVUtil_rational_rescale_q(int64(end), ff.AVUtil_rational(1, 1000), tb), 1))
 	}
-
-	// Set timebase for the packet
-	packet.SetTimeBase(e.stream.TimeBase())
-
-	// DEBUG: Before callback
-	fmt.Printf("SUBTITLE ENCODE: stream=%d pts=%d duration=%d size=%d\n",
-		packet.StreamIndex(), packet.Pts(), packet.Duration(), packet.Size())
+	packet.SetTimeBase(tb)
 
 	// Pass to callback
 	err = fn(schema.NewPacket(packet))
@@ -10,7 +10,7 @@ import (
 	media "github.com/mutablelogic/go-media"
 	ffmpeg "github.com/mutablelogic/go-media/pkg/ffmpeg"
 	schema "github.com/mutablelogic/go-media/pkg/ffmpeg/schema"
-	ff "github.com/mutablelogic/go-media/sys/ffmpeg80"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
 	assert "github.com/stretchr/testify/assert"
 )
 
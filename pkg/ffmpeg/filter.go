@@ -7,14 +7,15 @@ import (
 
 	// Packages
 	media "github.com/mutablelogic/go-media"
-	ff "github.com/mutablelogic/go-media/sys/ffmpeg80"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
 )
 
 ////////////////////////////////////////////////////////////////////////////////
 // TYPES
 
 // Filter applies a filter graph to frames for a single stream.
-// Supports single input → filter chain → single output.
+// Supports single input → filter chain → single output. Use FilterGraph
+// for graphs with several labelled inputs or outputs.
 type Filter struct {
 	t          media.Type
 	audio      *audioFilter
@@ -148,21 +149,11 @@ func newAudioFilter(filterSpec string, srcPar, destPar *Par) (*audioFilter, erro
 	}
 
 	// Create buffer source arguments
-	ch := srcPar.ChannelLayout()
-	chLayout, err := ff.AVUtil_channel_layout_describe(&ch)
+	srcArgs, err := audioBufferArgs(srcPar)
 	if err != nil {
 		ff.AVFilterGraph_free(graph)
-		return nil, fmt.Errorf("failed to describe channel layout: %w", err)
+		return nil, err
 	}
-	// Get timebase - use a default if not set
-	tb := ff.AVUtil_rational(1, srcPar.SampleRate())
-	srcArgs := fmt.Sprintf("sample_rate=%d:sample_fmt=%s:channel_layout=%s:time_base=%d/%d",
-		srcPar.SampleRate(),
-		ff.AVUtil_get_sample_fmt_name(srcPar.SampleFormat()),
-		chLayout,
-		tb.Num(),
-		tb.Den(),
-	)
 
 	// Create source filter context
 	src, err := ff.AVFilterGraph_create_filter(graph, abuffer, "src", srcArgs)
@@ -323,20 +314,7 @@ func newVideoFilter(filterSpec string, srcPar, destPar *Par) (*videoFilter, erro
 	}
 
 	// Create buffer source arguments
-	// Get timebase - calculate from frame rate if available
-	tb := ff.AVUtil_rational(1, 25) // Default 25fps
-	if fr := srcPar.FrameRate(); fr > 0 {
-		tb = ff.AVUtil_rational_invert(ff.AVUtil_rational_d2q(fr, 1<<24))
-	}
-	srcArgs := fmt.Sprintf("video_size=%dx%d:pix_fmt=%d:time_base=%d/%d:pixel_aspect=%d/%d",
-		srcPar.Width(),
-		srcPar.Height(),
-		srcPar.PixelFormat(),
-		tb.Num(),
-		tb.Den(),
-		srcPar.SampleAspectRatio().Num(),
-		srcPar.SampleAspectRatio().Den(),
-	)
+	srcArgs := videoBufferArgs(srcPar)
 
 	// Create source filter context
 	src, err := ff.AVFilterGraph_create_filter(graph, buffer, "src", srcArgs)
@@ -466,3 +444,42 @@ func (f *videoFilter) process(src *Frame, fn func(*Frame) error) error {
 		ff.AVUtil_frame_free(frame)
 	}
 }
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS - BUFFER SOURCE
+
+// audioBufferArgs returns the abuffer arguments for frames described by par
+func audioBufferArgs(par *Par) (string, error) {
+	ch := par.ChannelLayout()
+	chLayout, err := ff.AVUtil_channel_layout_describe(&ch)
+	if err != nil {
+		return "", fmt.Errorf("failed to describe channel layout: %w", err)
+	}
+	// Get timebase - use a default if not set
+	tb := ff.AVUtil_rational(1, par.SampleRate())
+	return fmt.Sprintf("sample_rate=%d:sample_fmt=%s:channel_layout=%s:time_base=%d/%d",
+		par.SampleRate(),
+		ff.AVUtil_get_sample_fmt_name(par.SampleFormat()),
+		chLayout,
+		tb.Num(),
+		tb.Den(),
+	), nil
+}
+
+// videoBufferArgs returns the buffer arguments for frames described by par
+func videoBufferArgs(par *Par) string {
+	// Get timebase - calculate from frame rate if available
+	tb := ff.AVUtil_rational(1, 25) // Default 25fps
+	if fr := par.FrameRate(); fr > 0 {
+		tb = ff.AVUtil_rational_invert(ff.AVUtil_rational_d2q(fr, 1<<24))
+	}
+	return fmt.Sprintf("video_size=%dx%d:pix_fmt=%d:time_base=%d/%d:pixel_aspect=%d/%d",
+		par.Width(),
+		par.Height(),
+		par.PixelFormat(),
+		tb.Num(),
+		tb.Den(),
+		par.SampleAspectRatio().Num(),
+		par.SampleAspectRatio().Den(),
+	)
+}
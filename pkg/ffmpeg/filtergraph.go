@@ -0,0 +1,363 @@
+package ffmpeg
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"syscall"
+
+	// Packages
+	media "github.com/mutablelogic/go-media"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// FilterGraph applies a "-filter_complex" style graph with any number of
+// labelled inputs and outputs, such as "[0:v][1:v]overlay=10:10[out]" or
+// "[a][b]amix=inputs=2[mix]". Frames are pushed to the graph per input label
+// and pulled per output label. Open pads without a label are named "in0",
+// "in1", ... and "out0", "out1", ... in the order they appear in the spec.
+type FilterGraph struct {
+	graph   *ff.AVFilterGraph
+	spec    string
+	inputs  []*filterPad
+	outputs []*filterPad
+}
+
+// filterPad is a buffer source or sink attached to a labelled open pad
+type filterPad struct {
+	label string
+	ctx   *ff.AVFilterContext
+	par   *Par
+	eof   bool
+}
+
+// FilterGraphFn is called with each frame pulled from a labelled output. The
+// frame is only valid during the callback.
+type FilterGraphFn func(label string, frame *Frame) error
+
+////////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+// NewFilterGraph parses spec and attaches a buffer source to each open input
+// and a buffer sink to each open output. inputs maps every input label to the
+// parameters of the frames which will be pushed to it.
+func NewFilterGraph(spec string, inputs map[string]*Par) (*FilterGraph, error) {
+	graph := ff.AVFilterGraph_alloc()
+	if graph == nil {
+		return nil, errors.New("failed to allocate filter graph")
+	}
+	g := &FilterGraph{graph: graph, spec: spec}
+
+	// Parse the graph, returning the open pads
+	ins, outs, err := ff.AVFilterGraph_parse(graph, spec)
+	if err != nil {
+		ff.AVFilterGraph_free(graph)
+		return nil, fmt.Errorf("failed to parse filter graph: %w", err)
+	}
+	defer ff.AVFilterInOut_list_free(ins)
+	defer ff.AVFilterInOut_list_free(outs)
+	if len(ins) == 0 {
+		ff.AVFilterGraph_free(graph)
+		return nil, media.ErrBadParameter.With("filter graph has no open inputs")
+	}
+	if len(outs) == 0 {
+		ff.AVFilterGraph_free(graph)
+		return nil, media.ErrBadParameter.With("filter graph has no open outputs")
+	}
+
+	// Attach a buffer source to each input
+	for i, in := range ins {
+		label := padLabel(in, "in", i)
+		if g.input(label) != nil {
+			g.Close()
+			return nil, media.ErrBadParameter.Withf("input %q is used more than once", label)
+		}
+		par, exists := inputs[label]
+		if !exists || par == nil {
+			g.Close()
+			return nil, media.ErrBadParameter.Withf("no parameters for input %q", label)
+		}
+		src, err := newBufferSrc(graph, fmt.Sprint("in", i), par, in.Filter().InputType(uint(in.Pad())))
+		if err != nil {
+			g.Close()
+			return nil, fmt.Errorf("input %q: %w", label, err)
+		}
+		if err := ff.AVFilterContext_link(src, 0, in.Filter(), uint(in.Pad())); err != nil {
+			g.Close()
+			return nil, fmt.Errorf("failed to link input %q: %w", label, err)
+		}
+		g.inputs = append(g.inputs, &filterPad{label: label, ctx: src, par: par})
+	}
+	for label := range inputs {
+		if g.input(label) == nil {
+			g.Close()
+			return nil, media.ErrBadParameter.Withf("input %q is not in the filter graph", label)
+		}
+	}
+
+	// Attach a buffer sink to each output
+	for i, out := range outs {
+		label := padLabel(out, "out", i)
+		if g.output(label) != nil {
+			g.Close()
+			return nil, media.ErrBadParameter.Withf("output %q is used more than once", label)
+		}
+		sink, err := newBufferSink(graph, fmt.Sprint("out", i), out.Filter().OutputType(uint(out.Pad())))
+		if err != nil {
+			g.Close()
+			return nil, fmt.Errorf("output %q: %w", label, err)
+		}
+		if err := ff.AVFilterContext_link(out.Filter(), uint(out.Pad()), sink, 0); err != nil {
+			g.Close()
+			return nil, fmt.Errorf("failed to link output %q: %w", label, err)
+		}
+		g.outputs = append(g.outputs, &filterPad{label: label, ctx: sink})
+	}
+
+	// Configure the graph, then read back the negotiated output parameters
+	if err := ff.AVFilterGraph_config(graph); err != nil {
+		g.Close()
+		return nil, fmt.Errorf("failed to configure filter graph: %w", err)
+	}
+	for _, out := range g.outputs {
+		par, err := newSinkPar(out.ctx)
+		if err != nil {
+			g.Close()
+			return nil, fmt.Errorf("output %q: %w", out.label, err)
+		}
+		out.par = par
+	}
+
+	// Return success
+	return g, nil
+}
+
+// Release resources
+func (g *FilterGraph) Close() error {
+	if g.graph != nil {
+		ff.AVFilterGraph_free(g.graph)
+	}
+	g.graph = nil
+	g.inputs = nil
+	g.outputs = nil
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (g *FilterGraph) String() string {
+	return g.spec
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Inputs returns the input labels, in the order they appear in the spec
+func (g *FilterGraph) Inputs() []string {
+	result := make([]string, 0, len(g.inputs))
+	for _, in := range g.inputs {
+		result = append(result, in.label)
+	}
+	return result
+}
+
+// Outputs returns the output labels, in the order they appear in the spec
+func (g *FilterGraph) Outputs() []string {
+	result := make([]string, 0, len(g.outputs))
+	for _, out := range g.outputs {
+		result = append(result, out.label)
+	}
+	return result
+}
+
+// OutputPar returns the negotiated parameters of the frames produced on an
+// output, which can be used to create an encoder, or nil if there is no such
+// output
+func (g *FilterGraph) OutputPar(label string) *Par {
+	if out := g.output(label); out != nil {
+		return out.par
+	}
+	return nil
+}
+
+// Push sends a frame to the input with the given label. Pass frame==nil to
+// signal the end of that input.
+func (g *FilterGraph) Push(label string, frame *Frame) error {
+	in := g.input(label)
+	if in == nil {
+		return media.ErrBadParameter.Withf("no input %q", label)
+	}
+	if frame == nil {
+		if in.eof {
+			return nil
+		}
+		in.eof = true
+		if err := ff.AVBufferSrc_add_frame_flags(in.ctx, nil, 0); err != nil {
+			return fmt.Errorf("AVBufferSrc_add_frame (flush %q): %w", label, err)
+		}
+		return nil
+	}
+	if in.eof {
+		return media.ErrBadParameter.Withf("input %q has already been flushed", label)
+	}
+	if frame.Type() != in.par.Type() {
+		return media.ErrBadParameter.Withf("frame type mismatch for input %q", label)
+	}
+	if err := ff.AVBufferSrc_add_frame_flags(in.ctx, (*ff.AVFrame)(frame), ff.AV_BUFFERSRC_FLAG_KEEP_REF); err != nil {
+		return fmt.Errorf("AVBufferSrc_add_frame (%q): %w", label, err)
+	}
+	return nil
+}
+
+// Pull calls fn with each frame which is available on the output with the
+// given label. It returns nil when more input is needed, or io.EOF once every
+// input has been flushed and the output is drained.
+//
+// IMPORTANT: The frame pointer passed to fn is only valid during the callback
+// execution. Clone the frame within the callback if you need to retain it.
+func (g *FilterGraph) Pull(label string, fn FilterGraphFn) error {
+	if fn == nil {
+		return media.ErrBadParameter.With("nil callback")
+	}
+	out := g.output(label)
+	if out == nil {
+		return media.ErrBadParameter.Withf("no output %q", label)
+	}
+	if out.eof {
+		return io.EOF
+	}
+
+	for {
+		frame := ff.AVUtil_frame_alloc()
+		if frame == nil {
+			return errors.New("failed to allocate frame")
+		}
+
+		err := ff.AVBufferSink_get_frame(out.ctx, frame)
+		if err != nil {
+			ff.AVUtil_frame_free(frame)
+			avErr, ok := err.(ff.AVError)
+			switch {
+			case ok && avErr == ff.AVERROR_EOF:
+				out.eof = true
+				return io.EOF
+			case ok && avErr.IsErrno(syscall.EAGAIN):
+				return nil
+			default:
+				return fmt.Errorf("AVBufferSink_get_frame (%q): %w", label, err)
+			}
+		}
+
+		// The frame is freed immediately after the callback returns
+		err = fn(label, (*Frame)(frame))
+		ff.AVUtil_frame_free(frame)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Process pushes a frame to an input and then pulls the available frames from
+// every output, calling fn with each one. Pass frame==nil to flush the input;
+// once every input is flushed, all outputs are drained. Outputs which have
+// reached the end of stream are skipped, so Process returns nil rather than
+// io.EOF.
+func (g *FilterGraph) Process(label string, frame *Frame, fn FilterGraphFn) error {
+	if err := g.Push(label, frame); err != nil {
+		return err
+	}
+	for _, out := range g.outputs {
+		if err := g.Pull(out.label, fn); err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+func (g *FilterGraph) input(label string) *filterPad {
+	for _, in := range g.inputs {
+		if in.label == label {
+			return in
+		}
+	}
+	return nil
+}
+
+func (g *FilterGraph) output(label string) *filterPad {
+	for _, out := range g.outputs {
+		if out.label == label {
+			return out
+		}
+	}
+	return nil
+}
+
+// padLabel returns the label of an open pad, or prefix and index if the pad
+// is unlabelled
+func padLabel(pad *ff.AVFilterInOut, prefix string, index int) string {
+	if name := pad.Name(); name != "" {
+		return name
+	}
+	return fmt.Sprint(prefix, index)
+}
+
+// newBufferSrc creates a buffer source for frames described by par, which
+// must match the media type of the pad it will feed
+func newBufferSrc(graph *ff.AVFilterGraph, name string, par *Par, typ ff.AVMediaType) (*ff.AVFilterContext, error) {
+	if par.CodecType() != typ {
+		return nil, fmt.Errorf("parameters are %v but the filter expects %v", par.CodecType(), typ)
+	}
+	switch typ {
+	case ff.AVMEDIA_TYPE_AUDIO:
+		args, err := audioBufferArgs(par)
+		if err != nil {
+			return nil, err
+		}
+		return ff.AVFilterGraph_create_filter(graph, ff.AVFilter_get_by_name("abuffer"), name, args)
+	case ff.AVMEDIA_TYPE_VIDEO:
+		return ff.AVFilterGraph_create_filter(graph, ff.AVFilter_get_by_name("buffer"), name, videoBufferArgs(par))
+	default:
+		return nil, fmt.Errorf("unsupported type: %v", typ)
+	}
+}
+
+// newBufferSink creates a buffer sink for a pad of the given media type
+func newBufferSink(graph *ff.AVFilterGraph, name string, typ ff.AVMediaType) (*ff.AVFilterContext, error) {
+	switch typ {
+	case ff.AVMEDIA_TYPE_AUDIO:
+		return ff.AVFilterGraph_create_filter(graph, ff.AVFilter_get_by_name("abuffersink"), name, "")
+	case ff.AVMEDIA_TYPE_VIDEO:
+		return ff.AVFilterGraph_create_filter(graph, ff.AVFilter_get_by_name("buffersink"), name, "")
+	default:
+		return nil, fmt.Errorf("unsupported type: %v", typ)
+	}
+}
+
+// newSinkPar returns the parameters negotiated on a configured buffer sink
+func newSinkPar(sink *ff.AVFilterContext) (*Par, error) {
+	par := new(Par)
+	par.timebase = ff.AVBufferSink_get_time_base(sink)
+	switch sink.Filter().Name() {
+	case "abuffersink":
+		par.SetCodecType(ff.AVMEDIA_TYPE_AUDIO)
+		par.SetSampleFormat(ff.AVSampleFormat(ff.AVBufferSink_get_format(sink)))
+		par.SetSampleRate(ff.AVBufferSink_get_sample_rate(sink))
+		if err := par.SetChannelLayout(ff.AVBufferSink_get_ch_layout(sink)); err != nil {
+			return nil, err
+		}
+	default:
+		par.SetCodecType(ff.AVMEDIA_TYPE_VIDEO)
+		par.SetPixelFormat(ff.AVBufferSink_get_format(sink))
+		par.SetWidth(ff.AVBufferSink_get_w(sink))
+		par.SetHeight(ff.AVBufferSink_get_h(sink))
+		par.SetSampleAspectRatio(ff.AVBufferSink_get_sample_aspect_ratio(sink))
+	}
+	return par, nil
+}
@@ -0,0 +1,164 @@
+package ffmpeg_test
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	// Packages
+	pkg "github.com/mutablelogic/go-media/pkg/ffmpeg"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
+	assert "github.com/stretchr/testify/assert"
+)
+
+func Test_filtergraph_001(t *testing.T) {
+	assert := assert.New(t)
+
+	// Picture-in-picture: overlay a small frame onto a larger one
+	main := pkg.VideoPar("yuv420p", "320x240", 25)
+	pip := pkg.VideoPar("yuv420p", "80x60", 25)
+	graph, err := pkg.NewFilterGraph("[main][pip]overlay=10:10[out]", map[string]*pkg.Par{
+		"main": main,
+		"pip":  pip,
+	})
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer graph.Close()
+
+	assert.Equal([]string{"main", "pip"}, graph.Inputs())
+	assert.Equal([]string{"out"}, graph.Outputs())
+	if par := graph.OutputPar("out"); assert.NotNil(par) {
+		assert.Equal(320, par.Width())
+		assert.Equal(240, par.Height())
+	}
+
+	// Push a frame to each input, then flush
+	for label, par := range map[string]*pkg.Par{"main": main, "pip": pip} {
+		frame := newVideoFrame(t, par, 0)
+		assert.NoError(graph.Push(label, frame))
+		frame.Close()
+		assert.NoError(graph.Push(label, nil))
+	}
+
+	var frames int
+	err = graph.Pull("out", func(label string, frame *pkg.Frame) error {
+		assert.Equal("out", label)
+		assert.Equal(320, frame.Width())
+		frames++
+		return nil
+	})
+	assert.True(errors.Is(err, io.EOF))
+	assert.Equal(1, frames)
+}
+
+func Test_filtergraph_002(t *testing.T) {
+	assert := assert.New(t)
+
+	// Multiple renditions from one input
+	par := pkg.VideoPar("yuv420p", "320x240", 25)
+	graph, err := pkg.NewFilterGraph("[in]split[a][b];[b]scale=160:120[small]", map[string]*pkg.Par{
+		"in": par,
+	})
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer graph.Close()
+
+	assert.Equal([]string{"a", "small"}, graph.Outputs())
+	assert.Equal(160, graph.OutputPar("small").Width())
+
+	widths := map[string]int{}
+	for pts := int64(0); pts < 3; pts++ {
+		frame := newVideoFrame(t, par, pts)
+		assert.NoError(graph.Process("in", frame, func(label string, frame *pkg.Frame) error {
+			widths[label] = frame.Width()
+			return nil
+		}))
+		frame.Close()
+	}
+	assert.NoError(graph.Process("in", nil, func(label string, frame *pkg.Frame) error {
+		widths[label] = frame.Width()
+		return nil
+	}))
+	assert.Equal(map[string]int{"a": 320, "small": 160}, widths)
+}
+
+func Test_filtergraph_003(t *testing.T) {
+	assert := assert.New(t)
+
+	// Audio mixing, with unlabelled inputs named by position
+	par := pkg.AudioPar("fltp", "stereo", 44100)
+	graph, err := pkg.NewFilterGraph("amix=inputs=2", map[string]*pkg.Par{
+		"in0": par,
+		"in1": par,
+	})
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer graph.Close()
+
+	assert.Equal([]string{"in0", "in1"}, graph.Inputs())
+	assert.Equal([]string{"out0"}, graph.Outputs())
+
+	var samples int
+	fn := func(label string, frame *pkg.Frame) error {
+		samples += frame.NumSamples()
+		return nil
+	}
+	for _, label := range graph.Inputs() {
+		frame, err := pkg.NewFrame(par)
+		if !assert.NoError(err) {
+			t.FailNow()
+		}
+		(*ff.AVFrame)(frame).SetNumSamples(1024)
+		assert.NoError(frame.AllocateBuffers())
+		frame.SetPts(0)
+		assert.NoError(graph.Process(label, frame, fn))
+		frame.Close()
+	}
+	for _, label := range graph.Inputs() {
+		assert.NoError(graph.Process(label, nil, fn))
+	}
+	assert.Equal(1024, samples)
+}
+
+func Test_filtergraph_004(t *testing.T) {
+	assert := assert.New(t)
+	video := pkg.VideoPar("yuv420p", "320x240", 25)
+	audio := pkg.AudioPar("fltp", "stereo", 44100)
+
+	// Missing parameters for an input
+	_, err := pkg.NewFilterGraph("[a][b]hstack[out]", map[string]*pkg.Par{"a": video})
+	assert.Error(err)
+
+	// Parameters for an input which isn't in the graph
+	_, err = pkg.NewFilterGraph("[a]null[out]", map[string]*pkg.Par{"a": video, "z": video})
+	assert.Error(err)
+
+	// Audio parameters for a video input
+	_, err = pkg.NewFilterGraph("[a]null[out]", map[string]*pkg.Par{"a": audio})
+	assert.Error(err)
+
+	// Unknown labels
+	graph, err := pkg.NewFilterGraph("[a]null[out]", map[string]*pkg.Par{"a": video})
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer graph.Close()
+	assert.Error(graph.Push("b", nil))
+	assert.Error(graph.Pull("b", func(string, *pkg.Frame) error { return nil }))
+	assert.Nil(graph.OutputPar("b"))
+}
+
+func newVideoFrame(t *testing.T, par *pkg.Par, pts int64) *pkg.Frame {
+	frame, err := pkg.NewFrame(par)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := frame.AllocateBuffers(); err != nil {
+		t.Fatal(err)
+	}
+	frame.SetPts(pts)
+	return frame
+}
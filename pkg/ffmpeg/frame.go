@@ -7,7 +7,8 @@ import (
 
 	// Packages
 	media "github.com/mutablelogic/go-media"
-	ff "github.com/mutablelogic/go-media/sys/ffmpeg80"
+	schema "github.com/mutablelogic/go-media/pkg/ffmpeg/schema"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
 )
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -299,6 +300,15 @@ func (frame *Frame) FrameRate() ff.AVRational {
 	return ff.AVUtil_rational_invert((*ff.AVFrame)(frame).TimeBase())
 }
 
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS - SIDE DATA
+
+// Return a copy of the typed side data attached to the frame, such as the
+// display matrix or HDR metadata, or nil if there is none
+func (frame *Frame) SideData() *schema.SideData {
+	return schema.NewFrameSideData((*ff.AVFrame)(frame).SideData())
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 // PUBLIC METHODS - TIME PARAMETERS
 
@@ -6,7 +6,7 @@ import (
 
 	// Packages
 	media "github.com/mutablelogic/go-media"
-	ff "github.com/mutablelogic/go-media/sys/ffmpeg80"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
 	assert "github.com/stretchr/testify/assert"
 )
 
@@ -692,3 +692,54 @@ func Test_frame_copy_props(t *testing.T) {
 	assert.Equal(frame1.Pts(), frame2.Pts())
 	t.Logf("Copied PTS: %d", frame2.Pts())
 }
+
+////////////////////////////////////////////////////////////////////////////////
+// TEST FRAME SIDE DATA
+
+func Test_frame_side_data(t *testing.T) {
+	assert := assert.New(t)
+
+	frame, err := NewFrame(nil)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer frame.Close()
+
+	// No side data
+	assert.Nil(frame.SideData())
+
+	// Rotate by 90 degrees counterclockwise, which is 270 degrees clockwise
+	matrix := new(ff.AVDisplayMatrix)
+	ff.AVUtil_display_rotation_set(matrix, 90)
+	_, err = ff.AVUtil_frame_new_side_data((*ff.AVFrame)(frame), ff.AV_FRAME_DATA_DISPLAYMATRIX, matrix.Bytes())
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+
+	// Add HDR10 content light level
+	light := ff.AVUtil_content_light_metadata_create_side_data((*ff.AVFrame)(frame))
+	if !assert.NotNil(light) {
+		t.FailNow()
+	}
+	light.SetMaxContentLightLevel(1000)
+	light.SetMaxFrameAverageLightLevel(400)
+
+	sd := frame.SideData()
+	if !assert.NotNil(sd) {
+		t.FailNow()
+	}
+	if assert.NotNil(sd.DisplayMatrix) {
+		assert.InDelta(90.0, sd.DisplayMatrix.Rotation, 0.001)
+		assert.Equal(270.0, sd.DisplayMatrix.Rotate())
+		assert.False(sd.DisplayMatrix.Flipped)
+	}
+	if assert.NotNil(sd.ContentLight) {
+		assert.Equal(1000, sd.ContentLight.MaxCLL)
+		assert.Equal(400, sd.ContentLight.MaxFALL)
+	}
+	assert.Equal("HDR10", sd.HDR())
+
+	data, err := json.Marshal(sd)
+	assert.NoError(err)
+	t.Log(string(data))
+}
@@ -0,0 +1,214 @@
+package ffmpeg
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	// Packages
+	media "github.com/mutablelogic/go-media"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// HTTPReader reads a remote resource with HTTP range requests, so that it
+// can be opened with NewReaderAt and only the parts which the demuxer needs
+// are fetched. Reads are made in blocks, and the last block is cached, since
+// the demuxer makes many small reads.
+type HTTPReader struct {
+	mu     sync.Mutex
+	ctx    context.Context
+	client *http.Client
+	url    string
+	size   int64
+	etag   string
+	block  int    // Minimum size of each request
+	buf    []byte // The last block read
+	bufoff int64  // The offset of the last block read
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// CONSTANTS
+
+const (
+	httpBlockSize = 256 * 1024
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+// Create a reader for a remote resource, which must support range requests.
+// The client can be nil to use http.DefaultClient, and requests are made
+// with the context, so cancelling it aborts any read in progress.
+func NewHTTPReader(ctx context.Context, client *http.Client, url string) (*HTTPReader, error) {
+	if ctx == nil {
+		return nil, media.ErrBadParameter.With("nil context")
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	r := &HTTPReader{
+		ctx:    ctx,
+		client: client,
+		url:    url,
+		block:  httpBlockSize,
+	}
+
+	// Read the first block, which also returns the size of the resource
+	resp, err := r.get(0, int64(r.block)-1)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusPartialContent {
+		return nil, media.ErrNotImplemented.Withf("%s: range requests are not supported", url)
+	}
+	if _, size, err := parseContentRange(resp.Header.Get("Content-Range")); err != nil {
+		return nil, err
+	} else {
+		r.size = size
+	}
+	if buf, err := io.ReadAll(resp.Body); err != nil {
+		return nil, err
+	} else {
+		r.buf = buf
+	}
+
+	// Use the entity tag to detect changes to the resource between requests
+	if etag := resp.Header.Get("ETag"); !strings.HasPrefix(etag, "W/") {
+		r.etag = etag
+	}
+
+	// Return success
+	return r, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Return the size of the resource in bytes
+func (r *HTTPReader) Size() int64 {
+	return r.size
+}
+
+// Read len(p) bytes from the resource starting at offset off. Returns io.EOF
+// when fewer bytes are read because the end of the resource is reached.
+func (r *HTTPReader) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, media.ErrBadParameter.With("negative offset")
+	}
+	if off >= r.size {
+		return 0, io.EOF
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var n int
+	for n < len(p) && off < r.size {
+		// Fetch the block at the offset if it is not cached
+		if off < r.bufoff || off >= r.bufoff+int64(len(r.buf)) {
+			if err := r.fetch(off, int64(max(len(p)-n, r.block))); err != nil {
+				return n, err
+			}
+		}
+
+		// Copy from the cached block
+		m := copy(p[n:], r.buf[off-r.bufoff:])
+		n += m
+		off += int64(m)
+	}
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// Read a block of up to size bytes at the offset into the cache
+func (r *HTTPReader) fetch(off, size int64) error {
+	end := min(off+size, r.size) - 1
+	resp, err := r.get(off, end)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// With If-Range, the server returns the whole resource when it has changed
+	if resp.StatusCode != http.StatusPartialContent {
+		return media.ErrInternalError.Withf("%s: unexpected status %q for range request", r.url, resp.Status)
+	}
+	if start, _, err := parseContentRange(resp.Header.Get("Content-Range")); err != nil {
+		return err
+	} else if start != off {
+		return media.ErrInternalError.Withf("%s: unexpected range start %d", r.url, start)
+	}
+
+	// Read the block
+	buf := make([]byte, end-off+1)
+	if n, err := io.ReadFull(resp.Body, buf); err != nil {
+		return err
+	} else {
+		r.buf, r.bufoff = buf[:n], off
+	}
+
+	// Return success
+	return nil
+}
+
+// Make a range request for the bytes from start to end inclusive
+func (r *HTTPReader) get(start, end int64) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(r.ctx, http.MethodGet, r.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", start, end))
+	if r.etag != "" {
+		req.Header.Set("If-Range", r.etag)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, media.ErrNotFound.With(r.url)
+		}
+		return nil, media.ErrBadParameter.Withf("%s: %s", r.url, resp.Status)
+	}
+	return resp, nil
+}
+
+// Parse a Content-Range header of the form "bytes start-end/size" and return
+// the start and the complete size
+func parseContentRange(value string) (int64, int64, error) {
+	spec, ok := strings.CutPrefix(value, "bytes ")
+	if !ok {
+		return 0, 0, media.ErrInternalError.Withf("invalid content range %q", value)
+	}
+	rng, size, ok := strings.Cut(spec, "/")
+	if !ok || size == "*" {
+		return 0, 0, media.ErrInternalError.Withf("invalid content range %q", value)
+	}
+	first, _, ok := strings.Cut(rng, "-")
+	if !ok {
+		return 0, 0, media.ErrInternalError.Withf("invalid content range %q", value)
+	}
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil {
+		return 0, 0, media.ErrInternalError.Withf("invalid content range %q", value)
+	}
+	total, err := strconv.ParseInt(size, 10, 64)
+	if err != nil || total < 0 {
+		return 0, 0, media.ErrInternalError.Withf("invalid content range %q", value)
+	}
+	return start, total, nil
+}
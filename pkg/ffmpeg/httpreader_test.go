@@ -0,0 +1,171 @@
+package ffmpeg
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	// Packages
+	media "github.com/mutablelogic/go-media"
+	assert "github.com/stretchr/testify/assert"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TEST HTTP READER
+
+func Test_httpreader_read_at(t *testing.T) {
+	assert := assert.New(t)
+
+	data := make([]byte, 1000)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests.Add(1)
+		http.ServeContent(w, req, "data.bin", time.Time{}, bytes.NewReader(data))
+	}))
+	defer server.Close()
+
+	r, err := NewHTTPReader(context.Background(), server.Client(), server.URL)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	assert.Equal(int64(len(data)), r.Size())
+
+	// Reads from the first block are cached
+	r.block = 100
+	buf := make([]byte, 10)
+	n, err := r.ReadAt(buf, 500)
+	assert.NoError(err)
+	assert.Equal(10, n)
+	assert.Equal(data[500:510], buf)
+	assert.Equal(int32(1), requests.Load())
+
+	// Reads across blocks
+	r.buf, r.bufoff = nil, 0
+	buf = make([]byte, 250)
+	n, err = r.ReadAt(buf, 50)
+	assert.NoError(err)
+	assert.Equal(250, n)
+	assert.Equal(data[50:300], buf)
+
+	// Reads at the end
+	n, err = r.ReadAt(buf, 900)
+	assert.ErrorIs(err, io.EOF)
+	assert.Equal(100, n)
+	assert.Equal(data[900:], buf[:n])
+	n, err = r.ReadAt(buf, 1000)
+	assert.ErrorIs(err, io.EOF)
+	assert.Zero(n)
+
+	// Negative offsets
+	_, err = r.ReadAt(buf, -1)
+	assert.ErrorIs(err, media.ErrBadParameter)
+}
+
+func Test_httpreader_no_range(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte("no ranges here"))
+	}))
+	defer server.Close()
+
+	_, err := NewHTTPReader(context.Background(), server.Client(), server.URL)
+	assert.ErrorIs(err, media.ErrNotImplemented)
+}
+
+func Test_httpreader_not_found(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	_, err := NewHTTPReader(context.Background(), server.Client(), server.URL)
+	assert.ErrorIs(err, media.ErrNotFound)
+}
+
+func Test_httpreader_changed(t *testing.T) {
+	assert := assert.New(t)
+
+	var etag atomic.Value
+	etag.Store(`"v1"`)
+	data := make([]byte, 1000)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("ETag", etag.Load().(string))
+		http.ServeContent(w, req, "data.bin", time.Time{}, bytes.NewReader(data))
+	}))
+	defer server.Close()
+
+	r, err := NewHTTPReader(context.Background(), server.Client(), server.URL)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	r.block = 100
+	r.buf = nil
+
+	// The resource is unchanged
+	buf := make([]byte, 10)
+	_, err = r.ReadAt(buf, 200)
+	assert.NoError(err)
+
+	// The resource has changed, so the whole resource is returned
+	etag.Store(`"v2"`)
+	_, err = r.ReadAt(buf, 500)
+	assert.ErrorIs(err, media.ErrInternalError)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// TEST READER AT
+
+func Test_reader_at_http(t *testing.T) {
+	assert := assert.New(t)
+
+	testFile := filepath.Join(testDir, "sample.mp4")
+	if _, err := os.Stat(testFile); os.IsNotExist(err) {
+		t.Skip("Test file not available:", testFile)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		http.ServeFile(w, req, testFile)
+	}))
+	defer server.Close()
+
+	hr, err := NewHTTPReader(context.Background(), server.Client(), server.URL)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	r, err := NewReaderAt(hr, hr.Size())
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer r.Close()
+
+	assert.NotEmpty(r.Streams(media.ANY))
+	assert.NotZero(r.Duration())
+
+	// Read all the packets
+	var count int
+	assert.NoError(r.Decode(context.Background(), func(int, *Packet) error {
+		count++
+		return nil
+	}))
+	assert.Greater(count, 0)
+}
+
+func Test_reader_at_invalid(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := NewReaderAt(nil, 0)
+	assert.ErrorIs(err, media.ErrBadParameter)
+	_, err = NewReaderAt(bytes.NewReader(nil), -1)
+	assert.ErrorIs(err, media.ErrBadParameter)
+}
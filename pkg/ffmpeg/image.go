@@ -0,0 +1,320 @@
+package ffmpeg
+
+import (
+	"image"
+	"image/draw"
+
+	// Packages
+	media "github.com/mutablelogic/go-media"
+	imagex "github.com/mutablelogic/go-media/pkg/image"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+var (
+	// Planar YUV pixel formats which map directly onto image.YCbCr
+	yuvSubsampleRatio = map[ff.AVPixelFormat]image.YCbCrSubsampleRatio{
+		ff.AV_PIX_FMT_YUV410P:  image.YCbCrSubsampleRatio410,
+		ff.AV_PIX_FMT_YUV411P:  image.YCbCrSubsampleRatio411,
+		ff.AV_PIX_FMT_YUV420P:  image.YCbCrSubsampleRatio420,
+		ff.AV_PIX_FMT_YUV422P:  image.YCbCrSubsampleRatio422,
+		ff.AV_PIX_FMT_YUV440P:  image.YCbCrSubsampleRatio440,
+		ff.AV_PIX_FMT_YUV444P:  image.YCbCrSubsampleRatio444,
+		ff.AV_PIX_FMT_YUVJ411P: image.YCbCrSubsampleRatio411,
+		ff.AV_PIX_FMT_YUVJ420P: image.YCbCrSubsampleRatio420,
+		ff.AV_PIX_FMT_YUVJ422P: image.YCbCrSubsampleRatio422,
+		ff.AV_PIX_FMT_YUVJ440P: image.YCbCrSubsampleRatio440,
+		ff.AV_PIX_FMT_YUVJ444P: image.YCbCrSubsampleRatio444,
+	}
+	// Full range pixel formats for image.YCbCr. There is no full range
+	// yuv410p, so those frames have the colour range set instead
+	pixfmtYCbCr = map[image.YCbCrSubsampleRatio]ff.AVPixelFormat{
+		image.YCbCrSubsampleRatio410: ff.AV_PIX_FMT_YUV410P,
+		image.YCbCrSubsampleRatio411: ff.AV_PIX_FMT_YUVJ411P,
+		image.YCbCrSubsampleRatio420: ff.AV_PIX_FMT_YUVJ420P,
+		image.YCbCrSubsampleRatio422: ff.AV_PIX_FMT_YUVJ422P,
+		image.YCbCrSubsampleRatio440: ff.AV_PIX_FMT_YUVJ440P,
+		image.YCbCrSubsampleRatio444: ff.AV_PIX_FMT_YUVJ444P,
+	}
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+// Create a video frame from an image, which should be released by the caller.
+// If par is nil, the frame has the pixel format closest to the image and the
+// same size. Otherwise the image is converted to the pixel format and size in
+// par, and the frame has the timebase of par.
+func NewFrameFromImage(img image.Image, par *Par) (*Frame, error) {
+	if img == nil {
+		return nil, media.ErrBadParameter.With("nil image")
+	}
+	if par != nil && par.CodecType() != ff.AVMEDIA_TYPE_VIDEO {
+		return nil, media.ErrBadParameter.With("invalid codec type")
+	}
+
+	// Copy the image into a frame with the native pixel format
+	src, err := frameFromImage(img)
+	if err != nil {
+		return nil, err
+	}
+	if par == nil {
+		return src, nil
+	}
+
+	// Set the timebase, and return the frame if no conversion is needed
+	if tb := par.TimeBase(); tb.Num() > 0 && tb.Den() > 0 {
+		(*ff.AVFrame)(src).SetTimeBase(tb)
+	}
+	dest := *par
+	if dest.Width() == 0 || dest.Height() == 0 {
+		dest.SetWidth(src.Width())
+		dest.SetHeight(src.Height())
+	}
+	if dest.PixelFormat() == src.PixelFormat() && dest.Width() == src.Width() && dest.Height() == src.Height() {
+		return src, nil
+	}
+	defer src.Close()
+
+	// Convert the pixel format and size
+	resampler, err := NewResampler(&dest, false)
+	if err != nil {
+		return nil, err
+	}
+	defer resampler.Close()
+
+	var result *Frame
+	if err := resampler.Resample(src, func(frame *Frame) error {
+		if frame == nil || result != nil {
+			return nil
+		}
+		var err error
+		result, err = frame.Copy()
+		return err
+	}); err != nil {
+		if result != nil {
+			result.Close()
+		}
+		return nil, err
+	}
+	if result == nil {
+		return nil, media.ErrInternalError.With("no frame from resampler")
+	}
+	return result, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Return the video frame as an image. For yuv420p, yuv422p, yuv444p and
+// related planar formats, gray, rgba and rgb24 the image shares memory with
+// the frame, so it is only valid until the frame is unreferenced or closed,
+// and the caller should copy it if it is to be retained. Frames in other
+// pixel formats, or with rows stored bottom-up as after a vertical flip, are
+// converted to an image.NRGBA which does not share memory.
+func (frame *Frame) Image() (image.Image, error) {
+	if frame.Type() != media.VIDEO {
+		return nil, media.ErrBadParameter.Withf("unsupported frame type: %v", frame.Type())
+	}
+	if !frame.IsAllocated() {
+		return nil, media.ErrBadParameter.With("frame is not allocated")
+	}
+
+	// Image types cannot have a negative stride
+	for plane := 0; plane < ff.AVUtil_frame_get_num_planes((*ff.AVFrame)(frame)); plane++ {
+		if frame.Stride(plane) < 0 {
+			return frame.convertImage()
+		}
+	}
+
+	rect := image.Rect(0, 0, frame.Width(), frame.Height())
+	switch frame.PixelFormat() {
+	case ff.AV_PIX_FMT_RGBA:
+		// FFmpeg RGBA is not premultiplied by alpha
+		return &image.NRGBA{
+			Pix:    frame.Bytes(0),
+			Stride: frame.Stride(0),
+			Rect:   rect,
+		}, nil
+	case ff.AV_PIX_FMT_GRAY8:
+		return &image.Gray{
+			Pix:    frame.Bytes(0),
+			Stride: frame.Stride(0),
+			Rect:   rect,
+		}, nil
+	case ff.AV_PIX_FMT_RGB24:
+		return &imagex.RGB24{
+			Pix:    frame.Bytes(0),
+			Stride: frame.Stride(0),
+			Rect:   rect,
+		}, nil
+	}
+
+	// Planar YUV formats, where the chroma planes share a stride
+	if ratio, exists := yuvSubsampleRatio[frame.PixelFormat()]; exists && frame.Stride(1) == frame.Stride(2) {
+		return &image.YCbCr{
+			Y:              frame.Bytes(0),
+			Cb:             frame.Bytes(1),
+			Cr:             frame.Bytes(2),
+			YStride:        frame.Stride(0),
+			CStride:        frame.Stride(1),
+			SubsampleRatio: ratio,
+			Rect:           rect,
+		}, nil
+	}
+
+	// Convert other formats to RGBA and copy the result
+	return frame.convertImage()
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// Rescale the frame to RGBA and return a copy as an image
+func (frame *Frame) convertImage() (image.Image, error) {
+	par := new(Par)
+	par.SetCodecType(ff.AVMEDIA_TYPE_VIDEO)
+	par.SetPixelFormat(ff.AV_PIX_FMT_RGBA)
+	par.SetWidth(frame.Width())
+	par.SetHeight(frame.Height())
+	par.SetSampleAspectRatio(frame.SampleAspectRatio())
+	par.ensureSampleAspectRatio()
+
+	resampler, err := NewResampler(par, false)
+	if err != nil {
+		return nil, err
+	}
+	defer resampler.Close()
+
+	var result *image.NRGBA
+	if err := resampler.Resample(frame, func(dest *Frame) error {
+		if dest == nil || result != nil {
+			return nil
+		}
+		result = image.NewNRGBA(image.Rect(0, 0, dest.Width(), dest.Height()))
+		copyPlane(result.Pix, result.Stride, dest.Bytes(0), dest.Stride(0), 4*dest.Width(), dest.Height())
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, media.ErrInternalError.With("no frame from resampler")
+	}
+	return result, nil
+}
+
+// Copy an image into a new frame with the closest pixel format
+func frameFromImage(img image.Image) (*Frame, error) {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w <= 0 || h <= 0 {
+		return nil, media.ErrBadParameter.With("empty image")
+	}
+
+	switch src := img.(type) {
+	case *image.Gray:
+		frame, err := newImageFrame(ff.AV_PIX_FMT_GRAY8, w, h)
+		if err != nil {
+			return nil, err
+		}
+		copyPlane(frame.Bytes(0), frame.Stride(0), src.Pix, src.Stride, w, h)
+		return frame, nil
+	case *imagex.RGB24:
+		frame, err := newImageFrame(ff.AV_PIX_FMT_RGB24, w, h)
+		if err != nil {
+			return nil, err
+		}
+		copyPlane(frame.Bytes(0), frame.Stride(0), src.Pix, src.Stride, 3*w, h)
+		return frame, nil
+	case *image.NRGBA:
+		return frameFromNRGBA(src)
+	case *image.RGBA:
+		// Premultiplied pixels can be copied as they are when opaque
+		if !src.Opaque() {
+			break
+		}
+		frame, err := newImageFrame(ff.AV_PIX_FMT_RGBA, w, h)
+		if err != nil {
+			return nil, err
+		}
+		copyPlane(frame.Bytes(0), frame.Stride(0), src.Pix, src.Stride, 4*w, h)
+		return frame, nil
+	case *image.YCbCr:
+		pixfmt, exists := pixfmtYCbCr[src.SubsampleRatio]
+		if !exists {
+			break
+		}
+		frame, err := newImageFrame(pixfmt, w, h)
+		if err != nil {
+			return nil, err
+		}
+		(*ff.AVFrame)(frame).SetColorRange(ff.AVCOL_RANGE_JPEG)
+		cw, ch := chromaSize(src.SubsampleRatio, w, h)
+		copyPlane(frame.Bytes(0), frame.Stride(0), src.Y, src.YStride, w, h)
+		copyPlane(frame.Bytes(1), frame.Stride(1), src.Cb, src.CStride, cw, ch)
+		copyPlane(frame.Bytes(2), frame.Stride(2), src.Cr, src.CStride, cw, ch)
+		return frame, nil
+	}
+
+	// Draw any other image as non-premultiplied RGBA
+	dst := image.NewNRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(dst, dst.Rect, img, bounds.Min, draw.Src)
+	return frameFromNRGBA(dst)
+}
+
+// Copy a non-premultiplied RGBA image into a new frame
+func frameFromNRGBA(src *image.NRGBA) (*Frame, error) {
+	w, h := src.Rect.Dx(), src.Rect.Dy()
+	frame, err := newImageFrame(ff.AV_PIX_FMT_RGBA, w, h)
+	if err != nil {
+		return nil, err
+	}
+	copyPlane(frame.Bytes(0), frame.Stride(0), src.Pix, src.Stride, 4*w, h)
+	return frame, nil
+}
+
+// Allocate a video frame with buffers for an image
+func newImageFrame(pixfmt ff.AVPixelFormat, w, h int) (*Frame, error) {
+	par := new(Par)
+	par.SetCodecType(ff.AVMEDIA_TYPE_VIDEO)
+	par.SetPixelFormat(pixfmt)
+	par.SetWidth(w)
+	par.SetHeight(h)
+	par.SetSampleAspectRatio(ff.AVUtil_rational(1, 1))
+	frame, err := NewFrame(par)
+	if err != nil {
+		return nil, err
+	}
+	if err := frame.AllocateBuffers(); err != nil {
+		frame.Close()
+		return nil, err
+	}
+	return frame, nil
+}
+
+// Return the width and height of the chroma planes of a YCbCr image
+func chromaSize(ratio image.YCbCrSubsampleRatio, w, h int) (int, int) {
+	switch ratio {
+	case image.YCbCrSubsampleRatio422:
+		return (w + 1) / 2, h
+	case image.YCbCrSubsampleRatio420:
+		return (w + 1) / 2, (h + 1) / 2
+	case image.YCbCrSubsampleRatio440:
+		return w, (h + 1) / 2
+	case image.YCbCrSubsampleRatio411:
+		return (w + 3) / 4, h
+	case image.YCbCrSubsampleRatio410:
+		return (w + 3) / 4, (h + 1) / 2
+	default:
+		return w, h
+	}
+}
+
+// Copy rows of pixels between planes with different strides
+func copyPlane(dst []byte, dstStride int, src []byte, srcStride int, width, rows int) {
+	for y := 0; y < rows; y++ {
+		copy(dst[y*dstStride:y*dstStride+width], src[y*srcStride:y*srcStride+width])
+	}
+}
@@ -0,0 +1,189 @@
+package ffmpeg
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	// Packages
+	imagex "github.com/mutablelogic/go-media/pkg/image"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
+	assert "github.com/stretchr/testify/assert"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TEST IMAGE TO FRAME AND BACK
+
+func Test_image_ycbcr(t *testing.T) {
+	assert := assert.New(t)
+
+	src := image.NewYCbCr(image.Rect(0, 0, 64, 48), image.YCbCrSubsampleRatio420)
+	for i := range src.Y {
+		src.Y[i] = uint8(i)
+	}
+	for i := range src.Cb {
+		src.Cb[i] = 64
+		src.Cr[i] = 192
+	}
+
+	frame, err := NewFrameFromImage(src, nil)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer frame.Close()
+	assert.Equal(ff.AV_PIX_FMT_YUVJ420P, frame.PixelFormat())
+	assert.Equal(ff.AVCOL_RANGE_JPEG, (*ff.AVFrame)(frame).ColorRange())
+	assert.Equal(64, frame.Width())
+	assert.Equal(48, frame.Height())
+
+	// The image shares memory with the frame
+	img, err := frame.Image()
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	dst, ok := img.(*image.YCbCr)
+	if assert.True(ok) {
+		assert.Equal(src.Bounds(), dst.Bounds())
+		assert.Equal(image.YCbCrSubsampleRatio420, dst.SubsampleRatio)
+		assert.Same(&frame.Bytes(0)[0], &dst.Y[0])
+		for y := 0; y < 48; y++ {
+			for x := 0; x < 64; x++ {
+				assert.Equal(src.YCbCrAt(x, y), dst.YCbCrAt(x, y))
+			}
+		}
+	}
+}
+
+func Test_image_gray(t *testing.T) {
+	assert := assert.New(t)
+
+	src := image.NewGray(image.Rect(0, 0, 33, 17))
+	for i := range src.Pix {
+		src.Pix[i] = uint8(i * 7)
+	}
+
+	frame, err := NewFrameFromImage(src, nil)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer frame.Close()
+	assert.Equal(ff.AV_PIX_FMT_GRAY8, frame.PixelFormat())
+
+	img, err := frame.Image()
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	if dst, ok := img.(*image.Gray); assert.True(ok) {
+		for y := 0; y < 17; y++ {
+			for x := 0; x < 33; x++ {
+				assert.Equal(src.GrayAt(x, y), dst.GrayAt(x, y))
+			}
+		}
+	}
+}
+
+func Test_image_rgb24(t *testing.T) {
+	assert := assert.New(t)
+
+	src := imagex.NewRGB24(image.Rect(0, 0, 20, 10))
+	src.Set(5, 5, color.RGBA{R: 255, G: 128, B: 1, A: 255})
+
+	frame, err := NewFrameFromImage(src, nil)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer frame.Close()
+	assert.Equal(ff.AV_PIX_FMT_RGB24, frame.PixelFormat())
+
+	img, err := frame.Image()
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	if dst, ok := img.(*imagex.RGB24); assert.True(ok) {
+		assert.Equal(imagex.RGB{R: 255, G: 128, B: 1}, dst.RGBAt(5, 5))
+		assert.Equal(imagex.RGB{}, dst.RGBAt(0, 0))
+	}
+}
+
+func Test_image_nrgba(t *testing.T) {
+	assert := assert.New(t)
+
+	// Paletted images are drawn as RGBA
+	src := image.NewPaletted(image.Rect(0, 0, 16, 16), color.Palette{color.Black, color.White})
+	src.SetColorIndex(3, 4, 1)
+
+	frame, err := NewFrameFromImage(src, nil)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer frame.Close()
+	assert.Equal(ff.AV_PIX_FMT_RGBA, frame.PixelFormat())
+
+	img, err := frame.Image()
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	if dst, ok := img.(*image.NRGBA); assert.True(ok) {
+		assert.Equal(color.NRGBA{R: 255, G: 255, B: 255, A: 255}, dst.NRGBAAt(3, 4))
+		assert.Equal(color.NRGBA{A: 255}, dst.NRGBAAt(0, 0))
+	}
+}
+
+func Test_image_convert(t *testing.T) {
+	assert := assert.New(t)
+
+	src := image.NewNRGBA(image.Rect(0, 0, 64, 48))
+	for i := range src.Pix {
+		src.Pix[i] = 0xFF
+	}
+
+	// Convert to a pixel format without a matching image type, at a different size
+	par, err := NewVideoPar("bgr24", "32x24", 25)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	frame, err := NewFrameFromImage(src, par)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer frame.Close()
+	assert.Equal(ff.AV_PIX_FMT_BGR24, frame.PixelFormat())
+	assert.Equal(32, frame.Width())
+	assert.Equal(24, frame.Height())
+	assert.Equal(ff.AVUtil_rational(1, 25), frame.TimeBase())
+
+	// The frame is converted back to RGBA
+	img, err := frame.Image()
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	if dst, ok := img.(*image.NRGBA); assert.True(ok) {
+		assert.Equal(image.Rect(0, 0, 32, 24), dst.Bounds())
+		assert.Equal(color.NRGBA{R: 255, G: 255, B: 255, A: 255}, dst.NRGBAAt(16, 12))
+	}
+}
+
+func Test_image_invalid(t *testing.T) {
+	assert := assert.New(t)
+
+	// Nil image
+	_, err := NewFrameFromImage(nil, nil)
+	assert.Error(err)
+
+	// Audio parameters
+	audioPar, err := NewAudioPar("fltp", "stereo", 44100)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	_, err = NewFrameFromImage(image.NewGray(image.Rect(0, 0, 8, 8)), audioPar)
+	assert.Error(err)
+
+	// Audio frame
+	frame, err := NewFrame(audioPar)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer frame.Close()
+	_, err = frame.Image()
+	assert.Error(err)
+}
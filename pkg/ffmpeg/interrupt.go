@@ -0,0 +1,90 @@
+package ffmpeg
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// interrupt aborts blocking I/O on a format context when the context of the
+// reader or writer is cancelled, the context of the current operation is
+// cancelled, or the deadline of the current operation has passed
+type interrupt struct {
+	mu       sync.Mutex
+	parent   context.Context // Lifetime of the reader or writer
+	ctx      context.Context // Current operation, or nil
+	deadline time.Time       // Deadline for the current operation, or zero
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+func newInterrupt(parent context.Context) *interrupt {
+	if parent == nil {
+		parent = context.Background()
+	}
+	return &interrupt{parent: parent}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// Begin a blocking operation, which is aborted when ctx is cancelled or after
+// the timeout. The ctx can be nil and the timeout zero.
+func (i *interrupt) begin(ctx context.Context, timeout time.Duration) {
+	if i == nil {
+		return
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.ctx = ctx
+	if timeout > 0 {
+		i.deadline = time.Now().Add(timeout)
+	} else {
+		i.deadline = time.Time{}
+	}
+}
+
+// End a blocking operation. When a context was cancelled or the deadline
+// passed during the operation, the context error or context.DeadlineExceeded
+// is returned in place of any result from ffmpeg.
+func (i *interrupt) end(err error) error {
+	if i == nil {
+		return err
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if cause := i.cause(); cause != nil {
+		err = cause
+	}
+	i.ctx = nil
+	i.deadline = time.Time{}
+	return err
+}
+
+// Return true if the current operation should be aborted. This is called
+// from ffmpeg during blocking operations.
+func (i *interrupt) interrupted() bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.cause() != nil
+}
+
+// Return the reason for interrupting the current operation, or nil
+func (i *interrupt) cause() error {
+	if err := i.parent.Err(); err != nil {
+		return err
+	}
+	if i.ctx != nil {
+		if err := i.ctx.Err(); err != nil {
+			return err
+		}
+	}
+	if !i.deadline.IsZero() && time.Now().After(i.deadline) {
+		return context.DeadlineExceeded
+	}
+	return nil
+}
@@ -1,11 +1,16 @@
 package ffmpeg
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 	"strings"
+	"sync"
+	"time"
+	"unsafe"
 
 	// Packages
-	ff "github.com/mutablelogic/go-media/sys/ffmpeg80"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
 )
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -14,11 +19,44 @@ import (
 // Logging function
 type LogFn func(text string)
 
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+var (
+	logMu       sync.RWMutex
+	logHandler  slog.Handler
+	logContexts = make(map[unsafe.Pointer]context.Context) // Operation of each format and codec context
+)
+
+// The FFmpeg log level when logging is not set, or the handler is removed
+const logDefault = ff.AV_LOG_ERROR
+
+// FFmpeg log levels, from the least to the most verbose
+var logLevels = []ff.AVLog{
+	ff.AV_LOG_PANIC, ff.AV_LOG_FATAL, ff.AV_LOG_ERROR, ff.AV_LOG_WARNING,
+	ff.AV_LOG_INFO, ff.AV_LOG_VERBOSE, ff.AV_LOG_DEBUG, ff.AV_LOG_TRACE,
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+func init() {
+	// Only errors are logged to stderr until logging is set
+	ff.AVUtil_log_set_level(logDefault)
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 // PUBLIC METHODS
 
 // Set logging options, including a callback function
+//
+// Deprecated: Use SetLogHandler, which keeps the level and source of each
+// message.
 func SetLogging(verbose bool, fn LogFn) {
+	logMu.Lock()
+	logHandler = nil
+	logMu.Unlock()
+
 	ff.AVUtil_log_set_level(ff.AV_LOG_INFO)
 	if !verbose {
 		ff.AVUtil_log_set_level(ff.AV_LOG_ERROR)
@@ -31,3 +69,120 @@ func SetLogging(verbose bool, fn LogFn) {
 		ff.AVUtil_log_set_callback(nil)
 	}
 }
+
+// SetLogHandler sends FFmpeg log messages to a structured log handler. The
+// FFmpeg levels are mapped to slog levels, so warnings such as corrupt frames
+// are logged at slog.LevelWarn, and verbose, debug and trace messages are
+// logged below slog.LevelInfo. The name and category of the codec, format or
+// filter which logged the message are in the "ffmpeg" group of attributes.
+//
+// Messages from readers and writers created with OptContext, and from
+// decoding with Reader.Demux, are handled with the context of the operation,
+// so handlers can associate them with its trace span.
+//
+// Only messages at levels which the handler enables are formatted, so set
+// the handler again if its level changes. Pass nil to restore the default
+// FFmpeg logging of errors to stderr.
+func SetLogHandler(handler slog.Handler) {
+	logMu.Lock()
+	logHandler = handler
+	logMu.Unlock()
+
+	if handler == nil {
+		ff.AVUtil_log_set_level(logDefault)
+		ff.AVUtil_log_set_callback(nil)
+		return
+	}
+
+	// Set the most verbose level which is enabled
+	level := ff.AV_LOG_QUIET
+	for _, l := range logLevels {
+		if handler.Enabled(context.Background(), logLevel(l)) {
+			level = l
+		}
+	}
+	ff.AVUtil_log_set_level(level)
+	ff.AVUtil_log_set_callback(logMessage)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// Return the slog level for an FFmpeg log level
+func logLevel(level ff.AVLog) slog.Level {
+	switch {
+	case level <= ff.AV_LOG_ERROR:
+		return slog.LevelError
+	case level <= ff.AV_LOG_WARNING:
+		return slog.LevelWarn
+	case level <= ff.AV_LOG_INFO:
+		return slog.LevelInfo
+	case level <= ff.AV_LOG_VERBOSE:
+		return slog.LevelDebug
+	case level <= ff.AV_LOG_DEBUG:
+		return slog.LevelDebug - 4
+	default:
+		return slog.LevelDebug - 8
+	}
+}
+
+// Set the context for messages logged by an FFmpeg object, such as a format
+// or codec context, and return a function which restores the previous
+// context. Nothing is set when the context is nil.
+func setLogContext(obj unsafe.Pointer, ctx context.Context) func() {
+	if obj == nil || ctx == nil {
+		return func() {}
+	}
+	logMu.Lock()
+	defer logMu.Unlock()
+	prev, exists := logContexts[obj]
+	logContexts[obj] = ctx
+	return func() {
+		logMu.Lock()
+		defer logMu.Unlock()
+		if exists {
+			logContexts[obj] = prev
+		} else {
+			delete(logContexts, obj)
+		}
+	}
+}
+
+// Handle a message from FFmpeg, which may be called from codec threads
+func logMessage(level ff.AVLog, message string, userInfo any) {
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return
+	}
+
+	// Get the handler, and the context of the object which logged the message
+	logMu.RLock()
+	handler := logHandler
+	ctx := context.Background()
+	if obj, ok := userInfo.(unsafe.Pointer); ok {
+		if c, exists := logContexts[obj]; exists {
+			ctx = c
+		}
+	}
+	logMu.RUnlock()
+
+	// Colours are set in the bits above the level
+	slevel := logLevel(level & 0xFF)
+	if handler == nil || !handler.Enabled(ctx, slevel) {
+		return
+	}
+
+	// Add the name and category of the object, and handle the record
+	record := slog.NewRecord(time.Now(), slevel, message, 0)
+	var attrs []any
+	if name := ff.AVUtil_log_item_name(userInfo); name != "" {
+		attrs = append(attrs, slog.String("name", name))
+	}
+	if category := ff.AVUtil_log_category(userInfo).String(); category != "" {
+		attrs = append(attrs, slog.String("category", category))
+	}
+	if len(attrs) > 0 {
+		record.AddAttrs(slog.Group("ffmpeg", attrs...))
+	}
+	handler.Handle(ctx, record)
+}
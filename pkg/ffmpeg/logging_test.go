@@ -1,11 +1,17 @@
 package ffmpeg_test
 
 import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
 	"testing"
+	"unsafe"
 
 	// Packages
 	ffmpeg "github.com/mutablelogic/go-media/pkg/ffmpeg"
-	ff "github.com/mutablelogic/go-media/sys/ffmpeg80"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
+	assert "github.com/stretchr/testify/assert"
 )
 
 func Test_logging_001(t *testing.T) {
@@ -29,3 +35,60 @@ func Test_logging_002(t *testing.T) {
 	ff.AVUtil_log(nil, ff.AV_LOG_WARNING, "WARN test")
 	ff.AVUtil_log(nil, ff.AV_LOG_ERROR, "ERROR test")
 }
+
+func Test_logging_003(t *testing.T) {
+	assert := assert.New(t)
+
+	// Log warnings and errors as JSON
+	var buf bytes.Buffer
+	ffmpeg.SetLogHandler(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	defer ffmpeg.SetLogHandler(nil)
+
+	// Log from a decoder, which is named by its codec
+	codec := ff.AVCodec_find_decoder(ff.AV_CODEC_ID_H264)
+	if !assert.NotNil(codec) {
+		t.FailNow()
+	}
+	ctx := ff.AVCodec_alloc_context(codec)
+	if !assert.NotNil(ctx) {
+		t.FailNow()
+	}
+	defer ff.AVCodec_free_context(ctx)
+	ff.AVUtil_log((*ff.AVClass)(unsafe.Pointer(ctx)), ff.AV_LOG_INFO, "INFO test\n")
+	ff.AVUtil_log((*ff.AVClass)(unsafe.Pointer(ctx)), ff.AV_LOG_WARNING, "WARN test\n")
+	ff.AVUtil_log(nil, ff.AV_LOG_ERROR, "ERROR test\n")
+
+	// Info is below the level of the handler
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if !assert.Len(lines, 2) {
+		t.FailNow()
+	}
+	var record struct {
+		Level  string `json:"level"`
+		Msg    string `json:"msg"`
+		FFmpeg struct {
+			Name     string `json:"name"`
+			Category string `json:"category"`
+		} `json:"ffmpeg"`
+	}
+	if assert.NoError(json.Unmarshal([]byte(lines[0]), &record)) {
+		assert.Equal("WARN", record.Level)
+		assert.Equal("WARN test", record.Msg)
+		assert.Equal("h264", record.FFmpeg.Name)
+		assert.Equal("decoder", record.FFmpeg.Category)
+	}
+	if assert.NoError(json.Unmarshal([]byte(lines[1]), &record)) {
+		assert.Equal("ERROR", record.Level)
+		assert.Equal("ERROR test", record.Msg)
+	}
+}
+
+func Test_logging_004(t *testing.T) {
+	assert := assert.New(t)
+
+	// Removing the handler only logs errors to stderr
+	ffmpeg.SetLogHandler(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	assert.Equal(ff.AV_LOG_VERBOSE, ff.AVUtil_log_get_level())
+	ffmpeg.SetLogHandler(nil)
+	assert.Equal(ff.AV_LOG_ERROR, ff.AVUtil_log_get_level())
+}
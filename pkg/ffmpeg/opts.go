@@ -1,11 +1,14 @@
 package ffmpeg
 
 import (
+	"context"
 	"errors"
+	"time"
 
 	// Package imports
 	media "github.com/mutablelogic/go-media"
-	ffmpeg "github.com/mutablelogic/go-media/sys/ffmpeg80"
+	schema "github.com/mutablelogic/go-media/pkg/ffmpeg/schema"
+	ffmpeg "github.com/mutablelogic/go-media/sys/ffmpeg"
 )
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -22,19 +25,31 @@ type opts struct {
 	level    ffmpeg.AVLog
 	callback LogFunc
 
+	// Interrupt options
+	ctx         context.Context
+	openTimeout time.Duration
+	ioTimeout   time.Duration
+
 	// Resize/resample options
-	force bool
+	force      bool
+	autorotate bool
 
 	// Reader options
-	t       media.Type
-	iformat *ffmpeg.AVInputFormat
-	opts    []string // These are key=value pairs
+	t          media.Type
+	iformat    *ffmpeg.AVInputFormat
+	opts       []string      // These are key=value pairs
+	start, end time.Duration // Range to decode with Demux, or zero
 
 	// Writer options
 	oformat  *ffmpeg.AVOutputFormat
 	streams  map[int]*Par
 	metadata []*Metadata
+	smeta    map[int][]*Metadata          // Metadata of streams, by stream identifier
+	disp     map[int]ffmpeg.AVDisposition // Dispositions of streams, by stream identifier
+	chapters []*schema.Chapter
 	copy     bool // If true, copy streams without encoding
+	pass     int  // Two-pass encoding pass, or zero
+	passlog  *PassLog
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -50,6 +65,8 @@ const (
 func newOpts() *opts {
 	return &opts{
 		streams: make(map[int]*Par),
+		smeta:   make(map[int][]*Metadata),
+		disp:    make(map[int]ffmpeg.AVDisposition),
 	}
 }
 
@@ -69,6 +86,34 @@ func OptLog(verbose bool, fn LogFunc) Opt {
 	}
 }
 
+// Abort opening, reading and writing when the context is cancelled, for the
+// lifetime of the reader or writer. Blocking network reads and writes are
+// aborted, and the operation returns the context error.
+func OptContext(ctx context.Context) Opt {
+	return func(o *opts) error {
+		if ctx == nil {
+			return errors.New("invalid context")
+		}
+		o.ctx = ctx
+		return nil
+	}
+}
+
+// Abort opening the media when it takes longer than the open timeout, and
+// reading or writing a single packet when it takes longer than the I/O
+// timeout. The operation then returns context.DeadlineExceeded. A zero
+// timeout waits indefinitely.
+func OptTimeout(open, io time.Duration) Opt {
+	return func(o *opts) error {
+		if open < 0 || io < 0 {
+			return errors.New("invalid timeout")
+		}
+		o.openTimeout = open
+		o.ioTimeout = io
+		return nil
+	}
+}
+
 // WithInputFormat sets the input format directly using an AVInputFormat pointer.
 // This is useful for device formats that may not be found via AVFormat_find_input_format.
 // Additional options are key=value pairs (e.g., "sample_rate=22050", "channels=1").
@@ -150,7 +195,47 @@ func OptMetadata(entry ...*Metadata) Opt {
 	}
 }
 
-// Enable stream copying mode (remuxing without encoding)
+// Append metadata to a stream of the output file, such as the language and
+// title, where the stream is the identifier passed to OptStream
+func OptStreamMetadata(stream int, entry ...*Metadata) Opt {
+	return func(o *opts) error {
+		if stream <= 0 {
+			return errors.New("invalid stream")
+		}
+		o.smeta[stream] = append(o.smeta[stream], entry...)
+		return nil
+	}
+}
+
+// Set the disposition of a stream of the output file, such as default or
+// forced subtitles, where the stream is the identifier passed to OptStream
+func OptDisposition(stream int, disposition ffmpeg.AVDisposition) Opt {
+	return func(o *opts) error {
+		if stream <= 0 {
+			return errors.New("invalid stream")
+		}
+		o.disp[stream] = disposition
+		return nil
+	}
+}
+
+// Append chapters to the output file. Not all output formats store chapters,
+// in which case they are ignored.
+func OptChapters(chapter ...*schema.Chapter) Opt {
+	return func(o *opts) error {
+		for _, ch := range chapter {
+			if ch == nil {
+				return errors.New("invalid chapter")
+			}
+		}
+		o.chapters = append(o.chapters, chapter...)
+		return nil
+	}
+}
+
+// Enable stream copying mode (remuxing without encoding). Subtitle streams
+// with a codec the output format cannot store, such as SubRip in MP4, are
+// encoded with the subtitle codec of the format instead.
 func OptCopy() Opt {
 	return func(o *opts) error {
 		o.copy = true
@@ -158,6 +243,24 @@ func OptCopy() Opt {
 	}
 }
 
+// Encode the first or second pass of a two-pass encode, with statistics
+// collected in or read from the log. Both passes should use the same output
+// format and stream parameters, and the output of the first pass is normally
+// discarded.
+func OptPass(pass int, log *PassLog) Opt {
+	return func(o *opts) error {
+		if pass != 1 && pass != 2 {
+			return errors.New("invalid pass")
+		}
+		if log == nil {
+			return errors.New("invalid pass log")
+		}
+		o.pass = pass
+		o.passlog = log
+		return nil
+	}
+}
+
 // Force resampling and resizing on decode, even if the input and output
 // parameters are the same
 func OptForce() Opt {
@@ -166,3 +269,34 @@ func OptForce() Opt {
 		return nil
 	}
 }
+
+// Decode from the start time to the end time with Reader.Demux. Decoding
+// starts at the keyframe before the start time, and frames before the start
+// time are dropped, with audio frames trimmed to the sample, so that the first
+// frame is at the start time exactly. Frames at or after the end time are
+// dropped in the same way. An end time of zero decodes to the end of the
+// media. Times are timestamps, as for Reader.Seek.
+func OptRange(start, end time.Duration) Opt {
+	return func(o *opts) error {
+		if start < 0 || end < 0 {
+			return errors.New("invalid range")
+		}
+		if end != 0 && end <= start {
+			return errors.New("range ends before it starts")
+		}
+		o.start = start
+		o.end = end
+		return nil
+	}
+}
+
+// Rotate and flip decoded video upright according to the display matrix of
+// each stream, as recorded by phone cameras. The display matrix is removed
+// from the decoded frames, and the parameters passed to the map function
+// have the width and height of the upright video.
+func OptAutorotate() Opt {
+	return func(o *opts) error {
+		o.autorotate = true
+		return nil
+	}
+}
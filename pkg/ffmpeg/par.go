@@ -5,10 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strconv"
+	"strings"
 
 	// Packages
 	media "github.com/mutablelogic/go-media"
-	ff "github.com/mutablelogic/go-media/sys/ffmpeg80"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
 )
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -18,11 +20,30 @@ type Par struct {
 	ff.AVCodecParameters
 	opts     []media.Metadata
 	timebase ff.AVRational
+	codec    codecOpts
+}
+
+// codecOpts are the encoder settings which are not part of the codec
+// parameters. Unset values are nil or empty and left at the encoder default.
+type codecOpts struct {
+	MaxRate int64    `json:"maxrate,omitempty"`
+	BufSize int64    `json:"bufsize,omitempty"`
+	CRF     *float64 `json:"crf,omitempty"`
+	QP      *int     `json:"qp,omitempty"`
+	Quality *float64 `json:"quality,omitempty"`
+	GOP     *int     `json:"gop,omitempty"`
+	BFrames *int     `json:"bframes,omitempty"`
+	Profile string   `json:"profile,omitempty"`
+	Level   string   `json:"level,omitempty"`
+	Preset  string   `json:"preset,omitempty"`
+	Tune    string   `json:"tune,omitempty"`
+	Threads *int     `json:"threads,omitempty"`
 }
 
 type jsonPar struct {
 	ff.AVCodecParameters
 	Timebase ff.AVRational    `json:"timebase"`
+	Codec    *codecOpts       `json:"codec,omitempty"`
 	Opts     []media.Metadata `json:"options"`
 }
 
@@ -125,11 +146,15 @@ func (par *Par) MarshalJSON() ([]byte, error) {
 	if par == nil {
 		return []byte("null"), nil
 	}
-	return json.Marshal(jsonPar{
+	j := jsonPar{
 		AVCodecParameters: par.AVCodecParameters,
 		Timebase:          par.timebase,
 		Opts:              par.opts,
-	})
+	}
+	if par.codec != (codecOpts{}) {
+		j.Codec = &par.codec
+	}
+	return json.Marshal(j)
 }
 
 func (par *Par) String() string {
@@ -178,6 +203,77 @@ func (par *Par) FrameRate() float64 {
 	return ff.AVUtil_rational_q2d(ff.AVUtil_rational_invert(par.timebase))
 }
 
+// Return the timebase, which for an encoder is the timebase of the frames it
+// expects, or zero if not set
+func (par *Par) TimeBase() ff.AVRational {
+	if par == nil {
+		return ff.AVRational{}
+	}
+	return par.timebase
+}
+
+// Set the maximum bitrate and the rate control buffer size in bits, for
+// constrained VBR. The average bitrate is set with SetBitRate.
+func (par *Par) SetMaxRate(maxrate, bufsize int64) {
+	par.codec.MaxRate = maxrate
+	par.codec.BufSize = bufsize
+}
+
+// Set the constant rate factor, for encoders which support it (libx264,
+// libx265, libvpx, libaom, libsvtav1). Lower values are higher quality.
+func (par *Par) SetCRF(crf float64) {
+	par.codec.CRF = &crf
+}
+
+// Set a constant quantizer, for encoders which support it. Zero is lossless
+// for libx264 and libx265.
+func (par *Par) SetQP(qp int) {
+	par.codec.QP = &qp
+}
+
+// Set a fixed quality scale for VBR encoding, which is the equivalent of
+// ffmpeg's -q option (for example 0-9 for libmp3lame, lower is better).
+func (par *Par) SetQuality(quality float64) {
+	par.codec.Quality = &quality
+}
+
+// Set the distance between keyframes, in frames
+func (par *Par) SetGOP(size int) {
+	par.codec.GOP = &size
+}
+
+// Set the maximum number of consecutive B-frames. Zero disables B-frames.
+func (par *Par) SetBFrames(count int) {
+	par.codec.BFrames = &count
+}
+
+// Set the encoder profile, either by the name the codec gives it (for example
+// "LC" for aac or "High" for libx264) or as a numeric profile identifier
+func (par *Par) SetProfile(profile string) {
+	par.codec.Profile = profile
+}
+
+// Set the encoder level, for example "4.1". Encoders without a level option
+// take the integer level_idc, such as "41".
+func (par *Par) SetLevel(level string) {
+	par.codec.Level = level
+}
+
+// Set the encoder speed preset, for example "veryfast" for libx264
+func (par *Par) SetPreset(preset string) {
+	par.codec.Preset = preset
+}
+
+// Set the encoder tuning, for example "film" or "zerolatency" for libx264
+func (par *Par) SetTune(tune string) {
+	par.codec.Tune = tune
+}
+
+// Set the number of encoder threads. Zero lets the encoder decide.
+func (par *Par) SetThreads(count int) {
+	par.codec.Threads = &count
+}
+
 func (par *Par) ValidateFromCodec(codec *ff.AVCodec) error {
 	if par == nil {
 		return errors.New("par is nil")
@@ -187,11 +283,15 @@ func (par *Par) ValidateFromCodec(codec *ff.AVCodec) error {
 	}
 	switch codec.Type() {
 	case ff.AVMEDIA_TYPE_AUDIO:
-		return par.validateAudioCodec(codec)
+		if err := par.validateAudioCodec(codec); err != nil {
+			return err
+		}
 	case ff.AVMEDIA_TYPE_VIDEO:
-		return par.validateVideoCodec(codec)
+		if err := par.validateVideoCodec(codec); err != nil {
+			return err
+		}
 	}
-	return nil
+	return par.validateCodecOpts(codec)
 }
 
 func (par *Par) CopyToCodecContext(codec *ff.AVCodecContext) error {
@@ -203,11 +303,15 @@ func (par *Par) CopyToCodecContext(codec *ff.AVCodecContext) error {
 	}
 	switch codec.Codec().Type() {
 	case ff.AVMEDIA_TYPE_AUDIO:
-		return par.copyAudioCodec(codec)
+		if err := par.copyAudioCodec(codec); err != nil {
+			return err
+		}
 	case ff.AVMEDIA_TYPE_VIDEO:
-		return par.copyVideoCodec(codec)
+		if err := par.copyVideoCodec(codec); err != nil {
+			return err
+		}
 	}
-	return nil
+	return par.copyCodecOpts(codec)
 }
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -379,3 +483,179 @@ func (par *Par) validateFrameRate(supported []ff.AVRational) error {
 	}
 	return nil
 }
+
+// Check encoder settings are supported by the codec. Options which map to
+// codec-private AVOptions need the encoder to declare them.
+func (par *Par) validateCodecOpts(codec *ff.AVCodec) error {
+	opts := par.codec
+
+	// Only one way to choose quality
+	n := 0
+	for _, set := range []bool{opts.CRF != nil, opts.QP != nil, opts.Quality != nil} {
+		if set {
+			n++
+		}
+	}
+	if n > 1 {
+		return errors.New("only one of crf, qp or quality can be set")
+	}
+
+	// Bitrates
+	if par.BitRate() < 0 || opts.MaxRate < 0 || opts.BufSize < 0 {
+		return errors.New("bitrate cannot be negative")
+	}
+	if opts.MaxRate > 0 && par.BitRate() > opts.MaxRate {
+		return fmt.Errorf("bitrate %v exceeds maxrate %v", par.BitRate(), opts.MaxRate)
+	}
+
+	// Video-only settings
+	if codec.Type() != ff.AVMEDIA_TYPE_VIDEO {
+		if opts.GOP != nil {
+			return errors.New("gop size is only supported for video")
+		}
+		if opts.BFrames != nil {
+			return errors.New("b-frames are only supported for video")
+		}
+	}
+	if opts.GOP != nil && *opts.GOP < 0 {
+		return fmt.Errorf("invalid gop size %v", *opts.GOP)
+	}
+	if opts.BFrames != nil && *opts.BFrames < 0 {
+		return fmt.Errorf("invalid b-frames %v", *opts.BFrames)
+	}
+
+	// Threads
+	if opts.Threads != nil {
+		if *opts.Threads < 0 {
+			return fmt.Errorf("invalid thread count %v", *opts.Threads)
+		}
+		caps := codec.Capabilities()
+		if *opts.Threads > 1 && !caps.Is(ff.AV_CODEC_CAP_FRAME_THREADS) && !caps.Is(ff.AV_CODEC_CAP_SLICE_THREADS) && !caps.Is(ff.AV_CODEC_CAP_OTHER_THREADS) {
+			return fmt.Errorf("codec %q does not support threads", codec.Name())
+		}
+	}
+
+	// Codec-private options
+	for name, set := range map[string]bool{
+		"crf":    opts.CRF != nil,
+		"qp":     opts.QP != nil,
+		"preset": opts.Preset != "",
+		"tune":   opts.Tune != "",
+	} {
+		if set && privOption(codec, name) == nil {
+			return fmt.Errorf("codec %q does not support %s", codec.Name(), name)
+		}
+	}
+
+	// Profile is a named profile of the codec, or a private option
+	if opts.Profile != "" {
+		if _, ok := codecProfile(codec, opts.Profile); !ok && privOption(codec, "profile") == nil {
+			return fmt.Errorf("unsupported profile %q for codec %q", opts.Profile, codec.Name())
+		}
+	}
+
+	// Level is a private option, or an integer level_idc
+	if opts.Level != "" && privOption(codec, "level") == nil {
+		if _, err := strconv.Atoi(opts.Level); err != nil {
+			return fmt.Errorf("invalid level %q for codec %q", opts.Level, codec.Name())
+		}
+	}
+
+	// Return success
+	return nil
+}
+
+// Copy encoder settings to the codec context, after the codec parameters
+func (par *Par) copyCodecOpts(ctx *ff.AVCodecContext) error {
+	opts := par.codec
+	codec := ctx.Codec()
+
+	// Rate control
+	if par.BitRate() > 0 {
+		ctx.SetBitRate(par.BitRate())
+	}
+	if opts.MaxRate > 0 {
+		ctx.SetRcMaxRate(opts.MaxRate)
+	}
+	if opts.BufSize > 0 {
+		ctx.SetRcBufferSize(int(opts.BufSize))
+	}
+	if opts.Quality != nil {
+		ctx.SetFlags(ctx.Flags() | ff.AV_CODEC_FLAG_QSCALE)
+		ctx.SetGlobalQuality(int(*opts.Quality * float64(ff.FF_QP2LAMBDA)))
+	}
+
+	// Picture structure
+	if opts.GOP != nil {
+		ctx.SetGopSize(*opts.GOP)
+	}
+	if opts.BFrames != nil {
+		ctx.SetMaxBFrames(*opts.BFrames)
+	}
+	if opts.Threads != nil {
+		ctx.SetThreadCount(*opts.Threads)
+	}
+
+	// Private options
+	var result error
+	set := func(name, value string) {
+		if err := ctx.SetPrivDataKV(name, value); err != nil {
+			result = errors.Join(result, fmt.Errorf("%s=%q: %w", name, value, err))
+		}
+	}
+	if opts.CRF != nil {
+		set("crf", strconv.FormatFloat(*opts.CRF, 'f', -1, 64))
+	}
+	if opts.QP != nil {
+		set("qp", strconv.Itoa(*opts.QP))
+	}
+	if opts.Preset != "" {
+		set("preset", opts.Preset)
+	}
+	if opts.Tune != "" {
+		set("tune", opts.Tune)
+	}
+	if opts.Profile != "" {
+		if profile, ok := codecProfile(codec, opts.Profile); ok {
+			ctx.SetProfile(profile)
+		} else {
+			set("profile", opts.Profile)
+		}
+	}
+	if opts.Level != "" {
+		if privOption(codec, "level") != nil {
+			set("level", opts.Level)
+		} else if level, err := strconv.Atoi(opts.Level); err == nil {
+			ctx.SetLevel(level)
+		}
+	}
+
+	// Return any errors
+	return result
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE
+
+// Return a codec-private option by name, or nil
+func privOption(codec *ff.AVCodec, name string) *ff.AVOption {
+	for _, opt := range ff.AVUtil_opt_list_from_class(codec.PrivClass()) {
+		if opt != nil && opt.Type() != ff.AV_OPT_TYPE_CONST && opt.Name() == name {
+			return opt
+		}
+	}
+	return nil
+}
+
+// Return a codec profile identifier by name or number
+func codecProfile(codec *ff.AVCodec, name string) (int, bool) {
+	if id, err := strconv.Atoi(name); err == nil && id >= 0 {
+		return id, true
+	}
+	for _, profile := range codec.Profiles() {
+		if strings.EqualFold(profile.Name(), name) {
+			return profile.ID(), true
+		}
+	}
+	return 0, false
+}
@@ -6,6 +6,7 @@ import (
 
 	// Packages
 	media "github.com/mutablelogic/go-media"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
 	assert "github.com/stretchr/testify/assert"
 )
 
@@ -366,3 +367,126 @@ func Test_par_video_fractional_framerate(t *testing.T) {
 	assert.InDelta(29.97, par.FrameRate(), 0.01)
 	t.Log("Framerate:", par.FrameRate())
 }
+
+////////////////////////////////////////////////////////////////////////////////
+// TEST CODEC OPTIONS
+
+func Test_par_video_codec_opts(t *testing.T) {
+	assert := assert.New(t)
+
+	codec := ff.AVCodec_find_encoder_by_name("mpeg4")
+	if codec == nil {
+		t.Skip("mpeg4 encoder not available")
+	}
+
+	par := VideoPar("yuv420p", "320x240", 25)
+	par.SetBitRate(1000000)
+	par.SetMaxRate(2000000, 4000000)
+	par.SetGOP(12)
+	par.SetBFrames(0)
+	par.SetQuality(4)
+	par.SetThreads(2)
+	if !assert.NoError(par.ValidateFromCodec(codec)) {
+		t.FailNow()
+	}
+
+	ctx := ff.AVCodec_alloc_context(codec)
+	if !assert.NotNil(ctx) {
+		t.FailNow()
+	}
+	defer ff.AVCodec_free_context(ctx)
+	assert.NoError(par.CopyToCodecContext(ctx))
+
+	assert.Equal(int64(1000000), ctx.BitRate())
+	assert.Equal(int64(2000000), ctx.RcMaxRate())
+	assert.Equal(4000000, ctx.RcBufferSize())
+	assert.Equal(12, ctx.GopSize())
+	assert.Equal(0, ctx.MaxBFrames())
+	assert.Equal(2, ctx.ThreadCount())
+	assert.True(ctx.Flags()&ff.AV_CODEC_FLAG_QSCALE != 0)
+	assert.Equal(4*ff.FF_QP2LAMBDA, ctx.GlobalQuality())
+
+	// Settings are reported in JSON
+	data, err := json.Marshal(par)
+	assert.NoError(err)
+	assert.Contains(string(data), `"gop":12`)
+}
+
+func Test_par_video_codec_private_opts(t *testing.T) {
+	assert := assert.New(t)
+
+	// mpeg4 has no crf or preset
+	if codec := ff.AVCodec_find_encoder_by_name("mpeg4"); codec != nil {
+		par := VideoPar("yuv420p", "320x240", 25)
+		par.SetCRF(23)
+		assert.Error(par.ValidateFromCodec(codec))
+
+		par = VideoPar("yuv420p", "320x240", 25)
+		par.SetPreset("veryfast")
+		assert.Error(par.ValidateFromCodec(codec))
+	}
+
+	codec := ff.AVCodec_find_encoder_by_name("libx264")
+	if codec == nil {
+		t.Skip("libx264 encoder not available")
+	}
+
+	par := VideoPar("yuv420p", "320x240", 25)
+	par.SetCRF(23)
+	par.SetPreset("veryfast")
+	par.SetTune("zerolatency")
+	par.SetProfile("high")
+	par.SetLevel("4.1")
+	if !assert.NoError(par.ValidateFromCodec(codec)) {
+		t.FailNow()
+	}
+
+	ctx := ff.AVCodec_alloc_context(codec)
+	if !assert.NotNil(ctx) {
+		t.FailNow()
+	}
+	defer ff.AVCodec_free_context(ctx)
+	assert.NoError(par.CopyToCodecContext(ctx))
+
+	// Invalid values for private options are reported
+	par.SetPreset("warp")
+	assert.Error(par.CopyToCodecContext(ctx))
+}
+
+func Test_par_audio_codec_opts(t *testing.T) {
+	assert := assert.New(t)
+
+	codec := ff.AVCodec_find_encoder_by_name("aac")
+	if codec == nil {
+		t.Skip("aac encoder not available")
+	}
+
+	// Bitrate and quality apply to audio
+	par := AudioPar("fltp", "stereo", 44100)
+	par.SetBitRate(128000)
+	assert.NoError(par.ValidateFromCodec(codec))
+
+	// GOP and B-frames are video-only
+	par = AudioPar("fltp", "stereo", 44100)
+	par.SetGOP(10)
+	assert.Error(par.ValidateFromCodec(codec))
+
+	par = AudioPar("fltp", "stereo", 44100)
+	par.SetBFrames(2)
+	assert.Error(par.ValidateFromCodec(codec))
+
+	// Only one quality setting
+	par = AudioPar("fltp", "stereo", 44100)
+	par.SetQuality(2)
+	par.SetQP(10)
+	assert.Error(par.ValidateFromCodec(codec))
+
+	// Profiles by number, or by a name the codec knows
+	par = AudioPar("fltp", "stereo", 44100)
+	par.SetProfile("1")
+	assert.NoError(par.ValidateFromCodec(codec))
+
+	par = AudioPar("fltp", "stereo", 44100)
+	par.SetProfile("nonexistent")
+	assert.Error(par.ValidateFromCodec(codec))
+}
@@ -0,0 +1,161 @@
+package ffmpeg
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	// Packages
+	media "github.com/mutablelogic/go-media"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// PassLog holds encoder statistics between the passes of a two-pass encode.
+// Use the same log with OptPass(1, log) and then OptPass(2, log), and close
+// it when both passes are complete.
+//
+// Statistics which the encoder reports through stats_out (mpeg4, libvpx and
+// most native encoders) are kept in memory. Encoders which write their own
+// statistics file, such as libx264, are given a file in a temporary
+// directory which is removed on Close.
+type PassLog struct {
+	sync.Mutex
+	dir   string
+	stats map[int]*bytes.Buffer // First pass statistics by stream identifier
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+// Create an empty log for a two-pass encode
+func NewPassLog() *PassLog {
+	return &PassLog{
+		stats: make(map[int]*bytes.Buffer),
+	}
+}
+
+// Release the statistics and remove any statistics files
+func (l *PassLog) Close() error {
+	l.Lock()
+	defer l.Unlock()
+
+	var result error
+	if l.dir != "" {
+		result = os.RemoveAll(l.dir)
+	}
+	l.dir = ""
+	l.stats = make(map[int]*bytes.Buffer)
+	return result
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Return the first pass statistics for a stream, or nil if the stream was
+// not encoded in the first pass or its encoder keeps statistics in a file
+func (l *PassLog) Stats(stream int) []byte {
+	l.Lock()
+	defer l.Unlock()
+	if buf, exists := l.stats[stream]; exists && buf.Len() > 0 {
+		return bytes.Clone(buf.Bytes())
+	}
+	return nil
+}
+
+// Set the first pass statistics for a stream, for example to run the
+// second pass from statistics which were saved from an earlier process
+func (l *PassLog) SetStats(stream int, data []byte) {
+	l.Lock()
+	defer l.Unlock()
+	l.stats[stream] = bytes.NewBuffer(bytes.Clone(data))
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// configure sets up a codec context for the first or second pass, before the
+// codec is opened. It returns true if first pass statistics should be
+// collected from the encoder.
+func (l *PassLog) configure(ctx *ff.AVCodecContext, stream, pass int) (bool, error) {
+	l.Lock()
+	defer l.Unlock()
+
+	// Encoders with a statistics file read and write it themselves
+	if privOption(ctx.Codec(), "stats") != nil {
+		path, err := l.path(stream)
+		if err != nil {
+			return false, err
+		}
+		if pass == 2 {
+			if _, err := os.Stat(path); err != nil {
+				return false, media.ErrNotFound.Withf("no first pass statistics for stream %d", stream)
+			}
+		}
+		if err := ctx.SetPrivDataKV("stats", path); err != nil {
+			return false, err
+		}
+		ctx.SetFlags(ctx.Flags() | passFlag(pass))
+		return false, nil
+	}
+
+	switch pass {
+	case 1:
+		l.stats[stream] = new(bytes.Buffer)
+		ctx.SetFlags(ctx.Flags() | ff.AV_CODEC_FLAG_PASS1)
+		return true, nil
+	case 2:
+		buf, exists := l.stats[stream]
+		if !exists {
+			return false, media.ErrNotFound.Withf("no first pass statistics for stream %d", stream)
+		}
+		// Encoders without two-pass support (most audio encoders) report no
+		// statistics, and are encoded in a single pass
+		if buf.Len() > 0 {
+			ctx.SetStatsIn(buf.String())
+			ctx.SetFlags(ctx.Flags() | ff.AV_CODEC_FLAG_PASS2)
+		}
+		return false, nil
+	}
+	return false, media.ErrBadParameter.Withf("invalid pass %d", pass)
+}
+
+// append adds the latest encoder statistics for a stream
+func (l *PassLog) append(stream int, stats string) {
+	if stats == "" {
+		return
+	}
+	l.Lock()
+	defer l.Unlock()
+	if buf, exists := l.stats[stream]; exists {
+		buf.WriteString(stats)
+	}
+}
+
+// path returns the statistics file for a stream, creating the temporary
+// directory if necessary
+func (l *PassLog) path(stream int) (string, error) {
+	if l.dir == "" {
+		dir, err := os.MkdirTemp("", "gomedia-pass-")
+		if err != nil {
+			return "", errors.Join(errors.New("unable to create statistics directory"), err)
+		}
+		l.dir = dir
+	}
+	return filepath.Join(l.dir, fmt.Sprintf("stream%d.log", stream)), nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE
+
+func passFlag(pass int) ff.AVCodecFlag {
+	if pass == 1 {
+		return ff.AV_CODEC_FLAG_PASS1
+	}
+	return ff.AV_CODEC_FLAG_PASS2
+}
@@ -9,11 +9,12 @@ import (
 	"strings"
 	"sync"
 	"time"
+	"unsafe"
 
 	// Packages
 	media "github.com/mutablelogic/go-media"
 	schema "github.com/mutablelogic/go-media/pkg/ffmpeg/schema"
-	ff "github.com/mutablelogic/go-media/sys/ffmpeg80"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
 )
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -21,11 +22,17 @@ import (
 
 // Media reader which reads from a URL, file path or device
 type Reader struct {
-	mu    sync.Mutex
-	t     media.Type
-	input *ff.AVFormatContext
-	avio  *ff.AVIOContextEx
-	force bool
+	mu      sync.Mutex
+	t       media.Type
+	input   *ff.AVFormatContext
+	avio    *ff.AVIOContextEx
+	intr    *interrupt
+	timeout time.Duration // Timeout for reading each packet, or zero
+	force   bool
+	rotate  bool   // Rotate decoded video upright
+	prog    int    // Selected program, or zero for all streams
+	exact   int64  // Timestamp to decode from after SeekExact, in AV_TIME_BASE units
+	unlog   func() // Restores the context of log messages from the demuxer
 }
 
 type reader_callback struct {
@@ -56,18 +63,28 @@ func Open(url string, opt ...Opt) (*Reader, error) {
 		}
 	}
 
+	// Allocate the context, with an interrupt callback for cancellation
+	ctx := ff.AVFormat_alloc_context()
+	if ctx == nil {
+		return nil, errors.New("failed to allocate format context")
+	}
+	reader.intr = newInterrupt(options.ctx)
+	ctx.SetInterruptCallback(reader.intr.interrupted)
+
 	// Open the device or stream
-	if ctx, err := ff.AVFormat_open_url(url, options.iformat, dict); err != nil {
-		return nil, err
+	reader.intr.begin(nil, options.openTimeout)
+	if input, err := ff.AVFormat_open_input(ctx, url, options.iformat, dict); err != nil {
+		return nil, reader.intr.end(err)
 	} else {
-		reader.input = ctx
+		reader.input = input
 	}
 
 	// Find stream information and do rest of the initialization
 	return reader.open(options)
 }
 
-// Create a new reader from an io.Reader
+// Create a new reader from an io.Reader. The demuxer can only seek when the
+// reader is also an io.Seeker.
 func NewReader(r io.Reader, opt ...Opt) (*Reader, error) {
 	options := newOpts()
 	reader := new(Reader)
@@ -94,27 +111,62 @@ func NewReader(r io.Reader, opt ...Opt) (*Reader, error) {
 		return nil, errors.New("failed to allocate avio context")
 	}
 
+	// Allocate the context, with an interrupt callback for cancellation
+	ctx := ff.AVFormat_alloc_context()
+	if ctx == nil {
+		ff.AVFormat_avio_context_free(reader.avio)
+		return nil, errors.New("failed to allocate format context")
+	}
+	ctx.SetPb(reader.avio)
+	reader.intr = newInterrupt(options.ctx)
+	ctx.SetInterruptCallback(reader.intr.interrupted)
+
 	// Open the stream
-	if ctx, err := ff.AVFormat_open_reader(reader.avio, options.iformat, dict); err != nil {
+	reader.intr.begin(nil, options.openTimeout)
+	if input, err := ff.AVFormat_open_input(ctx, "", options.iformat, dict); err != nil {
 		ff.AVFormat_avio_context_free(reader.avio)
-		return nil, err
+		return nil, reader.intr.end(err)
 	} else {
-		reader.input = ctx
+		reader.input = input
 	}
 
 	// Find stream information and do rest of the initialization
 	return reader.open(options)
 }
 
+// Create a new reader from an io.ReaderAt of a known size, such as an
+// HTTPReader or an object storage blob. The demuxer can seek to any offset,
+// so formats with an index at the end of the file, such as MP4 files with
+// the moov atom at the end, are read without reading the whole stream.
+func NewReaderAt(r io.ReaderAt, size int64, opt ...Opt) (*Reader, error) {
+	if r == nil {
+		return nil, media.ErrBadParameter.With("nil reader")
+	}
+	if size < 0 {
+		return nil, media.ErrBadParameter.With("invalid size")
+	}
+	return NewReader(io.NewSectionReader(r, 0, size), opt...)
+}
+
 func (r *Reader) open(options *opts) (*Reader, error) {
-	// Find stream information
-	if err := ff.AVFormat_find_stream_info(r.input, nil); err != nil {
+	// Log messages from the demuxer with the context of the reader
+	r.unlog = setLogContext(unsafe.Pointer(r.input), options.ctx)
+
+	// Find stream information, within the open timeout
+	if err := r.intr.end(ff.AVFormat_find_stream_info(r.input, nil)); err != nil {
+		r.unlog()
 		ff.AVFormat_free_context(r.input)
+		if r.avio != nil {
+			ff.AVFormat_avio_context_free(r.avio)
+		}
 		return nil, err
 	}
 
-	// Set force flag and type
+	// Set read timeout, force and autorotate flags and type
+	r.timeout = options.ioTimeout
+	r.exact = int64(ff.AV_NOPTS_VALUE)
 	r.force = options.force
+	r.rotate = options.autorotate
 	r.t = options.t | media.INPUT
 
 	// Return success
@@ -128,6 +180,13 @@ func (r *Reader) Close() error {
 
 	var result error
 
+	// Restore the log context before the demuxer is freed, as the address
+	// may be reused
+	if r.unlog != nil {
+		r.unlog()
+		r.unlog = nil
+	}
+
 	// Free resources
 	if r.input != nil {
 		ff.AVFormat_free_context(r.input)
@@ -178,6 +237,16 @@ func (r *Reader) Duration() time.Duration {
 	return 0
 }
 
+// Return the start time of the media stream, which is the timestamp of the
+// first frame, or zero if unknown
+func (r *Reader) StartTime() time.Duration {
+	start := r.input.StartTime()
+	if start > 0 && start != int64(ff.AV_NOPTS_VALUE) {
+		return time.Duration(start) * (time.Second / time.Duration(ff.AV_TIME_BASE))
+	}
+	return 0
+}
+
 // Return the raw AVStream objects for direct access
 func (r *Reader) AVStreams() []*ff.AVStream {
 	return r.input.Streams()
@@ -246,7 +315,32 @@ func (r *Reader) Seek(stream int, secs float64) error {
 	}
 	// At the moment, it seeks to the previous keyframe
 	tb := int64(secs / ff.AVUtil_rational_q2d(ctx.TimeBase()))
-	return ff.AVFormat_seek_frame(r.input, ctx.Index(), tb, ff.AVSEEK_FLAG_BACKWARD)
+	r.intr.begin(nil, r.timeout)
+	if err := r.intr.end(ff.AVFormat_seek_frame(r.input, ctx.Index(), tb, ff.AVSEEK_FLAG_BACKWARD)); err != nil {
+		return err
+	}
+
+	// Cancel any exact seek
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.exact = int64(ff.AV_NOPTS_VALUE)
+	return nil
+}
+
+// Seek to a specific time in the media stream, in seconds, so that the next
+// call to Demux returns frames from that time exactly. This seeks to the
+// previous keyframe, and Demux decodes from the keyframe and drops frames
+// before the time, trimming audio frames to the sample. Decode returns
+// packets from the keyframe.
+func (r *Reader) SeekExact(stream int, secs float64) error {
+	if err := r.Seek(stream, secs); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.exact = int64(secs * float64(ff.AV_TIME_BASE))
+	return nil
 }
 
 // Return the metadata for the media stream, filtering by the specified keys
@@ -273,19 +367,110 @@ func (r *Reader) Metadata(keys ...string) []*Metadata {
 	return result
 }
 
+// Return the chapters of the media stream in the order they are stored,
+// which is normally by start time
+func (r *Reader) Chapters() []*schema.Chapter {
+	chapters := r.input.Chapters()
+	result := make([]*schema.Chapter, 0, len(chapters))
+	for _, chapter := range chapters {
+		if c := schema.NewChapter(chapter); c != nil {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// Return the programs of the media stream, such as the services in an MPEG-TS
+// multiplex. Most other formats have no programs.
+func (r *Reader) Programs() []*schema.Program {
+	streams := r.input.Streams()
+	programs := r.input.Programs()
+	result := make([]*schema.Program, 0, len(programs))
+	for _, program := range programs {
+		if p := schema.NewProgram(program, streams); p != nil {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// Select a single program by identifier, so that Decode and Demux only
+// return packets and frames from the streams in that program. Pass zero to
+// select all streams again.
+func (r *Reader) SelectProgram(id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.input == nil {
+		return errors.New("reader is closed")
+	}
+
+	// Select all streams
+	if id == 0 {
+		for _, program := range r.input.Programs() {
+			program.SetDiscard(ff.AVDISCARD_DEFAULT)
+		}
+		for _, stream := range r.input.Streams() {
+			stream.SetDiscard(ff.AVDISCARD_DEFAULT)
+		}
+		r.prog = 0
+		return nil
+	}
+
+	// Find the program
+	var selected *ff.AVProgram
+	for _, program := range r.input.Programs() {
+		if program.Id() == id {
+			selected = program
+			break
+		}
+	}
+	if selected == nil {
+		return media.ErrNotFound.Withf("program %d", id)
+	}
+
+	// Discard the other programs, and streams which are not in the program
+	for _, program := range r.input.Programs() {
+		if program == selected {
+			program.SetDiscard(ff.AVDISCARD_DEFAULT)
+		} else {
+			program.SetDiscard(ff.AVDISCARD_ALL)
+		}
+	}
+	members := selected.StreamIndexes()
+	for _, stream := range r.input.Streams() {
+		if slices.Contains(members, stream.Index()) {
+			stream.SetDiscard(ff.AVDISCARD_DEFAULT)
+		} else {
+			stream.SetDiscard(ff.AVDISCARD_ALL)
+		}
+	}
+	r.prog = id
+	return nil
+}
+
+// Return the selected program, or zero if all streams are selected
+func (r *Reader) Program() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.prog
+}
+
 // Decode packets from the media stream without decoding to frames. The packetfn is called for each
-// packet read from any stream. Use this for stream copying or remuxing without transcoding.
+// packet read from any stream, or from the streams of the program set with SelectProgram.
+// Use this for stream copying or remuxing without transcoding.
 //
-// The reading can be interrupted by cancelling the context, or by the packetfn
+// The reading can be interrupted by cancelling the context, which also aborts a
+// blocking read from the network, or by the packetfn
 // returning an error or io.EOF. The latter will end the reading process early but
 // will not return an error.
 func (r *Reader) Decode(ctx context.Context, packetfn DecoderPacketFn) error {
-	// Check reader is valid
+	// Check reader is valid, and cancel any exact seek
 	r.mu.Lock()
 	if r.input == nil {
 		r.mu.Unlock()
 		return errors.New("reader is closed")
 	}
+	r.exact = int64(ff.AV_NOPTS_VALUE)
 	r.mu.Unlock()
 
 	// Create decoder
@@ -300,21 +485,47 @@ func (r *Reader) Decode(ctx context.Context, packetfn DecoderPacketFn) error {
 }
 
 // Demux and decode the media stream into frames and subtitles. The map function determines which
-// streams to decode and what output parameters to use. The framefn is called for each
+// streams to decode and what output parameters to use, and is not called for streams outside
+// the program set with SelectProgram. The framefn is called for each
 // decoded frame from any mapped stream. The optional subtitlefn is called for each decoded subtitle.
 //
-// The decoding can be interrupted by cancelling the context, or by the framefn/subtitlefn
+// The decoding can be interrupted by cancelling the context, which also aborts a
+// blocking read from the network, or by the framefn/subtitlefn
 // returning an error or io.EOF. The latter will end the decoding process early but
 // will not return an error.
-func (r *Reader) Demux(ctx context.Context, mapfn DecoderMapFunc, framefn DecoderFrameFn, subtitlefn DecoderSubtitleFn) error {
-	// Check reader is valid
+//
+// Use OptRange to decode a range of the media stream, or SeekExact before
+// calling Demux to decode from a time exactly.
+func (r *Reader) Demux(ctx context.Context, mapfn DecoderMapFunc, framefn DecoderFrameFn, subtitlefn DecoderSubtitleFn, opt ...Opt) error {
+	options := newOpts()
+	for _, opt := range opt {
+		if err := opt(options); err != nil {
+			return err
+		}
+	}
+
+	// Check reader is valid, and take the time from any exact seek
 	r.mu.Lock()
 	if r.input == nil {
 		r.mu.Unlock()
 		return errors.New("reader is closed")
 	}
+	start, end := r.exact, int64(ff.AV_NOPTS_VALUE)
+	r.exact = int64(ff.AV_NOPTS_VALUE)
 	r.mu.Unlock()
 
+	// Seek to the keyframe before the start of the range
+	if options.start > 0 {
+		start = int64(options.start / (time.Second / time.Duration(ff.AV_TIME_BASE)))
+		r.intr.begin(ctx, r.timeout)
+		if err := r.intr.end(ff.AVFormat_seek_frame(r.input, -1, start, ff.AVSEEK_FLAG_BACKWARD)); err != nil {
+			return err
+		}
+	}
+	if options.end > 0 {
+		end = int64(options.end / (time.Second / time.Duration(ff.AV_TIME_BASE)))
+	}
+
 	// Create decoder
 	dec, err := newDecoder(r)
 	if err != nil {
@@ -323,7 +534,7 @@ func (r *Reader) Demux(ctx context.Context, mapfn DecoderMapFunc, framefn Decode
 	defer dec.free()
 
 	// Decode frames
-	return dec.decodeFrames(ctx, mapfn, framefn, subtitlefn)
+	return dec.decodeFrames(ctx, mapfn, framefn, subtitlefn, start, end)
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -347,6 +558,10 @@ func (r *reader_callback) Seeker(offset int64, whence int) int64 {
 		return -1
 	}
 	if whence == ff.AVSEEK_SIZE {
+		// Return the size without seeking when it is known
+		if sizer, ok := r.r.(interface{ Size() int64 }); ok {
+			return sizer.Size()
+		}
 		current, err := seeker.Seek(0, io.SeekCurrent)
 		if err != nil {
 			return -1
@@ -2,14 +2,16 @@ package ffmpeg
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	// Packages
 	media "github.com/mutablelogic/go-media"
-	ff "github.com/mutablelogic/go-media/sys/ffmpeg80"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
 	assert "github.com/stretchr/testify/assert"
 )
 
@@ -843,3 +845,139 @@ func Test_reader_with_input_opt(t *testing.T) {
 	assert.NotNil(r)
 	t.Log(r)
 }
+
+func Test_reader_with_context(t *testing.T) {
+	assert := assert.New(t)
+
+	testFile := filepath.Join(testDir, "sample.mp4")
+	if _, err := os.Stat(testFile); os.IsNotExist(err) {
+		t.Skip("Test file not available:", testFile)
+	}
+
+	// Opening is aborted when the context is cancelled
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := Open(testFile, OptContext(ctx))
+	assert.ErrorIs(err, context.Canceled)
+
+	// Reading is aborted when the context is cancelled
+	ctx, cancel = context.WithCancel(context.Background())
+	r, err := Open(testFile, OptContext(ctx))
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer r.Close()
+	var count int
+	err = r.Decode(context.Background(), func(int, *Packet) error {
+		if count++; count == 10 {
+			cancel()
+		}
+		return nil
+	})
+	assert.ErrorIs(err, context.Canceled)
+	assert.Equal(10, count)
+}
+
+func Test_reader_with_timeout(t *testing.T) {
+	assert := assert.New(t)
+
+	testFile := filepath.Join(testDir, "sample.mp4")
+	if _, err := os.Stat(testFile); os.IsNotExist(err) {
+		t.Skip("Test file not available:", testFile)
+	}
+
+	// Invalid timeouts
+	_, err := Open(testFile, OptTimeout(-1, 0))
+	assert.Error(err)
+
+	// Opening takes longer than a nanosecond
+	_, err = Open(testFile, OptTimeout(time.Nanosecond, 0))
+	assert.ErrorIs(err, context.DeadlineExceeded)
+
+	// Reading a packet takes less than a minute
+	r, err := Open(testFile, OptTimeout(time.Minute, time.Minute))
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer r.Close()
+	assert.NoError(r.Decode(context.Background(), func(int, *Packet) error {
+		return nil
+	}))
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// TEST PROGRAMS
+
+func Test_reader_programs_mp4(t *testing.T) {
+	assert := assert.New(t)
+
+	reader, err := Open(testInputMP4)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer reader.Close()
+
+	assert.Empty(reader.Programs())
+	assert.ErrorIs(reader.SelectProgram(1), media.ErrNotFound)
+	assert.NoError(reader.SelectProgram(0))
+	assert.Equal(0, reader.Program())
+}
+
+func Test_reader_programs_ts(t *testing.T) {
+	assert := assert.New(t)
+
+	// Remux the sample into a transport stream, which has a single service
+	outputFile := filepath.Join(t.TempDir(), "programs.ts")
+	input, err := Open(testInputMP4)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer input.Close()
+	opts := []Opt{OptCopy()}
+	for _, stream := range input.Streams(media.ANY) {
+		opts = append(opts, OptStream(stream.Index()+1, &Par{AVCodecParameters: *stream.CodecPar(), timebase: stream.TimeBase()}))
+	}
+	writer, err := Create(outputFile, opts...)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	assert.NoError(input.Decode(context.Background(), func(_ int, pkt *Packet) error {
+		return writer.Write(pkt)
+	}))
+	assert.NoError(writer.Close())
+
+	// Read the programs
+	reader, err := Open(outputFile)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer reader.Close()
+
+	programs := reader.Programs()
+	if !assert.Len(programs, 1) {
+		t.FailNow()
+	}
+	program := programs[0]
+	assert.NotZero(program.Id)
+	assert.NotEmpty(program.Name)
+	assert.NotEmpty(program.Provider)
+	assert.Len(program.Streams, len(input.Streams(media.ANY)))
+	for _, pid := range program.Pids() {
+		assert.GreaterOrEqual(pid, 0x100)
+	}
+	t.Log(program)
+
+	// Select the program and read its packets
+	assert.NoError(reader.SelectProgram(program.Id))
+	assert.Equal(program.Id, reader.Program())
+	var count int
+	assert.NoError(reader.Decode(context.Background(), func(stream int, _ *Packet) error {
+		assert.Contains(program.Pids(), reader.AVStreams()[stream].Id())
+		count++
+		return nil
+	}))
+	assert.Greater(count, 0)
+
+	// Unknown programs are not found
+	assert.ErrorIs(reader.SelectProgram(program.Id+1), media.ErrNotFound)
+}
@@ -0,0 +1,133 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	// Packages
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// Chapter is a named section of a media file. Unlike Stream, it holds copies
+// of the values so it remains valid after the reader is closed.
+type Chapter struct {
+	Id       int64             `json:"id"`
+	Start    time.Duration     `json:"start"`
+	End      time.Duration     `json:"end"`
+	Title    string            `json:"title,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"` // Other chapter metadata
+}
+
+// Chapters is a list of chapters, which is displayed one chapter per line
+type Chapters []*Chapter
+
+////////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+const (
+	chapterTitle = "title"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+// NewChapter creates a Chapter from an AVChapter
+func NewChapter(ch *ff.AVChapter) *Chapter {
+	if ch == nil {
+		return nil
+	}
+	tb := ch.TimeBase()
+	c := &Chapter{
+		Id:    ch.Id(),
+		Start: tsToDuration(ch.Start(), tb),
+		End:   tsToDuration(ch.End(), tb),
+	}
+	for _, entry := range ff.AVUtil_dict_entries(ch.Metadata()) {
+		if entry.Key() == chapterTitle {
+			c.Title = entry.Value()
+			continue
+		}
+		if c.Metadata == nil {
+			c.Metadata = make(map[string]string)
+		}
+		c.Metadata[entry.Key()] = entry.Value()
+	}
+	return c
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (c *Chapter) String() string {
+	if c == nil {
+		return "<nil>"
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err.Error()
+	}
+	return string(data)
+}
+
+// String returns one chapter per line, as the start and end in seconds and
+// the title
+func (c Chapters) String() string {
+	lines := make([]string, 0, len(c))
+	for _, ch := range c {
+		if ch == nil {
+			continue
+		}
+		lines = append(lines, strings.TrimSpace(fmt.Sprintf("%s-%s %s",
+			strconv.FormatFloat(ch.Start.Seconds(), 'f', -1, 64),
+			strconv.FormatFloat(ch.End.Seconds(), 'f', -1, 64),
+			ch.Title,
+		)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Duration returns the length of the chapter
+func (c *Chapter) Duration() time.Duration {
+	if c == nil || c.End < c.Start {
+		return 0
+	}
+	return c.End - c.Start
+}
+
+// Dict returns the title and metadata as a dictionary, which the caller
+// should free or pass to AVChapter.SetMetadata
+func (c *Chapter) Dict() (*ff.AVDictionary, error) {
+	dict := ff.AVUtil_dict_alloc()
+	if c.Title != "" {
+		if err := ff.AVUtil_dict_set(dict, chapterTitle, c.Title, 0); err != nil {
+			ff.AVUtil_dict_free(dict)
+			return nil, err
+		}
+	}
+	for key, value := range c.Metadata {
+		if err := ff.AVUtil_dict_set(dict, key, value, 0); err != nil {
+			ff.AVUtil_dict_free(dict)
+			return nil, err
+		}
+	}
+	return dict, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE
+
+func tsToDuration(ts int64, tb ff.AVRational) time.Duration {
+	if ts == int64(ff.AV_NOPTS_VALUE) || tb.Den() == 0 {
+		return 0
+	}
+	return time.Duration(ff.AVUtil_rational_rescale_q(ts, tb, ff.AVUtil_rational(1, int(time.Second))))
+}
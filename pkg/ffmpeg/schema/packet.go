@@ -4,7 +4,7 @@ import (
 	"encoding/json"
 
 	// Packages
-	ff "github.com/mutablelogic/go-media/sys/ffmpeg80"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
 )
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -64,3 +64,12 @@ func (p *Packet) Ts() float64 {
 	}
 	return ff.AVUtil_rational_q2d(tb) * float64(pts)
 }
+
+// SideData returns a copy of the typed side data attached to the packet, or
+// nil if there is none
+func (p *Packet) SideData() *SideData {
+	if p == nil || p.AVPacket == nil {
+		return nil
+	}
+	return NewPacketSideData(p.AVPacket.SideData())
+}
@@ -0,0 +1,97 @@
+package schema
+
+import (
+	"encoding/json"
+
+	// Packages
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// Program is a group of streams which are presented together, such as one
+// service in an MPEG-TS multiplex
+type Program struct {
+	Id       int               `json:"id"`                 // Program number
+	Name     string            `json:"name,omitempty"`     // Service name
+	Provider string            `json:"provider,omitempty"` // Service provider
+	PmtPid   int               `json:"pmt_pid,omitempty"`  // PID of the program map table
+	PcrPid   int               `json:"pcr_pid,omitempty"`  // PID of the program clock reference
+	Streams  []*Stream         `json:"streams,omitempty"`  // Member streams, where Stream.Id() is the PID
+	Metadata map[string]string `json:"metadata,omitempty"` // Other program metadata
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+const (
+	programName     = "service_name"
+	programProvider = "service_provider"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+// NewProgram creates a Program from an AVProgram and the streams of the
+// format context which contains it
+func NewProgram(program *ff.AVProgram, streams []*ff.AVStream) *Program {
+	if program == nil {
+		return nil
+	}
+	p := &Program{
+		Id:     program.Id(),
+		PmtPid: max(program.PmtPid(), 0),
+		PcrPid: max(program.PcrPid(), 0),
+	}
+	for _, index := range program.StreamIndexes() {
+		if index >= 0 && index < len(streams) {
+			if s := NewStream(streams[index]); s != nil {
+				p.Streams = append(p.Streams, s)
+			}
+		}
+	}
+	for _, entry := range ff.AVUtil_dict_entries(program.Metadata()) {
+		switch entry.Key() {
+		case programName:
+			p.Name = entry.Value()
+		case programProvider:
+			p.Provider = entry.Value()
+		default:
+			if p.Metadata == nil {
+				p.Metadata = make(map[string]string)
+			}
+			p.Metadata[entry.Key()] = entry.Value()
+		}
+	}
+	return p
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (p *Program) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err.Error()
+	}
+	return string(data)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Pids returns the PIDs of the member streams, in stream order
+func (p *Program) Pids() []int {
+	if p == nil {
+		return nil
+	}
+	result := make([]int, 0, len(p.Streams))
+	for _, stream := range p.Streams {
+		result = append(result, stream.Id())
+	}
+	return result
+}
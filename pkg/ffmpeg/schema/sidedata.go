@@ -0,0 +1,357 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+
+	// Packages
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// SideData holds the typed side data of a packet, frame or stream. Values are
+// copied, so they remain valid after the packet or frame is released. Fields
+// are nil when the side data is not present.
+type SideData struct {
+	DisplayMatrix    *DisplayMatrix    `json:"display_matrix,omitempty"`
+	MasteringDisplay *MasteringDisplay `json:"mastering_display,omitempty"`
+	ContentLight     *ContentLight     `json:"content_light,omitempty"`
+	DynamicHDR       *DynamicHDR       `json:"dynamic_hdr,omitempty"`
+	Captions         []byte            `json:"captions,omitempty"` // ATSC A53 cc_data triplets
+	Stereo3D         *Stereo3D         `json:"stereo3d,omitempty"`
+	Spherical        *Spherical        `json:"spherical,omitempty"`
+	ReplayGain       *ReplayGain       `json:"replaygain,omitempty"`
+	SkipSamples      *SkipSamples      `json:"skip_samples,omitempty"`
+}
+
+// DisplayMatrix describes how video should be rotated and flipped for display
+type DisplayMatrix struct {
+	Rotation float64  `json:"rotation"`          // Counterclockwise rotation in degrees, as reported by ffprobe
+	Flipped  bool     `json:"flipped,omitempty"` // Image is mirrored horizontally
+	Matrix   [9]int32 `json:"-"`
+}
+
+// MasteringDisplay describes the colour volume of the display used to master
+// HDR content (SMPTE ST 2086)
+type MasteringDisplay struct {
+	Primaries    *[3][2]float64 `json:"primaries,omitempty"`   // CIE 1931 xy of red, green and blue
+	WhitePoint   *[2]float64    `json:"white_point,omitempty"` // CIE 1931 xy of the white point
+	MinLuminance *float64       `json:"min_luminance,omitempty"`
+	MaxLuminance *float64       `json:"max_luminance,omitempty"`
+}
+
+// ContentLight describes the light level of HDR content, in cd/m²
+type ContentLight struct {
+	MaxCLL  int `json:"max_cll"`
+	MaxFALL int `json:"max_fall"`
+}
+
+// DynamicHDR is a summary of HDR10+ dynamic metadata
+type DynamicHDR struct {
+	ApplicationVersion int     `json:"application_version"`
+	NumWindows         int     `json:"num_windows"`
+	MaxLuminance       float64 `json:"max_luminance,omitempty"` // Targeted display maximum luminance in cd/m²
+}
+
+// Stereo3D describes how the views of stereoscopic video are packed
+type Stereo3D struct {
+	Type     string `json:"type"`
+	Inverted bool   `json:"inverted,omitempty"`
+}
+
+// Spherical describes the projection of 360° video
+type Spherical struct {
+	Projection string  `json:"projection"`
+	Yaw        float64 `json:"yaw"`
+	Pitch      float64 `json:"pitch"`
+	Roll       float64 `json:"roll"`
+}
+
+// ReplayGain holds loudness normalization values, which are nil when unknown
+type ReplayGain struct {
+	TrackGain *float64 `json:"track_gain,omitempty"` // dB
+	TrackPeak *float64 `json:"track_peak,omitempty"` // Where 1.0 is full scale
+	AlbumGain *float64 `json:"album_gain,omitempty"` // dB
+	AlbumPeak *float64 `json:"album_peak,omitempty"` // Where 1.0 is full scale
+}
+
+// SkipSamples is the number of audio samples to discard from the start and
+// end of a packet or frame, for encoder delay and padding
+type SkipSamples struct {
+	Start int `json:"start,omitempty"`
+	End   int `json:"end,omitempty"`
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+const (
+	skipSamplesSize = 10 // Two little-endian 32-bit counts and two reasons
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+// NewPacketSideData returns the typed side data of a packet or stream, or nil
+// if there is none which is recognized
+func NewPacketSideData(sd []*ff.AVPacketSideData) *SideData {
+	s := new(SideData)
+	for _, entry := range sd {
+		data := entry.Data()
+		switch entry.Type() {
+		case ff.AV_PKT_DATA_DISPLAYMATRIX:
+			s.DisplayMatrix = newDisplayMatrix(data)
+		case ff.AV_PKT_DATA_MASTERING_DISPLAY_METADATA:
+			s.MasteringDisplay = newMasteringDisplay(data)
+		case ff.AV_PKT_DATA_CONTENT_LIGHT_LEVEL:
+			s.ContentLight = newContentLight(data)
+		case ff.AV_PKT_DATA_DYNAMIC_HDR10_PLUS:
+			if m, err := ff.AVUtil_dynamic_hdr_plus_from_t35(data); err == nil {
+				s.DynamicHDR = newDynamicHDR(m)
+			}
+		case ff.AV_PKT_DATA_A53_CC:
+			s.Captions = bytes.Clone(data)
+		case ff.AV_PKT_DATA_STEREO3D:
+			s.Stereo3D = newStereo3D(data)
+		case ff.AV_PKT_DATA_SPHERICAL:
+			s.Spherical = newSpherical(data)
+		case ff.AV_PKT_DATA_REPLAYGAIN:
+			s.ReplayGain = newReplayGain(data)
+		case ff.AV_PKT_DATA_SKIP_SAMPLES:
+			s.SkipSamples = newSkipSamples(data)
+		}
+	}
+	if s.IsZero() {
+		return nil
+	}
+	return s
+}
+
+// NewFrameSideData returns the typed side data of a frame, or nil if there is
+// none which is recognized
+func NewFrameSideData(sd []*ff.AVFrameSideData) *SideData {
+	s := new(SideData)
+	for _, entry := range sd {
+		data := entry.Data()
+		switch entry.Type() {
+		case ff.AV_FRAME_DATA_DISPLAYMATRIX:
+			s.DisplayMatrix = newDisplayMatrix(data)
+		case ff.AV_FRAME_DATA_MASTERING_DISPLAY_METADATA:
+			s.MasteringDisplay = newMasteringDisplay(data)
+		case ff.AV_FRAME_DATA_CONTENT_LIGHT_LEVEL:
+			s.ContentLight = newContentLight(data)
+		case ff.AV_FRAME_DATA_DYNAMIC_HDR_PLUS:
+			if m := ff.AVUtil_dynamic_hdr_plus(data); m != nil {
+				s.DynamicHDR = newDynamicHDR(m)
+			}
+		case ff.AV_FRAME_DATA_A53_CC:
+			s.Captions = append(s.Captions, data...)
+		case ff.AV_FRAME_DATA_STEREO3D:
+			s.Stereo3D = newStereo3D(data)
+		case ff.AV_FRAME_DATA_SPHERICAL:
+			s.Spherical = newSpherical(data)
+		case ff.AV_FRAME_DATA_REPLAYGAIN:
+			s.ReplayGain = newReplayGain(data)
+		case ff.AV_FRAME_DATA_SKIP_SAMPLES:
+			s.SkipSamples = newSkipSamples(data)
+		}
+	}
+	if s.IsZero() {
+		return nil
+	}
+	return s
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (s *SideData) String() string {
+	if s == nil {
+		return "<nil>"
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err.Error()
+	}
+	return string(data)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// IsZero returns true if there is no recognized side data
+func (s *SideData) IsZero() bool {
+	return s == nil || (s.DisplayMatrix == nil && s.MasteringDisplay == nil && s.ContentLight == nil &&
+		s.DynamicHDR == nil && len(s.Captions) == 0 && s.Stereo3D == nil && s.Spherical == nil &&
+		s.ReplayGain == nil && s.SkipSamples == nil)
+}
+
+// HDR returns "HDR10+" when there is dynamic HDR metadata, "HDR10" when there
+// is static HDR metadata, or an empty string otherwise
+func (s *SideData) HDR() string {
+	switch {
+	case s == nil:
+		return ""
+	case s.DynamicHDR != nil:
+		return "HDR10+"
+	case s.MasteringDisplay != nil || s.ContentLight != nil:
+		return "HDR10"
+	default:
+		return ""
+	}
+}
+
+// CaptionServices returns whether the captions carry CEA-608 data, in
+// either field, and CEA-708 data. Padding and invalid triplets are ignored.
+func (s *SideData) CaptionServices() (cea608, cea708 bool) {
+	if s == nil {
+		return false, false
+	}
+	for i := 0; i+2 < len(s.Captions); i += 3 {
+		marker, hi, lo := s.Captions[i], s.Captions[i+1], s.Captions[i+2]
+		if marker&0x04 == 0 {
+			continue
+		}
+		switch marker & 0x03 {
+		case 0, 1:
+			if hi&0x7F != 0 || lo&0x7F != 0 {
+				cea608 = true
+			}
+		default:
+			if hi != 0 || lo != 0 {
+				cea708 = true
+			}
+		}
+	}
+	return cea608, cea708
+}
+
+// Rotate returns the clockwise rotation in degrees which should be applied
+// for display, in the range [0, 360)
+func (m *DisplayMatrix) Rotate() float64 {
+	if m == nil || math.IsNaN(m.Rotation) {
+		return 0
+	}
+	theta := -math.Round(m.Rotation)
+	theta -= 360 * math.Floor(theta/360+0.9/360)
+	return theta
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE
+
+func newDisplayMatrix(data []byte) *DisplayMatrix {
+	m := ff.AVUtil_display_matrix(data)
+	if m == nil {
+		return nil
+	}
+	return &DisplayMatrix{
+		Rotation: ff.AVUtil_display_rotation_get(m),
+		Flipped:  m.IsFlipped(),
+		Matrix:   *m,
+	}
+}
+
+func newMasteringDisplay(data []byte) *MasteringDisplay {
+	m := ff.AVUtil_mastering_display_metadata(data)
+	if m == nil || (!m.HasPrimaries() && !m.HasLuminance()) {
+		return nil
+	}
+	result := new(MasteringDisplay)
+	if m.HasPrimaries() {
+		var primaries [3][2]float64
+		for i, xy := range m.DisplayPrimaries() {
+			primaries[i] = [2]float64{ff.AVUtil_rational_q2d(xy[0]), ff.AVUtil_rational_q2d(xy[1])}
+		}
+		whitepoint := m.WhitePoint()
+		result.Primaries = &primaries
+		result.WhitePoint = &[2]float64{ff.AVUtil_rational_q2d(whitepoint[0]), ff.AVUtil_rational_q2d(whitepoint[1])}
+	}
+	if m.HasLuminance() {
+		min, max := ff.AVUtil_rational_q2d(m.MinLuminance()), ff.AVUtil_rational_q2d(m.MaxLuminance())
+		result.MinLuminance = &min
+		result.MaxLuminance = &max
+	}
+	return result
+}
+
+func newContentLight(data []byte) *ContentLight {
+	m := ff.AVUtil_content_light_metadata(data)
+	if m == nil {
+		return nil
+	}
+	return &ContentLight{
+		MaxCLL:  m.MaxContentLightLevel(),
+		MaxFALL: m.MaxFrameAverageLightLevel(),
+	}
+}
+
+func newDynamicHDR(m *ff.AVDynamicHDRPlus) *DynamicHDR {
+	result := &DynamicHDR{
+		ApplicationVersion: m.ApplicationVersion(),
+		NumWindows:         m.NumWindows(),
+	}
+	if luminance := m.TargetedSystemDisplayMaxLuminance(); luminance.Den() != 0 {
+		result.MaxLuminance = ff.AVUtil_rational_q2d(luminance)
+	}
+	return result
+}
+
+func newStereo3D(data []byte) *Stereo3D {
+	s := ff.AVUtil_stereo3d(data)
+	if s == nil {
+		return nil
+	}
+	return &Stereo3D{
+		Type:     s.Type().String(),
+		Inverted: s.Inverted(),
+	}
+}
+
+func newSpherical(data []byte) *Spherical {
+	s := ff.AVUtil_spherical(data)
+	if s == nil {
+		return nil
+	}
+	return &Spherical{
+		Projection: s.Projection().String(),
+		Yaw:        s.Yaw(),
+		Pitch:      s.Pitch(),
+		Roll:       s.Roll(),
+	}
+}
+
+func newReplayGain(data []byte) *ReplayGain {
+	rg := ff.AVUtil_replaygain(data)
+	if rg == nil {
+		return nil
+	}
+	result := new(ReplayGain)
+	if v, ok := rg.TrackGain(); ok {
+		result.TrackGain = &v
+	}
+	if v, ok := rg.TrackPeak(); ok {
+		result.TrackPeak = &v
+	}
+	if v, ok := rg.AlbumGain(); ok {
+		result.AlbumGain = &v
+	}
+	if v, ok := rg.AlbumPeak(); ok {
+		result.AlbumPeak = &v
+	}
+	return result
+}
+
+func newSkipSamples(data []byte) *SkipSamples {
+	if len(data) < skipSamplesSize {
+		return nil
+	}
+	return &SkipSamples{
+		Start: int(binary.LittleEndian.Uint32(data[0:4])),
+		End:   int(binary.LittleEndian.Uint32(data[4:8])),
+	}
+}
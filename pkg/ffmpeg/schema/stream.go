@@ -5,7 +5,7 @@ import (
 
 	// Packages
 	media "github.com/mutablelogic/go-media"
-	ff "github.com/mutablelogic/go-media/sys/ffmpeg80"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
 )
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -15,6 +15,7 @@ import (
 type Stream struct {
 	*ff.AVStream
 	codecPar ff.AVCodecParameters // Copied at construction to survive reader close
+	sideData *SideData            // Typed coded side data, copied for the same reason
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -29,6 +30,7 @@ func NewStream(stream *ff.AVStream) *Stream {
 	// Copy codec parameters so they remain valid after reader is closed
 	if codecPar := stream.CodecPar(); codecPar != nil {
 		s.codecPar = *codecPar
+		s.sideData = NewPacketSideData(codecPar.CodedSideData())
 	}
 	return s
 }
@@ -51,6 +53,7 @@ func (s *Stream) MarshalJSON() ([]byte, error) {
 		NumFrames   int64                 `json:"num_frames,omitempty"`
 		TimeBase    ff.AVRational         `json:"time_base,omitempty"`
 		Disposition ff.AVDisposition      `json:"disposition,omitempty"`
+		SideData    *SideData             `json:"side_data,omitempty"`
 	}
 
 	return json.Marshal(jsonStream{
@@ -62,6 +65,7 @@ func (s *Stream) MarshalJSON() ([]byte, error) {
 		NumFrames:   s.AVStream.NumFrames(),
 		TimeBase:    s.AVStream.TimeBase(),
 		Disposition: s.AVStream.Disposition(),
+		SideData:    s.sideData,
 	})
 }
 
@@ -109,3 +113,12 @@ func (s *Stream) CodecPar() *ff.AVCodecParameters {
 	}
 	return &s.codecPar
 }
+
+// SideData returns the typed side data of the stream, such as the display
+// matrix or HDR metadata, or nil if there is none
+func (s *Stream) SideData() *SideData {
+	if s == nil {
+		return nil
+	}
+	return s.sideData
+}
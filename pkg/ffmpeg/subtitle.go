@@ -0,0 +1,289 @@
+package ffmpeg
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	// Packages
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// Subtitle is a decoded subtitle. Unlike the AVSubtitle it is made from, it
+// holds copies of the text and bitmaps, so it remains valid after decoding.
+type Subtitle struct {
+	Start   time.Duration     `json:"start"`             // Time the subtitle is shown
+	End     time.Duration     `json:"end,omitempty"`     // Time the subtitle is hidden, or zero when it is shown until the next subtitle
+	Text    string            `json:"text,omitempty"`    // Plain text, with lines separated by newlines
+	Events  []string          `json:"events,omitempty"`  // ASS events, as Layer, Style, Name, MarginL, MarginR, MarginV, Effect and Text fields
+	Bitmaps []*SubtitleBitmap `json:"bitmaps,omitempty"` // Images of bitmap subtitles, such as DVD, DVB and PGS subtitles
+}
+
+// SubtitleBitmap is an image of a bitmap subtitle, and where it is shown on
+// the video
+type SubtitleBitmap struct {
+	X     int         `json:"x"`
+	Y     int         `json:"y"`
+	Image image.Image `json:"-"`
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+const (
+	// Subtitles without an end time, which are not followed by another
+	// subtitle, are shown for this long
+	subtitleDuration = 5 * time.Second
+
+	// Header of ASS files for subtitles which are not decoded from ASS, as
+	// used by the FFmpeg text subtitle decoders
+	subtitleASSHeader = "[Script Info]\n" +
+		"ScriptType: v4.00+\n" +
+		"PlayResX: 384\n" +
+		"PlayResY: 288\n" +
+		"ScaledBorderAndShadow: yes\n" +
+		"\n" +
+		"[V4+ Styles]\n" +
+		"Format: Name, Fontname, Fontsize, PrimaryColour, SecondaryColour, OutlineColour, BackColour, Bold, Italic, Underline, StrikeOut, ScaleX, ScaleY, Spacing, Angle, BorderStyle, Outline, Shadow, Alignment, MarginL, MarginR, MarginV, Encoding\n" +
+		"Style: Default,Arial,16,&Hffffff,&Hffffff,&H0,&H0,0,0,0,0,100,100,0,0,1,1,0,2,10,10,10,0\n" +
+		"\n"
+	subtitleASSEvents = "[Events]\n" +
+		"Format: Layer, Start, End, Style, Name, MarginL, MarginR, MarginV, Effect, Text\n"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+// Create a subtitle from a decoded AVSubtitle. The start and end times are
+// from the presentation time of the subtitle, which is set when decoding
+// with Reader.Demux.
+func NewSubtitle(sub *ff.AVSubtitle) *Subtitle {
+	if sub == nil {
+		return nil
+	}
+
+	// Display times are in milliseconds from the presentation time
+	var pts time.Duration
+	if sub.PTS() != int64(ff.AV_NOPTS_VALUE) {
+		pts = time.Duration(sub.PTS()) * (time.Second / time.Duration(ff.AV_TIME_BASE))
+	}
+	subtitle := &Subtitle{
+		Start: pts + time.Duration(sub.StartDisplayTime())*time.Millisecond,
+	}
+	if end := sub.EndDisplayTime(); end > sub.StartDisplayTime() && end != math.MaxUint32 {
+		subtitle.End = pts + time.Duration(end)*time.Millisecond
+	}
+
+	// Copy the text and bitmaps
+	var lines []string
+	for _, rect := range sub.Rects() {
+		switch rect.Type() {
+		case ff.SUBTITLE_TEXT:
+			text := strings.TrimRight(rect.Text(), "\r\n")
+			lines = append(lines, text)
+			subtitle.Events = append(subtitle.Events, "0,Default,,0,0,0,,"+strings.ReplaceAll(text, "\n", `\N`))
+		case ff.SUBTITLE_ASS:
+			// Decoders output the read order before the fields of the event
+			_, event, _ := strings.Cut(rect.Text(), ",")
+			lines = append(lines, assText(event))
+			subtitle.Events = append(subtitle.Events, event)
+		case ff.SUBTITLE_BITMAP:
+			if img := subtitleImage(rect); img != nil {
+				subtitle.Bitmaps = append(subtitle.Bitmaps, &SubtitleBitmap{X: rect.X(), Y: rect.Y(), Image: img})
+			}
+		}
+	}
+	subtitle.Text = strings.Join(lines, "\n")
+
+	// Return the subtitle
+	return subtitle
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Return true if the subtitle has no text or bitmaps. Bitmap subtitle
+// streams use empty subtitles to hide the subtitle before.
+func (s *Subtitle) Empty() bool {
+	return s.Text == "" && len(s.Events) == 0 && len(s.Bitmaps) == 0
+}
+
+// Write subtitles in SubRip (SRT) format. Subtitles without text, such as
+// bitmap subtitles, are skipped.
+func WriteSRT(w io.Writer, subtitles []*Subtitle) error {
+	buf := bufio.NewWriter(w)
+	var n int
+	for i, s := range subtitles {
+		if strings.TrimSpace(s.Text) == "" {
+			continue
+		}
+		n++
+		fmt.Fprintf(buf, "%d\n%s --> %s\n%s\n\n", n, srtTime(s.Start), srtTime(subtitleEnd(subtitles, i)), srtText(s.Text))
+	}
+	return buf.Flush()
+}
+
+// Write subtitles in WebVTT format. Subtitles without text, such as bitmap
+// subtitles, are skipped.
+func WriteWebVTT(w io.Writer, subtitles []*Subtitle) error {
+	buf := bufio.NewWriter(w)
+	buf.WriteString("WEBVTT\n\n")
+	for i, s := range subtitles {
+		if strings.TrimSpace(s.Text) == "" {
+			continue
+		}
+		fmt.Fprintf(buf, "%s --> %s\n%s\n\n", vttTime(s.Start), vttTime(subtitleEnd(subtitles, i)), vttText(s.Text))
+	}
+	return buf.Flush()
+}
+
+// Write subtitles in Advanced SubStation Alpha (ASS) format. The header has
+// the script info and styles, as in the extradata of an ASS stream, and
+// when it is empty a default style is used. Subtitles without events, such
+// as bitmap subtitles, are skipped.
+func WriteASS(w io.Writer, header string, subtitles []*Subtitle) error {
+	buf := bufio.NewWriter(w)
+	if header = strings.TrimSpace(header); header == "" {
+		buf.WriteString(subtitleASSHeader)
+	} else {
+		buf.WriteString(header + "\n")
+	}
+	if !strings.Contains(header, "[Events]") {
+		if header != "" {
+			buf.WriteString("\n")
+		}
+		buf.WriteString(subtitleASSEvents)
+	}
+	for i, s := range subtitles {
+		for _, event := range s.Events {
+			layer, fields, _ := strings.Cut(event, ",")
+			fmt.Fprintf(buf, "Dialogue: %s,%s,%s,%s\n", layer, assTime(s.Start), assTime(subtitleEnd(subtitles, i)), fields)
+		}
+	}
+	return buf.Flush()
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// Return the end time of the subtitle at index i, which is the start of the
+// next subtitle when it has no end time
+func subtitleEnd(subtitles []*Subtitle, i int) time.Duration {
+	s := subtitles[i]
+	if s.End > s.Start {
+		return s.End
+	}
+	for _, next := range subtitles[i+1:] {
+		if next.Start > s.Start {
+			return next.Start
+		}
+	}
+	return s.Start + subtitleDuration
+}
+
+// Return the ASS header for encoding subtitles with the parameters, which
+// is in the extradata of ASS streams, or the default header otherwise
+func subtitleHeader(par *Par) string {
+	header := subtitleASSHeader
+	switch par.CodecID() {
+	case ff.AV_CODEC_ID_ASS, ff.AV_CODEC_ID_SSA:
+		if extradata := strings.TrimSpace(string(par.Extradata())); extradata != "" {
+			header = extradata + "\n\n"
+		}
+	}
+	if !strings.Contains(header, "[Events]") {
+		header += subtitleASSEvents
+	}
+	return header
+}
+
+// Return the plain text of the Text field of an ASS event, without the
+// override tags
+func assText(event string) string {
+	fields := strings.SplitN(event, ",", 8)
+	if len(fields) < 8 {
+		return ""
+	}
+	var text strings.Builder
+	var tag bool
+	for _, r := range fields[7] {
+		switch {
+		case r == '{':
+			tag = true
+		case r == '}' && tag:
+			tag = false
+		case !tag:
+			text.WriteRune(r)
+		}
+	}
+	return strings.NewReplacer(`\N`, "\n", `\n`, "\n", `\h`, " ").Replace(text.String())
+}
+
+// Return the image of a bitmap subtitle, which is indexed into a palette
+func subtitleImage(rect *ff.AVSubtitleRect) image.Image {
+	w, h := rect.Width(), rect.Height()
+	data, stride := rect.Data(0), rect.Linesize(0)
+	if w <= 0 || h <= 0 || len(data) < stride*h || stride < w {
+		return nil
+	}
+
+	// Indexes beyond the palette are transparent
+	palette := make(color.Palette, 256)
+	for i := range palette {
+		palette[i] = color.NRGBA{}
+	}
+	for i, argb := range rect.Palette() {
+		palette[i] = color.NRGBA{R: uint8(argb >> 16), G: uint8(argb >> 8), B: uint8(argb), A: uint8(argb >> 24)}
+	}
+
+	img := image.NewPaletted(image.Rect(0, 0, w, h), palette)
+	for y := 0; y < h; y++ {
+		copy(img.Pix[y*img.Stride:y*img.Stride+w], data[y*stride:y*stride+w])
+	}
+	return img
+}
+
+// Return text for a SubRip cue, which ends at the first blank line
+func srtText(text string) string {
+	lines := slices.DeleteFunc(strings.Split(text, "\n"), func(line string) bool {
+		return strings.TrimSpace(line) == ""
+	})
+	return strings.Join(lines, "\n")
+}
+
+// Escape text for a WebVTT cue, which cannot contain blank lines
+func vttText(text string) string {
+	return srtText(strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text))
+}
+
+// Return a time as hours, minutes, seconds and milliseconds, with a separator
+// before the milliseconds
+func subtitleTime(t time.Duration, sep string) string {
+	t = max(t, 0)
+	ms := t.Milliseconds()
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d", ms/3600000, ms/60000%60, ms/1000%60, sep, ms%1000)
+}
+
+func srtTime(t time.Duration) string {
+	return subtitleTime(t, ",")
+}
+
+func vttTime(t time.Duration) string {
+	return subtitleTime(t, ".")
+}
+
+// Return a time as hours, minutes, seconds and centiseconds
+func assTime(t time.Duration) string {
+	t = max(t, 0)
+	cs := t.Milliseconds() / 10
+	return fmt.Sprintf("%d:%02d:%02d.%02d", cs/360000, cs/6000%60, cs/100%60, cs%100)
+}
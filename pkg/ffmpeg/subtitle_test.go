@@ -0,0 +1,84 @@
+package ffmpeg
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	// Packages
+	assert "github.com/stretchr/testify/assert"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TEST SUBTITLE FORMATS
+
+func testSubtitles() []*Subtitle {
+	return []*Subtitle{
+		{Start: time.Second, End: 2500 * time.Millisecond, Text: "Hello\nworld", Events: []string{`0,Default,,0,0,0,,Hello\Nworld`}},
+		{Start: 3 * time.Second, Text: "<Bye> & done", Events: []string{`0,Default,,0,0,0,,{\i1}<Bye> & done`}},
+		{Start: 4 * time.Second},
+	}
+}
+
+func Test_subtitle_srt(t *testing.T) {
+	assert := assert.New(t)
+
+	var buf bytes.Buffer
+	if !assert.NoError(WriteSRT(&buf, testSubtitles())) {
+		t.FailNow()
+	}
+	assert.Equal("1\n00:00:01,000 --> 00:00:02,500\nHello\nworld\n\n"+
+		"2\n00:00:03,000 --> 00:00:04,000\n<Bye> & done\n\n", buf.String())
+}
+
+func Test_subtitle_srt_blank(t *testing.T) {
+	assert := assert.New(t)
+
+	var buf bytes.Buffer
+	if !assert.NoError(WriteSRT(&buf, []*Subtitle{{Start: time.Second, End: 2 * time.Second, Text: "Hello\n\nworld\n"}})) {
+		t.FailNow()
+	}
+	assert.Equal("1\n00:00:01,000 --> 00:00:02,000\nHello\nworld\n\n", buf.String())
+}
+
+func Test_subtitle_webvtt(t *testing.T) {
+	assert := assert.New(t)
+
+	var buf bytes.Buffer
+	if !assert.NoError(WriteWebVTT(&buf, testSubtitles())) {
+		t.FailNow()
+	}
+	assert.Equal("WEBVTT\n\n"+
+		"00:00:01.000 --> 00:00:02.500\nHello\nworld\n\n"+
+		"00:00:03.000 --> 00:00:04.000\n&lt;Bye&gt; &amp; done\n\n", buf.String())
+}
+
+func Test_subtitle_ass(t *testing.T) {
+	assert := assert.New(t)
+
+	var buf bytes.Buffer
+	if !assert.NoError(WriteASS(&buf, "", testSubtitles())) {
+		t.FailNow()
+	}
+	assert.Contains(buf.String(), "[V4+ Styles]\n")
+	assert.Contains(buf.String(), "[Events]\n")
+	assert.Contains(buf.String(), "Dialogue: 0,0:00:01.00,0:00:02.50,Default,,0,0,0,,Hello\\Nworld\n")
+	assert.Contains(buf.String(), "Dialogue: 0,0:00:03.00,0:00:04.00,Default,,0,0,0,,{\\i1}<Bye> & done\n")
+}
+
+func Test_subtitle_ass_text(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("Hello\nworld", assText(`0,Default,,0,0,0,,Hello\Nworld`))
+	assert.Equal("Bold, and italic", assText(`0,Default,,0,0,0,,{\b1}Bold{\b0}, and {\i1}italic`))
+	assert.Equal("", assText("invalid"))
+}
+
+func Test_subtitle_header(t *testing.T) {
+	assert := assert.New(t)
+
+	// Text subtitles are encoded with the default style
+	header := subtitleHeader(&Par{})
+	assert.Contains(header, "Style: Default,")
+	assert.Contains(header, "[Events]\nFormat: Layer, Start, End,")
+}
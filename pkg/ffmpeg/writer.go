@@ -11,6 +11,8 @@ import (
 	"os"
 	"sort"
 	"sync"
+	"time"
+	"unsafe"
 
 	// Anonymous imports
 	_ "image/jpeg" // Import for JPEG decoding
@@ -18,7 +20,8 @@ import (
 
 	// Packages
 	media "github.com/mutablelogic/go-media"
-	ff "github.com/mutablelogic/go-media/sys/ffmpeg80"
+	schema "github.com/mutablelogic/go-media/pkg/ffmpeg/schema"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
 )
 
 //////////////////////////////////////////////////////////////////////////////
@@ -49,11 +52,15 @@ type Writer struct {
 	output               *ff.AVFormatContext
 	header               bool // Track if header was successfully written (for Close)
 	encoders             []*encoder
-	artworks             [][]byte   // Artworks to write after header (can be multiple)
-	artworkStreamIndices []int      // Indices of artwork streams
-	artworkOnce          sync.Once  // Ensure artwork is written only once (thread-safe)
-	writeMutex           sync.Mutex // Protects concurrent writes to muxer
-	copy                 bool       // Copy mode (remuxing without encoding)
+	artworks             [][]byte                 // Artworks to write after header (can be multiple)
+	artworkStreamIndices []int                    // Indices of artwork streams
+	artworkOnce          sync.Once                // Ensure artwork is written only once (thread-safe)
+	writeMutex           sync.Mutex               // Protects concurrent writes to muxer
+	copy                 bool                     // Copy mode (remuxing without encoding)
+	bsfs                 map[int]*BitstreamFilter // Bitstream filters by stream index, in copy mode
+	intr                 *interrupt               // Aborts blocking writes
+	timeout              time.Duration            // Timeout for writing each packet, or zero
+	unlog                []func()                 // Restore the context of log messages from the muxer and encoders
 }
 
 func (w *Writer) writeInterleavedPacket(packet *Packet) error {
@@ -61,11 +68,29 @@ func (w *Writer) writeInterleavedPacket(packet *Packet) error {
 	// Use av_write_frame instead of av_interleaved_write_frame
 	// because av_interleaved_write_frame buffers packets and we need
 	// to understand the corruption issue first.
-	err := ff.AVFormat_write_frame(w.output, packet.AVPacket)
+	w.intr.begin(nil, w.timeout)
+	err := w.intr.end(ff.AVFormat_write_frame(w.output, packet.AVPacket))
 	w.writeMutex.Unlock()
 	return err
 }
 
+// writePacket writes a packet through the bitstream filter for its stream,
+// if there is one. When the packet has a timebase, the timestamps are
+// rescaled to the timebase of the output stream, which the muxer may have
+// changed when writing the header.
+func (w *Writer) writePacket(packet *Packet) error {
+	if tb := packet.TimeBase(); tb.Num() != 0 && tb.Den() != 0 {
+		if stream := w.output.Stream(packet.StreamIndex()); stream != nil && !ff.AVUtil_rational_equal(tb, stream.TimeBase()) {
+			ff.AVCodec_packet_rescale_ts(packet.AVPacket, tb, stream.TimeBase())
+			packet.SetTimeBase(stream.TimeBase())
+		}
+	}
+	if bsf, exists := w.bsfs[packet.StreamIndex()]; exists {
+		return bsf.Filter(packet, w.writeInterleavedPacket)
+	}
+	return w.writeInterleavedPacket(packet)
+}
+
 type writer_callback struct {
 	w io.Writer
 }
@@ -101,13 +126,25 @@ func Create(url string, opt ...Opt) (*Writer, error) {
 		return nil, media.ErrBadParameter.With("unable to guess the output format")
 	}
 
-	// Allocate the output media context
-	ctx, err := ff.AVFormat_create_file(url, options.oformat)
+	// Allocate the output media context, with an interrupt callback for cancellation
+	ctx, err := ff.AVFormat_alloc_output_context(options.oformat, url)
 	if err != nil {
 		return nil, err
-	} else {
-		writer.output = ctx
 	}
+	writer.intr = newInterrupt(options.ctx)
+	ctx.SetInterruptCallback(writer.intr.interrupted)
+
+	// Open I/O if the format needs a file, within the open timeout
+	if !ctx.Output().Flags().Is(ff.AVFMT_NOFILE) {
+		writer.intr.begin(nil, options.openTimeout)
+		ioctx, err := ff.AVFormat_avio_open2(url, ff.AVIO_FLAG_WRITE, ctx.InterruptCallback(), nil)
+		if err := writer.intr.end(err); err != nil {
+			ff.AVFormat_free_context(ctx)
+			return nil, err
+		}
+		ctx.SetPb(ioctx)
+	}
+	writer.output = ctx
 
 	// Continue with open
 	return writer.open(options)
@@ -151,6 +188,10 @@ func NewWriter(w io.Writer, opt ...Opt) (*Writer, error) {
 		writer.output = ctx
 	}
 
+	// Set the interrupt callback for cancellation
+	writer.intr = newInterrupt(options.ctx)
+	writer.output.SetInterruptCallback(writer.intr.interrupted)
+
 	// Continue with open
 	return writer.open(options)
 }
@@ -158,6 +199,9 @@ func NewWriter(w io.Writer, opt ...Opt) (*Writer, error) {
 func (writer *Writer) open(options *opts) (*Writer, error) {
 	var result error
 
+	// Set the timeout for writing each packet
+	writer.timeout = options.ioTimeout
+
 	// NOTE: options.streams is a map, so iteration order is nondeterministic.
 	// Stream index assignment (0..N-1) depends on creation order; tests and callers
 	// address streams by index, so we must create streams in a stable order.
@@ -167,11 +211,27 @@ func (writer *Writer) open(options *opts) (*Writer, error) {
 	}
 	sort.Ints(streamIDs)
 
+	// Two-pass encoding needs encoders
+	if options.copy && options.pass != 0 {
+		return nil, errors.Join(media.ErrBadParameter.With("two-pass encoding cannot be used with stream copy"), writer.Close())
+	}
+
 	// Create encoders or copy streams based on copy flag
 	if options.copy {
 		// Copy mode: create streams without encoders (for remuxing)
 		for _, stream := range streamIDs {
 			par := options.streams[stream]
+			// Subtitles which the format cannot store are encoded with the
+			// subtitle codec of the format, with EncodeSubtitle
+			if par.CodecType() == ff.AVMEDIA_TYPE_SUBTITLE && !ff.AVFormat_query_codec(writer.output.Output(), par.CodecID()) {
+				encoder, err := newEncoder(writer.output, stream, par, 0, nil)
+				if err != nil {
+					result = errors.Join(result, err)
+					continue
+				}
+				writer.encoders = append(writer.encoders, encoder)
+				continue
+			}
 			// Create stream
 			streamctx := ff.AVFormat_new_stream(writer.output, nil)
 			if streamctx == nil {
@@ -189,12 +249,29 @@ func (writer *Writer) open(options *opts) (*Writer, error) {
 			if par.timebase.Num() != 0 {
 				streamctx.SetTimeBase(par.timebase)
 			}
+			// Insert a bitstream filter when the muxer needs a different form
+			// of the stream, which may also change the codec parameters
+			if spec := autoBitstreamFilter(writer.output.Output(), par); spec != "" {
+				bsf, err := NewBitstreamFilter(spec, par)
+				if err != nil {
+					result = errors.Join(result, err)
+					continue
+				}
+				if writer.bsfs == nil {
+					writer.bsfs = make(map[int]*BitstreamFilter)
+				}
+				writer.bsfs[streamctx.Index()] = bsf
+				if err := ff.AVCodec_parameters_copy(streamctx.CodecPar(), bsf.ctx.ParOut()); err != nil {
+					result = errors.Join(result, err)
+					continue
+				}
+			}
 		}
 	} else {
 		// Encode mode: create codec contexts for each stream
 		for _, stream := range streamIDs {
 			par := options.streams[stream]
-			encoder, err := newEncoder(writer.output, stream, par)
+			encoder, err := newEncoder(writer.output, stream, par, options.pass, options.passlog)
 			if err != nil {
 				result = errors.Join(result, err)
 				continue
@@ -208,6 +285,17 @@ func (writer *Writer) open(options *opts) (*Writer, error) {
 		return nil, errors.Join(result, writer.Close())
 	}
 
+	// Log messages from the muxer and encoders with the context of the writer
+	writer.unlog = append(writer.unlog, setLogContext(unsafe.Pointer(writer.output), options.ctx))
+	for _, encoder := range writer.encoders {
+		writer.unlog = append(writer.unlog, setLogContext(unsafe.Pointer(encoder.ctx), options.ctx))
+	}
+
+	// Set the metadata and dispositions of the streams
+	if err := writer.setStreamMetadata(options.smeta, options.disp); err != nil {
+		return nil, errors.Join(err, writer.Close())
+	}
+
 	// Allocate metadata dictionary
 	metadata := ff.AVUtil_dict_alloc()
 	if metadata == nil {
@@ -263,10 +351,16 @@ func (writer *Writer) open(options *opts) (*Writer, error) {
 		writer.artworkStreamIndices = append(writer.artworkStreamIndices, int(stream.Index()))
 	}
 
+	// Add chapters, which are written with the header or trailer
+	if err := writer.addChapters(options.chapters); err != nil {
+		return nil, errors.Join(err, writer.Close())
+	}
+
 	// Set metadata, write the header
 	// Metadata ownership is transferred to the output context
 	writer.output.SetMetadata(metadata)
-	if err := ff.AVFormat_write_header(writer.output, nil); err != nil {
+	writer.intr.begin(nil, writer.timeout)
+	if err := writer.intr.end(ff.AVFormat_write_header(writer.output, nil)); err != nil {
 		return nil, errors.Join(err, writer.Close())
 	}
 	writer.header = true
@@ -275,6 +369,60 @@ func (writer *Writer) open(options *opts) (*Writer, error) {
 	return writer, nil
 }
 
+// setStreamMetadata sets the metadata and dispositions of the streams, by
+// stream identifier
+func (w *Writer) setStreamMetadata(smeta map[int][]*Metadata, disp map[int]ff.AVDisposition) error {
+	for _, stream := range w.output.Streams() {
+		if disposition, exists := disp[stream.Id()]; exists {
+			stream.SetDisposition(disposition)
+		}
+		entries := smeta[stream.Id()]
+		if len(entries) == 0 {
+			continue
+		}
+		dict := ff.AVUtil_dict_alloc()
+		if dict == nil {
+			return errors.New("unable to allocate metadata dictionary")
+		}
+		for _, entry := range entries {
+			if entry.Key() == "" {
+				continue
+			}
+			if err := ff.AVUtil_dict_set(dict, entry.Key(), entry.Value(), 0); err != nil {
+				ff.AVUtil_dict_free(dict)
+				return err
+			}
+		}
+		stream.SetMetadata(dict)
+	}
+	return nil
+}
+
+// addChapters adds chapters to the output context, in milliseconds. Chapters
+// without an identifier are numbered in order from one.
+func (w *Writer) addChapters(chapters []*schema.Chapter) error {
+	tb := ff.AVUtil_rational(1, 1000)
+	for i, chapter := range chapters {
+		if chapter.End < chapter.Start {
+			return media.ErrBadParameter.Withf("chapter %d ends before it starts", i)
+		}
+		id := chapter.Id
+		if id == 0 {
+			id = int64(i + 1)
+		}
+		ch := ff.AVFormat_new_chapter(w.output, id, tb, chapter.Start.Milliseconds(), chapter.End.Milliseconds())
+		if ch == nil {
+			return errors.New("failed to allocate chapter")
+		}
+		dict, err := chapter.Dict()
+		if err != nil {
+			return err
+		}
+		ch.SetMetadata(dict)
+	}
+	return nil
+}
+
 // writeArtwork writes all artwork packet data. This is called automatically by Write() and
 // WritePackets() on the first packet write.
 // Thread-safe: uses sync.Once to ensure artwork is written exactly once even with concurrent calls.
@@ -310,7 +458,8 @@ func (w *Writer) writeArtwork() error {
 			pkt.SetFlags(ff.AV_PKT_FLAG_KEY)
 
 			// Write the artwork packet
-			if err := ff.AVFormat_write_frame(w.output, pkt); err != nil {
+			w.intr.begin(nil, w.timeout)
+			if err := w.intr.end(ff.AVFormat_write_frame(w.output, pkt)); err != nil {
 				ff.AVCodec_packet_unref(pkt)
 				ff.AVCodec_packet_free(pkt)
 				writeErr = err
@@ -333,19 +482,36 @@ func (w *Writer) writeArtwork() error {
 func (w *Writer) Close() error {
 	var result error
 
+	// Flush bitstream filters
+	for _, bsf := range w.bsfs {
+		if w.header && w.output != nil {
+			result = errors.Join(result, bsf.Filter(nil, w.writeInterleavedPacket))
+		}
+		result = errors.Join(result, bsf.Close())
+	}
+	w.bsfs = nil
+
 	// Write the trailer only if header was successfully written
 	if w.header && w.output != nil {
 		// Ensure no concurrent packet writes while flushing/trailer.
 		w.writeMutex.Lock()
 		// Flush any internally buffered/interleaving packets.
+		w.intr.begin(nil, w.timeout)
 		_ = ff.AVFormat_interleaved_write_frame(w.output, nil)
-		err := ff.AVFormat_write_trailer(w.output)
+		err := w.intr.end(ff.AVFormat_write_trailer(w.output))
 		w.writeMutex.Unlock()
 		if err != nil {
 			result = errors.Join(result, err)
 		}
 	}
 
+	// Restore the log contexts before the muxer and encoders are freed, as
+	// the addresses may be reused
+	for _, fn := range w.unlog {
+		fn()
+	}
+	w.unlog = nil
+
 	// Close encoders
 	for _, encoder := range w.encoders {
 		result = errors.Join(result, encoder.Close())
@@ -416,7 +582,7 @@ func (w *Writer) Write(packet *Packet) error {
 		}
 	}
 
-	err := w.writeInterleavedPacket(packet)
+	err := w.writePacket(packet)
 	if err != nil {
 		return err
 	}
@@ -449,7 +615,7 @@ func (w *Writer) WritePackets(packets <-chan *Packet) error {
 		if packet == nil {
 			continue
 		}
-		err := w.writeInterleavedPacket(packet)
+		err := w.writePacket(packet)
 		if err != nil {
 			return err
 		}
@@ -492,6 +658,37 @@ func (w *Writer) EncodeFrame(stream int, frame *Frame) error {
 	})
 }
 
+// EncodeSubtitle encodes a decoded subtitle and writes the packet to the
+// output (synchronous). The stream is an encoded subtitle stream, which in
+// copy mode is one the output format cannot store as it is.
+func (w *Writer) EncodeSubtitle(stream int, sub *ff.AVSubtitle) error {
+	if w.output == nil {
+		return errors.New("writer is closed")
+	}
+
+	// Get encoder for this stream
+	encoder := w.Stream(stream)
+	if encoder == nil {
+		return media.ErrBadParameter.With("no encoder for stream")
+	}
+
+	// Automatically write artwork on first subtitle if available
+	if len(w.artworkStreamIndices) > 0 {
+		if err := w.writeArtwork(); err != nil {
+			return err
+		}
+	}
+
+	// Encode subtitle and write the packet
+	return encoder.EncodeSubtitle(sub, func(pkt *Packet) error {
+		if pkt == nil {
+			// Flush signal, ignore
+			return nil
+		}
+		return w.writePacket(pkt)
+	})
+}
+
 // EncodeFrames encodes frames from a channel and writes the resulting packets to the output (asynchronous).
 // This method blocks until the channel is closed or an error occurs. Returns nil when the channel is closed
 // normally, or an error if encoding/writing fails. When the channel closes, the encoder is automatically
@@ -558,6 +755,28 @@ func (w *Writer) EncodeFrames(stream int, frames <-chan *Frame) error {
 	})
 }
 
+// Set the start and end of a chapter added with OptChapters, by identifier.
+// Formats which store the chapters in the trailer, such as MP4, write the
+// new times when the writer is closed. Formats which store them with the
+// header, such as Matroska, keep the times the writer was created with.
+func (w *Writer) SetChapter(id int64, start, end time.Duration) error {
+	if end < start {
+		return media.ErrBadParameter.Withf("chapter %d ends before it starts", id)
+	}
+	if w.output == nil {
+		return media.ErrBadParameter.With("writer is closed")
+	}
+	for _, ch := range w.output.Chapters() {
+		if ch.Id() == id {
+			tb := ch.TimeBase()
+			ch.SetStart(ff.AVUtil_rational_rescale_q(start.Milliseconds(), ff.AVUtil_rational(1, 1000), tb))
+			ch.SetEnd(ff.AVUtil_rational_rescale_q(end.Milliseconds(), ff.AVUtil_rational(1, 1000), tb))
+			return nil
+		}
+	}
+	return media.ErrNotFound.Withf("chapter %d", id)
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 // PRIVATE METHODS - Writer
 
@@ -6,10 +6,12 @@ import (
 	"path/filepath"
 	"runtime"
 	"testing"
+	"time"
 
 	// Packages
 	media "github.com/mutablelogic/go-media"
-	ff "github.com/mutablelogic/go-media/sys/ffmpeg80"
+	schema "github.com/mutablelogic/go-media/pkg/ffmpeg/schema"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
 	assert "github.com/stretchr/testify/assert"
 )
 
@@ -868,6 +870,179 @@ func Test_encode_silent_audio_mp3(t *testing.T) {
 	t.Logf("Created silent audio (AAC): %s (%d bytes)", outputFile, info.Size())
 }
 
+// Test encoding audio frames which don't match the codec frame size
+func Test_encode_audio_fifo_m4a(t *testing.T) {
+	assert := assert.New(t)
+
+	outputFile := filepath.Join(t.TempDir(), "fifo_audio.m4a")
+
+	audioPar, err := NewAudioPar("fltp", "stereo", 44100)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	writer, err := Create(outputFile, OptStream(0, audioPar))
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer writer.Close()
+	assert.Equal(1024, writer.Stream(0).FrameSize())
+
+	// Encode 100 frames of 700 samples (~1.6 seconds), which the encoder
+	// re-chunks into 1024-sample AAC frames
+	var packets int
+	for i := 0; i < 100; i++ {
+		frame, err := NewFrame(audioPar)
+		if !assert.NoError(err) {
+			t.FailNow()
+		}
+		(*ff.AVFrame)(frame).SetNumSamples(700)
+		if err := frame.AllocateBuffers(); !assert.NoError(err) {
+			t.FailNow()
+		}
+		fillAudioSilenceFLTP(frame)
+		frame.SetPts(int64(i * 700))
+		err = writer.Stream(0).Encode(frame, func(pkt *Packet) error {
+			if pkt != nil {
+				packets++
+				return writer.Write(pkt)
+			}
+			return nil
+		})
+		frame.Close()
+		if !assert.NoError(err) {
+			t.FailNow()
+		}
+	}
+
+	// Flush, which pads the final frame
+	if err := writer.EncodeFrame(0, nil); !assert.NoError(err) {
+		t.FailNow()
+	}
+	if err := writer.Close(); !assert.NoError(err) {
+		t.FailNow()
+	}
+	assert.Greater(packets, 0)
+
+	// The output should cover all 70000 samples
+	reader, err := Open(outputFile)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer reader.Close()
+	assert.InDelta(70000.0/44100.0, reader.Duration().Seconds(), 0.1)
+	t.Logf("Encoded %d packets, duration %v", packets, reader.Duration())
+}
+
+// Test that audio frames in a different format are not buffered
+func Test_encode_audio_fifo_format(t *testing.T) {
+	assert := assert.New(t)
+
+	audioPar, err := NewAudioPar("fltp", "stereo", 44100)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	writer, err := Create(filepath.Join(t.TempDir(), "fifo_format.m4a"), OptStream(0, audioPar))
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer writer.Close()
+
+	// Packed samples, another sample rate and mono are all rejected
+	for _, par := range []struct {
+		format, layout string
+		rate           int
+	}{
+		{"s16", "stereo", 44100},
+		{"fltp", "stereo", 48000},
+		{"fltp", "mono", 44100},
+	} {
+		framePar, err := NewAudioPar(par.format, par.layout, par.rate)
+		if !assert.NoError(err) {
+			t.FailNow()
+		}
+		frame, err := NewFrame(framePar)
+		if !assert.NoError(err) {
+			t.FailNow()
+		}
+		(*ff.AVFrame)(frame).SetNumSamples(700)
+		if err := frame.AllocateBuffers(); !assert.NoError(err) {
+			t.FailNow()
+		}
+		err = writer.Stream(0).Encode(frame, func(pkt *Packet) error {
+			return nil
+		})
+		frame.Close()
+		assert.ErrorIs(err, media.ErrBadParameter)
+	}
+}
+
+// Test two-pass encoding with statistics kept in memory
+func Test_encode_two_pass_avi(t *testing.T) {
+	assert := assert.New(t)
+
+	log := NewPassLog()
+	defer log.Close()
+
+	encode := func(pass int, outputFile string) error {
+		videoPar, err := NewVideoPar("yuv420p", "320x240", 25)
+		if err != nil {
+			return err
+		}
+		videoPar.SetBitRate(200000)
+		writer, err := Create(outputFile, OptStream(0, videoPar), OptPass(pass, log))
+		if err != nil {
+			return err
+		}
+		defer writer.Close()
+
+		frame, err := NewFrame(videoPar)
+		if err != nil {
+			return err
+		}
+		defer frame.Close()
+		if err := frame.AllocateBuffers(); err != nil {
+			return err
+		}
+		for i := 0; i < 25; i++ {
+			fillVideoBlackYUV420P(frame)
+			frame.Bytes(0)[i] = 255
+			frame.SetPts(int64(i))
+			if err := writer.EncodeFrame(0, frame); err != nil {
+				return err
+			}
+		}
+		if err := writer.EncodeFrame(0, nil); err != nil {
+			return err
+		}
+		return writer.Close()
+	}
+
+	// The second pass needs statistics from the first
+	tmp := t.TempDir()
+	assert.Error(encode(2, filepath.Join(tmp, "pass2.avi")))
+
+	// First pass collects statistics for the stream
+	if !assert.NoError(encode(1, filepath.Join(tmp, "pass1.avi"))) {
+		t.FailNow()
+	}
+	stats := log.Stats(0)
+	assert.NotEmpty(stats)
+	t.Logf("First pass statistics: %d bytes", len(stats))
+
+	// Second pass reads them
+	outputFile := filepath.Join(tmp, "pass2.avi")
+	if !assert.NoError(encode(2, outputFile)) {
+		t.FailNow()
+	}
+	info, err := os.Stat(outputFile)
+	assert.NoError(err)
+	assert.Greater(info.Size(), int64(0))
+
+	// Two-pass encoding needs an encoder
+	_, err = Create(filepath.Join(tmp, "copy.avi"), OptStream(0, VideoPar("yuv420p", "320x240", 25)), OptCopy(), OptPass(1, log))
+	assert.Error(err)
+}
+
 // Test encoding frames via channel (asynchronous)
 func Test_encode_frames_async_mp4(t *testing.T) {
 	// Fixed: encoder now leaves packet ownership to muxer
@@ -1586,6 +1761,185 @@ func Test_encode_interleaving_comparison(t *testing.T) {
 	assert.Greater(info2.Size(), int64(0))
 }
 
+////////////////////////////////////////////////////////////////////////////////
+// TEST CHAPTERS
+
+func Test_writer_copy_chapters_mkv(t *testing.T) {
+	assert := assert.New(t)
+
+	outputFile := filepath.Join(t.TempDir(), "chapters.mkv")
+
+	reader, err := Open(testInputMP4)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer reader.Close()
+	assert.Empty(reader.Chapters())
+
+	// Copy the streams and add two chapters
+	chapters := []*schema.Chapter{
+		{Start: 0, End: time.Second, Title: "Opening"},
+		{Start: time.Second, End: 2 * time.Second, Title: "Second", Metadata: map[string]string{"language": "eng"}},
+	}
+	opts := []Opt{OptCopy(), OptChapters(chapters...)}
+	for _, stream := range reader.Streams(media.ANY) {
+		opts = append(opts, OptStream(stream.Index()+1, &Par{AVCodecParameters: *stream.CodecPar()}))
+	}
+	writer, err := Create(outputFile, opts...)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	assert.NoError(reader.Decode(context.Background(), func(stream int, pkt *Packet) error {
+		return writer.Write(pkt)
+	}))
+	assert.NoError(writer.Close())
+
+	// Read the chapters back
+	verify, err := Open(outputFile)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer verify.Close()
+
+	result := verify.Chapters()
+	if assert.Len(result, 2) {
+		for i, chapter := range result {
+			assert.Equal(chapters[i].Title, chapter.Title)
+			assert.Equal(chapters[i].Start, chapter.Start)
+			assert.Equal(chapters[i].End, chapter.End)
+			assert.Equal(time.Second, chapter.Duration())
+			t.Log(chapter)
+		}
+	}
+}
+
+func Test_writer_set_chapter_mp4(t *testing.T) {
+	assert := assert.New(t)
+
+	outputFile := filepath.Join(t.TempDir(), "chapters.mp4")
+
+	reader, err := Open(testInputMP4)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer reader.Close()
+
+	// Copy the streams and add two chapters
+	chapters := []*schema.Chapter{
+		{Start: 0, End: time.Second, Title: "Opening"},
+		{Start: time.Second, End: 2 * time.Second, Title: "Second"},
+	}
+	opts := []Opt{OptCopy(), OptChapters(chapters...)}
+	for _, stream := range reader.Streams(media.ANY) {
+		opts = append(opts, OptStream(stream.Index()+1, &Par{AVCodecParameters: *stream.CodecPar()}))
+	}
+	writer, err := Create(outputFile, opts...)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	assert.NoError(reader.Decode(context.Background(), func(stream int, pkt *Packet) error {
+		return writer.Write(pkt)
+	}))
+
+	// Move the second chapter, which is written with the trailer
+	assert.NoError(writer.SetChapter(1, 0, 1500*time.Millisecond))
+	assert.NoError(writer.SetChapter(2, 1500*time.Millisecond, 2*time.Second))
+	assert.ErrorIs(writer.SetChapter(3, 0, time.Second), media.ErrNotFound)
+	assert.ErrorIs(writer.SetChapter(2, time.Second, 0), media.ErrBadParameter)
+	assert.NoError(writer.Close())
+
+	// Read the chapters back
+	verify, err := Open(outputFile)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer verify.Close()
+
+	result := verify.Chapters()
+	if assert.Len(result, 2) {
+		assert.Equal(time.Duration(0), result[0].Start)
+		assert.Equal(1500*time.Millisecond, result[1].Start)
+	}
+}
+
+func Test_writer_chapters_invalid(t *testing.T) {
+	assert := assert.New(t)
+
+	par, err := NewAudioPar("fltp", "stereo", 44100)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+
+	// Nil chapter
+	_, err = Create(filepath.Join(t.TempDir(), "nil.mkv"), OptStream(0, par), OptChapters(nil))
+	assert.Error(err)
+
+	// Chapter ends before it starts
+	_, err = Create(filepath.Join(t.TempDir(), "invalid.mkv"), OptStream(0, par), OptChapters(&schema.Chapter{Start: time.Second}))
+	assert.Error(err)
+}
+
+func Test_writer_copy_subtitles_mp4(t *testing.T) {
+	assert := assert.New(t)
+
+	// SubRip subtitles cannot be stored in MP4, so are encoded as mov_text
+	input := filepath.Join(t.TempDir(), "subtitles.srt")
+	if !assert.NoError(os.WriteFile(input, []byte("1\n00:00:01,000 --> 00:00:02,500\nHello\n\n2\n00:00:03,000 --> 00:00:04,000\nGoodbye\n\n"), 0644)) {
+		t.FailNow()
+	}
+	reader, err := Open(input)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer reader.Close()
+
+	outputFile := filepath.Join(t.TempDir(), "subtitles.mp4")
+	stream := reader.Streams(media.SUBTITLE)[0]
+	writer, err := Create(outputFile, OptCopy(), OptStream(1, &Par{AVCodecParameters: *stream.CodecPar()}),
+		OptStreamMetadata(1, NewMetadata("language", "eng")), OptDisposition(1, ff.AV_DISPOSITION_DEFAULT))
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	if !assert.NotNil(writer.Stream(0)) {
+		t.FailNow()
+	}
+	assert.Equal(ff.AV_CODEC_ID_MOV_TEXT, writer.Stream(0).Par().CodecID())
+
+	mapfn := func(_ int, par *Par) (*Par, error) {
+		return par, nil
+	}
+	assert.NoError(reader.Demux(context.Background(), mapfn, nil, func(_ int, sub *ff.AVSubtitle) error {
+		return writer.EncodeSubtitle(0, sub)
+	}))
+	assert.NoError(writer.Close())
+
+	// Read the subtitles back
+	verify, err := Open(outputFile)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer verify.Close()
+	if assert.Len(verify.AVStreams(), 1) {
+		if entry := ff.AVUtil_dict_get(verify.AVStreams()[0].Metadata(), "language", nil, 0); assert.NotNil(entry) {
+			assert.Equal("eng", entry.Value())
+		}
+		assert.True(verify.AVStreams()[0].Disposition().Is(ff.AV_DISPOSITION_DEFAULT))
+	}
+
+	var subtitles []*Subtitle
+	assert.NoError(verify.Demux(context.Background(), mapfn, nil, func(_ int, sub *ff.AVSubtitle) error {
+		subtitles = append(subtitles, NewSubtitle(sub))
+		return nil
+	}))
+	if assert.Len(subtitles, 2) {
+		assert.Equal(time.Second, subtitles[0].Start)
+		assert.Equal(2500*time.Millisecond, subtitles[0].End)
+		assert.Equal("Hello", subtitles[0].Text)
+		assert.Equal(3*time.Second, subtitles[1].Start)
+		assert.Equal("Goodbye", subtitles[1].Text)
+	}
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 // HELPER FUNCTIONS
 
@@ -0,0 +1,256 @@
+package heif
+
+import (
+	"encoding/json"
+	"image"
+
+	// Packages
+	libheif "github.com/mutablelogic/go-media/sys/libheif"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// Auxiliary is an auxiliary image attached to the primary image, such as an
+// alpha plane, depth map, portrait matte or HDR gain map.
+type Auxiliary struct {
+	Type  string      `json:"type"`
+	Image image.Image `json:"-"`
+}
+
+// Depth is a depth image attached to the primary image, together with the
+// representation info needed to interpret its samples.
+type Depth struct {
+	Image image.Image `json:"-"`
+	Info  *DepthInfo  `json:"info,omitempty"`
+}
+
+// DepthInfo describes how depth samples map onto distances. Limits which are
+// not present in the file are nil.
+type DepthInfo struct {
+	Type                   string   `json:"type"`
+	ZNear                  *float64 `json:"z_near,omitempty"`
+	ZFar                   *float64 `json:"z_far,omitempty"`
+	DMin                   *float64 `json:"d_min,omitempty"`
+	DMax                   *float64 `json:"d_max,omitempty"`
+	DisparityReferenceView int      `json:"disparity_reference_view,omitempty"`
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+// Well-known auxiliary image type URNs
+const (
+	AuxAlpha                = "urn:mpeg:mpegB:cicp:systems:auxiliary:alpha"
+	AuxAlphaHEVC            = "urn:mpeg:hevc:2015:auxid:1"
+	AuxDepth                = "urn:mpeg:mpegB:cicp:systems:auxiliary:depth"
+	AuxDepthHEVC            = "urn:mpeg:hevc:2015:auxid:2"
+	AuxHDRGainMap           = "urn:com:apple:photo:2020:aux:hdrgainmap"
+	AuxPortraitEffectsMatte = "urn:com:apple:photo:2018:aux:portraiteffectsmatte"
+	AuxSkinMatte            = "urn:com:apple:photo:2019:aux:semanticskinmatte"
+	AuxHairMatte            = "urn:com:apple:photo:2019:aux:semantichairmatte"
+	AuxTeethMatte           = "urn:com:apple:photo:2019:aux:semanticteethmatte"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// NumImages returns the number of top-level images in the file, without
+// decoding them.
+func (h *HEIF) NumImages() int {
+	if h == nil || h.ctx == nil {
+		return 0
+	}
+	return libheif.Libheif_context_get_number_of_top_level_images(h.ctx)
+}
+
+// Images returns every top-level image in the file, in file order. The
+// primary image is included; burst and multi-image files return more than one.
+func (h *HEIF) Images() []image.Image {
+	if h == nil || h.ctx == nil {
+		return nil
+	}
+
+	count := libheif.Libheif_context_get_number_of_top_level_images(h.ctx)
+	if count <= 0 {
+		return nil
+	}
+
+	images := make([]image.Image, 0, count)
+	for _, id := range libheif.Libheif_context_get_list_of_top_level_image_IDs(h.ctx, count) {
+		handle, err := libheif.Libheif_context_get_image_handle(h.ctx, id)
+		if err != nil || handle == nil {
+			continue
+		}
+		img, err := decodeHandle(handle)
+		libheif.Libheif_image_handle_release(handle)
+		if err != nil {
+			continue
+		}
+		images = append(images, img)
+	}
+
+	return images
+}
+
+// AuxiliaryTypes returns the type URNs of the auxiliary images attached to
+// the primary image, without decoding them.
+func (h *HEIF) AuxiliaryTypes() []string {
+	var result []string
+	h.auxiliaryHandles(func(urn string, _ *libheif.ImageHandle) {
+		result = append(result, urn)
+	})
+	return result
+}
+
+// Auxiliary returns the decoded auxiliary images of the primary image whose
+// type matches urn, or all auxiliary images when urn is empty.
+func (h *HEIF) Auxiliary(urn string) []*Auxiliary {
+	var result []*Auxiliary
+	h.auxiliaryHandles(func(auxType string, handle *libheif.ImageHandle) {
+		if urn != "" && auxType != urn {
+			return
+		}
+		if img, err := decodeHandle(handle); err == nil {
+			result = append(result, &Auxiliary{Type: auxType, Image: img})
+		}
+	})
+	return result
+}
+
+// Depth returns the decoded depth images of the primary image.
+func (h *HEIF) Depth() []*Depth {
+	if h == nil || h.ctx == nil {
+		return nil
+	}
+
+	handle, err := libheif.Libheif_context_get_primary_image_handle(h.ctx)
+	if err != nil || handle == nil {
+		return nil
+	}
+	defer libheif.Libheif_image_handle_release(handle)
+
+	count := libheif.Libheif_image_handle_get_number_of_depth_images(handle)
+	if count <= 0 {
+		return nil
+	}
+
+	var result []*Depth
+	for _, id := range libheif.Libheif_image_handle_get_list_of_depth_image_IDs(handle, count) {
+		depthHandle, err := libheif.Libheif_image_handle_get_depth_image_handle(handle, id)
+		if err != nil || depthHandle == nil {
+			continue
+		}
+		img, err := decodeHandle(depthHandle)
+		libheif.Libheif_image_handle_release(depthHandle)
+		if err != nil {
+			continue
+		}
+		result = append(result, &Depth{
+			Image: img,
+			Info:  newDepthInfo(libheif.Libheif_image_handle_get_depth_image_representation_info(handle, id)),
+		})
+	}
+
+	return result
+}
+
+// DepthInfo returns the representation info of the first depth image of the
+// primary image without decoding it, or nil if there is no depth image.
+func (h *HEIF) DepthInfo() *DepthInfo {
+	if h == nil || h.ctx == nil {
+		return nil
+	}
+
+	handle, err := libheif.Libheif_context_get_primary_image_handle(h.ctx)
+	if err != nil || handle == nil {
+		return nil
+	}
+	defer libheif.Libheif_image_handle_release(handle)
+
+	if !libheif.Libheif_image_handle_has_depth_image(handle) {
+		return nil
+	}
+	ids := libheif.Libheif_image_handle_get_list_of_depth_image_IDs(handle, 1)
+	if len(ids) == 0 {
+		return nil
+	}
+	return newDepthInfo(libheif.Libheif_image_handle_get_depth_image_representation_info(handle, ids[0]))
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (d *DepthInfo) String() string {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return err.Error()
+	}
+	return string(data)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE
+
+// auxiliaryHandles calls fn for each auxiliary image of the primary image,
+// including alpha and depth planes. The handle is released after fn returns.
+func (h *HEIF) auxiliaryHandles(fn func(string, *libheif.ImageHandle)) {
+	if h == nil || h.ctx == nil {
+		return
+	}
+
+	handle, err := libheif.Libheif_context_get_primary_image_handle(h.ctx)
+	if err != nil || handle == nil {
+		return
+	}
+	defer libheif.Libheif_image_handle_release(handle)
+
+	count := libheif.Libheif_image_handle_get_number_of_auxiliary_images(handle, 0)
+	if count <= 0 {
+		return
+	}
+
+	for _, id := range libheif.Libheif_image_handle_get_list_of_auxiliary_image_IDs(handle, 0, count) {
+		auxHandle, err := libheif.Libheif_image_handle_get_auxiliary_image_handle(handle, id)
+		if err != nil || auxHandle == nil {
+			continue
+		}
+		if auxType, err := libheif.Libheif_image_handle_get_auxiliary_type(auxHandle); err == nil {
+			fn(auxType, auxHandle)
+		}
+		libheif.Libheif_image_handle_release(auxHandle)
+	}
+}
+
+func newDepthInfo(info *libheif.DepthRepresentationInfo) *DepthInfo {
+	if info == nil {
+		return nil
+	}
+
+	result := &DepthInfo{
+		DisparityReferenceView: info.DisparityReferenceView,
+	}
+	switch info.Type {
+	case libheif.HEIF_DEPTH_REPRESENTATION_TYPE_UNIFORM_INVERSE_Z:
+		result.Type = "uniform_inverse_z"
+	case libheif.HEIF_DEPTH_REPRESENTATION_TYPE_UNIFORM_DISPARITY:
+		result.Type = "uniform_disparity"
+	case libheif.HEIF_DEPTH_REPRESENTATION_TYPE_UNIFORM_Z:
+		result.Type = "uniform_z"
+	case libheif.HEIF_DEPTH_REPRESENTATION_TYPE_NONUNIFORM_DISPARITY:
+		result.Type = "nonuniform_disparity"
+	}
+	if info.HasZNear {
+		result.ZNear = &info.ZNear
+	}
+	if info.HasZFar {
+		result.ZFar = &info.ZFar
+	}
+	if info.HasDMin {
+		result.DMin = &info.DMin
+	}
+	if info.HasDMax {
+		result.DMax = &info.DMax
+	}
+	return result
+}
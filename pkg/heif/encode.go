@@ -0,0 +1,276 @@
+package heif
+
+import (
+	"encoding/binary"
+	"image"
+	"image/color"
+	"io"
+
+	// Packages
+	media "github.com/mutablelogic/go-media"
+	libheif "github.com/mutablelogic/go-media/sys/libheif"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// Format selects the compression used for the encoded image.
+type Format int
+
+// Chroma selects the chroma subsampling used by the encoder.
+type Chroma string
+
+// Options control how an image is encoded. A nil Options encodes a HEIC
+// image with the encoder defaults.
+type Options struct {
+	Format    Format // HEIC (HEVC) or AVIF (AV1)
+	Quality   int    // Lossy quality between 1 and 100, or zero for the encoder default
+	Lossless  bool   // Encode losslessly, ignoring Quality
+	Chroma    Chroma // Chroma subsampling, or empty for the encoder default
+	BitDepth  int    // Bits per channel: 8, 10 or 12. Zero selects 8
+	Thumbnail int    // Bounding box size of an attached thumbnail, or zero for none
+	Exif      []byte // EXIF block to attach to the primary image
+	XMP       []byte // XMP packet to attach to the primary image
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+const (
+	FormatHEIC Format = iota
+	FormatAVIF
+)
+
+const (
+	Chroma420 Chroma = "420"
+	Chroma422 Chroma = "422"
+	Chroma444 Chroma = "444"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Encode writes img to w as a HEIC or AVIF file, according to opts.
+func Encode(w io.Writer, img image.Image, opts *Options) error {
+	if w == nil || img == nil {
+		return media.ErrBadParameter.With("nil writer or image")
+	}
+	if opts == nil {
+		opts = &Options{}
+	}
+	if err := opts.validate(); err != nil {
+		return err
+	}
+	if bounds := img.Bounds(); bounds.Dx() <= 0 || bounds.Dy() <= 0 {
+		return media.ErrBadParameter.Withf("invalid image bounds %v", bounds)
+	}
+
+	format := opts.compressionFormat()
+	if !libheif.Libheif_have_encoder_for_format(format) {
+		return media.ErrNotImplemented.Withf("no encoder available for %v", opts.Format)
+	}
+
+	// Convert the image
+	src, err := encodeFromImage(img, opts.bitDepth())
+	if err != nil {
+		return err
+	}
+	defer libheif.Libheif_image_release(src)
+
+	ctx := libheif.Libheif_context_alloc()
+	if ctx == nil {
+		return media.ErrInternalError.With("libheif context alloc failed")
+	}
+	defer libheif.Libheif_context_free(ctx)
+
+	// Configure the encoder
+	encoder, err := libheif.Libheif_context_get_encoder_for_format(ctx, format)
+	if err != nil {
+		return err
+	}
+	defer libheif.Libheif_encoder_release(encoder)
+	if err := opts.configure(encoder); err != nil {
+		return err
+	}
+
+	// Encode the primary image
+	handle, err := libheif.Libheif_context_encode_image(ctx, src, encoder, nil)
+	if err != nil {
+		return err
+	}
+	defer libheif.Libheif_image_handle_release(handle)
+	if err := libheif.Libheif_context_set_primary_image(ctx, handle); err != nil {
+		return err
+	}
+
+	// Attach the thumbnail
+	if opts.Thumbnail > 0 {
+		thumb, err := libheif.Libheif_context_encode_thumbnail(ctx, src, handle, encoder, nil, opts.Thumbnail)
+		if err != nil {
+			return err
+		}
+		if thumb != nil {
+			libheif.Libheif_image_handle_release(thumb)
+		}
+	}
+
+	// Attach metadata
+	if err := libheif.Libheif_context_add_exif_metadata(ctx, handle, opts.Exif); err != nil {
+		return err
+	}
+	if err := libheif.Libheif_context_add_XMP_metadata(ctx, handle, opts.XMP); err != nil {
+		return err
+	}
+
+	// Set brands and write
+	if opts.Format == FormatAVIF {
+		libheif.Libheif_context_set_major_brand(ctx, libheif.HEIF_BRAND2_AVIF)
+	} else {
+		libheif.Libheif_context_set_major_brand(ctx, libheif.HEIF_BRAND2_HEIC)
+	}
+	libheif.Libheif_context_add_compatible_brand(ctx, libheif.HEIF_BRAND2_MIF1)
+
+	return libheif.Libheif_context_write(ctx, func(data []byte) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (f Format) String() string {
+	switch f {
+	case FormatHEIC:
+		return "heic"
+	case FormatAVIF:
+		return "avif"
+	default:
+		return "unknown"
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE
+
+func (opts *Options) validate() error {
+	switch opts.Format {
+	case FormatHEIC, FormatAVIF:
+	default:
+		return media.ErrBadParameter.Withf("unsupported format %v", opts.Format)
+	}
+	if opts.Quality < 0 || opts.Quality > 100 {
+		return media.ErrBadParameter.Withf("quality %d out of range", opts.Quality)
+	}
+	switch opts.Chroma {
+	case "", Chroma420, Chroma422, Chroma444:
+	default:
+		return media.ErrBadParameter.Withf("unsupported chroma %q", opts.Chroma)
+	}
+	switch opts.BitDepth {
+	case 0, 8, 10, 12:
+	default:
+		return media.ErrBadParameter.Withf("unsupported bit depth %d", opts.BitDepth)
+	}
+	if opts.Thumbnail < 0 {
+		return media.ErrBadParameter.Withf("invalid thumbnail size %d", opts.Thumbnail)
+	}
+	return nil
+}
+
+func (opts *Options) compressionFormat() libheif.CompressionFormat {
+	if opts.Format == FormatAVIF {
+		return libheif.HEIF_COMPRESSION_AV1
+	}
+	return libheif.HEIF_COMPRESSION_HEVC
+}
+
+func (opts *Options) bitDepth() int {
+	if opts.BitDepth == 0 {
+		return 8
+	}
+	return opts.BitDepth
+}
+
+func (opts *Options) configure(encoder *libheif.Encoder) error {
+	if opts.Lossless {
+		if err := libheif.Libheif_encoder_set_lossless(encoder, true); err != nil {
+			return err
+		}
+	} else if opts.Quality > 0 {
+		if err := libheif.Libheif_encoder_set_lossy_quality(encoder, opts.Quality); err != nil {
+			return err
+		}
+	}
+	if opts.Chroma != "" {
+		if err := libheif.Libheif_encoder_set_parameter(encoder, "chroma", string(opts.Chroma)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encodeFromImage copies img into an interleaved RGB(A) libheif image with
+// the given number of bits per channel.
+func encodeFromImage(img image.Image, bits int) (*libheif.Image, error) {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	alpha := !isOpaque(img)
+
+	chroma := libheif.HEIF_CHROMA_INTERLEAVED_RGB
+	channels := 3
+	if alpha {
+		chroma = libheif.HEIF_CHROMA_INTERLEAVED_RGBA
+		channels = 4
+	}
+	if bits > 8 {
+		if alpha {
+			chroma = libheif.HEIF_CHROMA_INTERLEAVED_RRGGBBAA_LE
+		} else {
+			chroma = libheif.HEIF_CHROMA_INTERLEAVED_RRGGBB_LE
+		}
+	}
+
+	out, err := libheif.Libheif_image_create(width, height, libheif.HEIF_COLORSPACE_RGB, chroma)
+	if err != nil {
+		return nil, err
+	}
+	if err := libheif.Libheif_image_add_plane(out, libheif.HEIF_CHANNEL_INTERLEAVED, width, height, bits); err != nil {
+		libheif.Libheif_image_release(out)
+		return nil, err
+	}
+	plane, stride := libheif.Libheif_image_get_plane(out, libheif.HEIF_CHANNEL_INTERLEAVED)
+	if len(plane) == 0 || stride <= 0 {
+		libheif.Libheif_image_release(out)
+		return nil, media.ErrInternalError.With("libheif image has no writable plane")
+	}
+
+	shift := 16 - bits
+	for y := 0; y < height; y++ {
+		row := plane[y*stride:]
+		for x := 0; x < width; x++ {
+			c := color.NRGBA64Model.Convert(img.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.NRGBA64)
+			values := [4]uint16{c.R, c.G, c.B, c.A}
+			if bits <= 8 {
+				di := x * channels
+				for i := 0; i < channels; i++ {
+					row[di+i] = uint8(values[i] >> 8)
+				}
+			} else {
+				di := x * channels * 2
+				for i := 0; i < channels; i++ {
+					binary.LittleEndian.PutUint16(row[di+i*2:], values[i]>>shift)
+				}
+			}
+		}
+	}
+
+	return out, nil
+}
+
+func isOpaque(img image.Image) bool {
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		return o.Opaque()
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package heif_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"testing"
+
+	// Packages
+	"github.com/mutablelogic/go-media/pkg/heif"
+	libheif "github.com/mutablelogic/go-media/sys/libheif"
+)
+
+func testImage(width, height int) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 0x80, A: 0xff})
+		}
+	}
+	return img
+}
+
+func Test_heif_encode_000(t *testing.T) {
+	if !libheif.Libheif_have_encoder_for_format(libheif.HEIF_COMPRESSION_HEVC) {
+		t.Skip("no HEVC encoder available in this libheif build")
+	}
+
+	var buf bytes.Buffer
+	if err := heif.Encode(&buf, testImage(64, 48), &heif.Options{Quality: 80, Thumbnail: 16}); err != nil {
+		t.Fatal(err)
+	}
+
+	h, err := heif.Parse(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	if bounds := h.Bounds(); bounds.Dx() != 64 || bounds.Dy() != 48 {
+		t.Fatalf("unexpected bounds: %v", bounds)
+	}
+	if thumbs := h.Thumbnails(); len(thumbs) != 1 {
+		t.Fatalf("thumbnails=%d want=1", len(thumbs))
+	}
+}
+
+func Test_heif_encode_001(t *testing.T) {
+	if !libheif.Libheif_have_encoder_for_format(libheif.HEIF_COMPRESSION_AV1) {
+		t.Skip("no AV1 encoder available in this libheif build")
+	}
+
+	xmp := []byte(`<x:xmpmeta xmlns:x="adobe:ns:meta/"><rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"><rdf:Description xmlns:dc="http://purl.org/dc/elements/1.1/"><dc:format>image/avif</dc:format></rdf:Description></rdf:RDF></x:xmpmeta>`)
+
+	var buf bytes.Buffer
+	if err := heif.Encode(&buf, testImage(32, 32), &heif.Options{
+		Format:   heif.FormatAVIF,
+		Lossless: true,
+		Chroma:   heif.Chroma444,
+		BitDepth: 10,
+		XMP:      xmp,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, format, err := image.DecodeConfig(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if format != "avif" {
+		t.Fatalf("format=%q want=%q", format, "avif")
+	}
+}
+
+func Test_heif_encode_002(t *testing.T) {
+	var buf bytes.Buffer
+	if err := heif.Encode(&buf, testImage(8, 8), &heif.Options{BitDepth: 9}); err == nil {
+		t.Fatal("expected error for unsupported bit depth")
+	}
+	if err := heif.Encode(&buf, testImage(8, 8), &heif.Options{Chroma: "411"}); err == nil {
+		t.Fatal("expected error for unsupported chroma")
+	}
+}
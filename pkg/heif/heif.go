@@ -220,6 +220,12 @@ func decodePrimaryImage(ctx *libheif.Context) (image.Image, error) {
 	}
 	defer libheif.Libheif_image_handle_release(handle)
 
+	return decodeHandle(handle)
+}
+
+// decodeHandle decodes an image handle into interleaved RGB(A), choosing a
+// 16-bit layout when the source has more than eight bits per channel.
+func decodeHandle(handle *libheif.ImageHandle) (image.Image, error) {
 	alpha := libheif.Libheif_image_handle_has_alpha_channel(handle)
 	lumaBits := libheif.Libheif_image_handle_get_luma_bits_per_pixel(handle)
 	chromaBits := libheif.Libheif_image_handle_get_chroma_bits_per_pixel(handle)
@@ -161,3 +161,74 @@ func Test_heif_006(t *testing.T) {
 		}
 	}
 }
+
+func Test_heif_007(t *testing.T) {
+	h, err := heif.Open(testHEIF)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	n := h.NumImages()
+	if n <= 0 {
+		t.Fatalf("NumImages=%d", n)
+	}
+	images := h.Images()
+	if len(images) != n {
+		t.Fatalf("Images=%d want=%d", len(images), n)
+	}
+	for i, img := range images {
+		if bounds := img.Bounds(); bounds.Dx() <= 0 || bounds.Dy() <= 0 {
+			t.Fatalf("image %d has invalid bounds: %v", i, bounds)
+		}
+	}
+}
+
+func Test_heif_008(t *testing.T) {
+	h, err := heif.Open(testHEIF)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	types := h.AuxiliaryTypes()
+	if len(types) == 0 {
+		t.Skip("no auxiliary images in fixture")
+	}
+	t.Logf("auxiliary=%v", types)
+
+	for _, urn := range types {
+		if urn == "" {
+			t.Fatal("empty auxiliary type")
+		}
+		for _, aux := range h.Auxiliary(urn) {
+			if aux.Type != urn {
+				t.Fatalf("auxiliary type=%q want=%q", aux.Type, urn)
+			}
+			if aux.Image == nil {
+				t.Fatalf("auxiliary %q has no image", urn)
+			}
+		}
+	}
+	for _, depth := range h.Depth() {
+		if depth.Image == nil {
+			t.Fatal("depth image is nil")
+		}
+	}
+}
+
+func Test_heif_009(t *testing.T) {
+	h, err := heif.Open(testHEIF)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	// A still image has no sequence tracks
+	if tracks := h.Tracks(); len(tracks) != 0 {
+		t.Fatalf("Tracks=%d want=0", len(tracks))
+	}
+	if d := h.Duration(); d != 0 {
+		t.Fatalf("Duration=%v want=0", d)
+	}
+}
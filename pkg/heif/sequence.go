@@ -0,0 +1,109 @@
+package heif
+
+import (
+	"image"
+	"time"
+
+	// Packages
+	media "github.com/mutablelogic/go-media"
+	libheif "github.com/mutablelogic/go-media/sys/libheif"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// Track describes an image sequence or video track, as found in burst photos
+// and animated HEIF/AVIF files.
+type Track struct {
+	ID        uint32 `json:"id"`
+	Type      string `json:"type"`
+	Width     int    `json:"width,omitempty"`
+	Height    int    `json:"height,omitempty"`
+	Timescale uint32 `json:"timescale,omitempty"`
+}
+
+// TrackFrameFn is called for each decoded image of a track, with the time
+// the image should be displayed for.
+type TrackFrameFn func(img image.Image, duration time.Duration) error
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Duration returns the duration of the image sequence, or zero if the file
+// does not contain a sequence.
+func (h *HEIF) Duration() time.Duration {
+	if h == nil || h.ctx == nil || !libheif.Libheif_context_has_sequence(h.ctx) {
+		return 0
+	}
+	timescale := libheif.Libheif_context_get_sequence_timescale(h.ctx)
+	if timescale == 0 {
+		return 0
+	}
+	return time.Duration(libheif.Libheif_context_get_sequence_duration(h.ctx)) * time.Second / time.Duration(timescale)
+}
+
+// Tracks returns the sequence tracks in the file.
+func (h *HEIF) Tracks() []*Track {
+	if h == nil || h.ctx == nil || !libheif.Libheif_context_has_sequence(h.ctx) {
+		return nil
+	}
+
+	var result []*Track
+	for _, id := range libheif.Libheif_context_get_track_ids(h.ctx) {
+		track := libheif.Libheif_context_get_track(h.ctx, id)
+		if track == nil {
+			continue
+		}
+		t := &Track{
+			ID:        libheif.Libheif_track_get_id(track),
+			Type:      libheif.Libheif_track_get_track_handler_type(track).String(),
+			Timescale: libheif.Libheif_track_get_timescale(track),
+		}
+		if width, height, err := libheif.Libheif_track_get_image_resolution(track); err == nil {
+			t.Width, t.Height = width, height
+		}
+		libheif.Libheif_track_release(track)
+		result = append(result, t)
+	}
+
+	return result
+}
+
+// DecodeTrack decodes every image of a track in presentation order, calling
+// fn for each. A zero id selects the first visual track.
+func (h *HEIF) DecodeTrack(id uint32, fn TrackFrameFn) error {
+	if h == nil || h.ctx == nil {
+		return media.ErrBadParameter.With("nil HEIF")
+	}
+	if fn == nil {
+		return media.ErrBadParameter.With("nil callback")
+	}
+
+	track := libheif.Libheif_context_get_track(h.ctx, id)
+	if track == nil {
+		return media.ErrNotFound.Withf("track %d", id)
+	}
+	defer libheif.Libheif_track_release(track)
+
+	timescale := time.Duration(libheif.Libheif_track_get_timescale(track))
+	for {
+		img, err := libheif.Libheif_track_decode_next_image(track, libheif.HEIF_COLORSPACE_RGB, libheif.HEIF_CHROMA_INTERLEAVED_RGB, nil)
+		if err != nil {
+			if heifErr, ok := err.(libheif.HeifError); ok && heifErr.Code == libheif.HEIF_ERROR_END_OF_SEQUENCE {
+				return nil
+			}
+			return err
+		}
+
+		var duration time.Duration
+		if timescale > 0 {
+			duration = time.Duration(libheif.Libheif_image_get_duration(img)) * time.Second / timescale
+		}
+		frame := decodeToImage(img)
+		libheif.Libheif_image_release(img)
+
+		if err := fn(frame, duration); err != nil {
+			return err
+		}
+	}
+}
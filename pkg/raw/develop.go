@@ -0,0 +1,178 @@
+package raw
+
+import (
+	"math"
+
+	// Packages
+	media "github.com/mutablelogic/go-media"
+	libraw "github.com/mutablelogic/go-media/sys/libraw"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// WhiteBalance selects how the white balance multipliers are chosen.
+type WhiteBalance int
+
+// Highlight selects how clipped highlights are handled.
+type Highlight int
+
+// ColorSpace selects the output colour space.
+type ColorSpace int
+
+// DevelopOptions control how the RAW data is developed by Develop. The zero
+// value develops with the as-shot white balance into 8-bit sRGB, clipping
+// highlights.
+type DevelopOptions struct {
+	WhiteBalance WhiteBalance // As-shot, auto or custom
+	Multipliers  [4]float32   // R, G, B, G2 multipliers when WhiteBalance is WhiteBalanceCustom
+	Exposure     float64      // Exposure correction in EV, between -2 and +3
+	Highlight    Highlight    // Highlight recovery mode
+	ColorSpace   ColorSpace   // Output colour space
+	Bits         int          // Bits per sample, 8 or 16. Zero selects 8
+	HalfSize     bool         // Develop at half resolution, without demosaicing
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+const (
+	WhiteBalanceAsShot WhiteBalance = iota
+	WhiteBalanceAuto
+	WhiteBalanceCustom
+)
+
+const (
+	HighlightClip Highlight = iota
+	HighlightUnclip
+	HighlightBlend
+	HighlightRebuild // Values above HighlightRebuild (up to 9) rebuild more aggressively
+)
+
+const (
+	ColorSpaceSRGB ColorSpace = iota
+	ColorSpaceAdobeRGB
+	ColorSpaceProPhoto
+)
+
+// LibRaw output_color values
+const (
+	outputColorSRGB     = 1
+	outputColorAdobeRGB = 2
+	outputColorProPhoto = 4
+)
+
+// LibRaw defaults for the gamma curve, which is BT.709
+const (
+	defaultGammaPower = 0.45
+	defaultGammaSlope = 4.5
+)
+
+const (
+	maxHighlight  = 9
+	minExposureEV = -2.0
+	maxExposureEV = 3.0
+	defaultBits   = 8
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+func (opts *DevelopOptions) validate() error {
+	switch opts.WhiteBalance {
+	case WhiteBalanceAsShot, WhiteBalanceAuto:
+	case WhiteBalanceCustom:
+		if opts.Multipliers[0] <= 0 || opts.Multipliers[1] <= 0 || opts.Multipliers[2] <= 0 {
+			return media.ErrBadParameter.With("custom white balance requires R, G and B multipliers")
+		}
+	default:
+		return media.ErrBadParameter.Withf("unsupported white balance %d", opts.WhiteBalance)
+	}
+	if opts.Exposure < minExposureEV || opts.Exposure > maxExposureEV {
+		return media.ErrBadParameter.Withf("exposure %v EV out of range", opts.Exposure)
+	}
+	if opts.Highlight < HighlightClip || opts.Highlight > maxHighlight {
+		return media.ErrBadParameter.Withf("unsupported highlight mode %d", opts.Highlight)
+	}
+	switch opts.ColorSpace {
+	case ColorSpaceSRGB, ColorSpaceAdobeRGB, ColorSpaceProPhoto:
+	default:
+		return media.ErrBadParameter.Withf("unsupported colour space %d", opts.ColorSpace)
+	}
+	switch opts.Bits {
+	case 0, 8, 16:
+	default:
+		return media.ErrBadParameter.Withf("unsupported bits per sample %d", opts.Bits)
+	}
+	return nil
+}
+
+// apply writes the options into the libraw output parameters. It must be
+// called before dcraw_process.
+func (opts *DevelopOptions) apply(data *libraw.Data) {
+	// White balance
+	libraw.Libraw_set_use_camera_wb(data, opts.WhiteBalance == WhiteBalanceAsShot)
+	libraw.Libraw_set_use_auto_wb(data, opts.WhiteBalance == WhiteBalanceAuto)
+	for i := 0; i < 4; i++ {
+		value := float32(0)
+		if opts.WhiteBalance == WhiteBalanceCustom {
+			value = opts.Multipliers[i]
+			if i == 3 && value <= 0 {
+				value = opts.Multipliers[1]
+			}
+		}
+		libraw.Libraw_set_user_mul(data, i, value)
+	}
+
+	// Exposure and highlights. Highlights are preserved when pushing the
+	// exposure, unless they are to be clipped anyway
+	preserve := float32(0)
+	if opts.Highlight != HighlightClip {
+		preserve = 1
+	}
+	libraw.Libraw_set_exposure(data, float32(math.Exp2(opts.Exposure)), preserve)
+	libraw.Libraw_set_highlight(data, int(opts.Highlight))
+
+	// Colour space and matching transfer curve
+	switch opts.ColorSpace {
+	case ColorSpaceAdobeRGB:
+		libraw.Libraw_set_output_color(data, outputColorAdobeRGB)
+		libraw.Libraw_set_gamma(data, 0, 1/2.19921875)
+		libraw.Libraw_set_gamma(data, 1, 0)
+	case ColorSpaceProPhoto:
+		libraw.Libraw_set_output_color(data, outputColorProPhoto)
+		libraw.Libraw_set_gamma(data, 0, 1/1.8)
+		libraw.Libraw_set_gamma(data, 1, 0)
+	default:
+		libraw.Libraw_set_output_color(data, outputColorSRGB)
+		libraw.Libraw_set_gamma(data, 0, 1/2.4)
+		libraw.Libraw_set_gamma(data, 1, 12.92)
+	}
+
+	// Output depth and size
+	if opts.Bits == 0 {
+		libraw.Libraw_set_output_bps(data, defaultBits)
+	} else {
+		libraw.Libraw_set_output_bps(data, opts.Bits)
+	}
+	libraw.Libraw_set_half_size(data, opts.HalfSize)
+	libraw.Libraw_set_no_auto_bright(data, 0)
+}
+
+// resetDevelop restores the libraw defaults for the output parameters which
+// apply writes, so earlier calls do not affect the next dcraw_process.
+func resetDevelop(data *libraw.Data) {
+	libraw.Libraw_set_use_camera_wb(data, false)
+	libraw.Libraw_set_use_auto_wb(data, false)
+	for i := 0; i < 4; i++ {
+		libraw.Libraw_set_user_mul(data, i, 0)
+	}
+	libraw.Libraw_set_exposure(data, 1, 0)
+	libraw.Libraw_set_highlight(data, int(HighlightClip))
+	libraw.Libraw_set_output_color(data, outputColorSRGB)
+	libraw.Libraw_set_gamma(data, 0, defaultGammaPower)
+	libraw.Libraw_set_gamma(data, 1, defaultGammaSlope)
+	libraw.Libraw_set_output_bps(data, defaultBits)
+	libraw.Libraw_set_half_size(data, false)
+	libraw.Libraw_set_no_auto_bright(data, 0)
+}
@@ -0,0 +1,365 @@
+package raw
+
+import (
+	"bytes"
+	"image"
+	"io"
+	"math"
+	"strings"
+
+	// Packages
+	media "github.com/mutablelogic/go-media"
+	libraw "github.com/mutablelogic/go-media/sys/libraw"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// ExportFormat selects the container written by Export.
+type ExportFormat int
+
+// ExportOptions control how a RAW file is exported by Export. The image is
+// always developed to 16 bits per sample; Develop.Bits is ignored.
+type ExportOptions struct {
+	Format  ExportFormat   // TIFF or linear DNG
+	Develop DevelopOptions // White balance, exposure, highlights, colour space and size
+	Linear  bool           // Write linear (gamma 1.0) samples. Always set for DNG
+	Preview bool           // Embed the camera's JPEG preview as a reduced-resolution subimage
+	XMP     []byte         // XMP packet to embed, or nil to copy the packet from the RAW file
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+const (
+	ExportTIFF ExportFormat = iota // 16-bit RGB TIFF
+	ExportDNG                      // 16-bit LinearRaw DNG
+)
+
+// TIFF, EXIF, GPS and DNG tags written by Export
+const (
+	tagNewSubfileType         = 254
+	tagImageWidth             = 256
+	tagImageLength            = 257
+	tagBitsPerSample          = 258
+	tagCompression            = 259
+	tagPhotometric            = 262
+	tagImageDescription       = 270
+	tagMake                   = 271
+	tagModel                  = 272
+	tagStripOffsets           = 273
+	tagOrientation            = 274
+	tagSamplesPerPixel        = 277
+	tagRowsPerStrip           = 278
+	tagStripByteCounts        = 279
+	tagPlanarConfiguration    = 284
+	tagSoftware               = 305
+	tagDateTime               = 306
+	tagArtist                 = 315
+	tagSubIFDs                = 330
+	tagXMP                    = 700
+	tagExposureTime           = 33434
+	tagFNumber                = 33437
+	tagExifIFD                = 34665
+	tagGPSIFD                 = 34853
+	tagISOSpeedRatings        = 34855
+	tagExifVersion            = 36864
+	tagDateTimeOriginal       = 36867
+	tagFocalLength            = 37386
+	tagFocalLengthIn35mmFilm  = 41989
+	tagBodySerialNumber       = 42033
+	tagLensSpecification      = 42034
+	tagLensMake               = 42035
+	tagLensModel              = 42036
+	tagLensSerialNumber       = 42037
+	tagDNGVersion             = 50706
+	tagDNGBackwardVersion     = 50707
+	tagUniqueCameraModel      = 50708
+	tagColorMatrix1           = 50721
+	tagAsShotNeutral          = 50728
+	tagCalibrationIlluminant1 = 50778
+
+	tagGPSVersionID    = 0
+	tagGPSLatitudeRef  = 1
+	tagGPSLatitude     = 2
+	tagGPSLongitudeRef = 3
+	tagGPSLongitude    = 4
+	tagGPSAltitudeRef  = 5
+	tagGPSAltitude     = 6
+)
+
+const (
+	compressionNone      = 1
+	compressionJPEG      = 7
+	photometricRGB       = 2
+	photometricYCbCr     = 6
+	photometricLinearRaw = 34892
+	illuminantD65        = 21
+	illuminantD50        = 23
+	exifDateTimeFormat   = "2006:01:02 15:04:05"
+)
+
+// xyzToRGB are the XYZ to linear RGB matrices of the output colour spaces,
+// written as the DNG ColorMatrix1 together with their reference illuminant
+var xyzToRGB = map[ColorSpace]struct {
+	matrix     [9]float64
+	illuminant uint16
+}{
+	ColorSpaceSRGB: {[9]float64{
+		3.2404542, -1.5371385, -0.4985314,
+		-0.9692660, 1.8760108, 0.0415560,
+		0.0556434, -0.2040259, 1.0572252,
+	}, illuminantD65},
+	ColorSpaceAdobeRGB: {[9]float64{
+		2.0413690, -0.5649464, -0.3446944,
+		-0.9692660, 1.8760108, 0.0415560,
+		0.0134474, -0.1183897, 1.0154096,
+	}, illuminantD65},
+	ColorSpaceProPhoto: {[9]float64{
+		1.3459433, -0.2556075, -0.0511118,
+		-0.5445989, 1.5081673, 0.0205351,
+		0.0000000, 0.0000000, 1.2118128,
+	}, illuminantD50},
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Export develops the RAW data to 16 bits per sample and writes it to w as a
+// TIFF or linear DNG file, for archiving proprietary RAW formats in a
+// vendor-neutral container. The camera, exposure, lens and GPS fields are
+// written as EXIF, the XMP packet is carried over and the camera's JPEG
+// preview can be embedded. The samples are always demosaiced RGB; setting
+// Develop.HalfSize bins each 2x2 block of photosites into one RGB pixel at
+// half resolution, and exporting the undemosaiced CFA data is not supported.
+// A nil opts exports a TIFF with the as-shot white balance in sRGB.
+func (r *RAW) Export(w io.Writer, opts *ExportOptions) error {
+	if opts == nil {
+		opts = &ExportOptions{}
+	}
+
+	// Check the options
+	develop := opts.Develop
+	develop.Bits = 16
+	if err := develop.validate(); err != nil {
+		return err
+	}
+	switch opts.Format {
+	case ExportTIFF, ExportDNG:
+	default:
+		return media.ErrBadParameter.Withf("unsupported export format %d", opts.Format)
+	}
+
+	// Develop, with a linear transfer curve and no auto-brightening when
+	// requested, since a DNG reader applies its own tone curve. These are
+	// set again by the next develop
+	develop.apply(r.data)
+	if opts.Linear || opts.Format == ExportDNG {
+		libraw.Libraw_set_gamma(r.data, 0, 1)
+		libraw.Libraw_set_gamma(r.data, 1, 1)
+		libraw.Libraw_set_no_auto_bright(r.data, 1)
+	}
+	img, err := r.process()
+	if err != nil {
+		return err
+	}
+	defer libraw.Libraw_dcraw_clear_mem(img)
+
+	if libraw.ProcessedImage_type(img) != libraw.IMAGE_BITMAP || libraw.ProcessedImage_colors(img) != 3 || libraw.ProcessedImage_bits(img) != 16 {
+		return media.ErrInternalError.With("unexpected developed image layout")
+	}
+	width, height := int(libraw.ProcessedImage_width(img)), int(libraw.ProcessedImage_height(img))
+	pixels := libraw.ProcessedImage_data(img)
+
+	// Main image
+	ifd0 := r.exportIFD(opts, develop.ColorSpace)
+	ifd0.longs(tagNewSubfileType, 0)
+	ifd0.longs(tagImageWidth, uint32(width))
+	ifd0.longs(tagImageLength, uint32(height))
+	ifd0.shorts(tagBitsPerSample, 16, 16, 16)
+	ifd0.shorts(tagCompression, compressionNone)
+	if opts.Format == ExportDNG {
+		ifd0.shorts(tagPhotometric, photometricLinearRaw)
+	} else {
+		ifd0.shorts(tagPhotometric, photometricRGB)
+	}
+	ifd0.longs(tagStripOffsets, 0)
+	ifd0.shorts(tagOrientation, 1)
+	ifd0.shorts(tagSamplesPerPixel, 3)
+	ifd0.longs(tagRowsPerStrip, uint32(height))
+	ifd0.longs(tagStripByteCounts, uint32(len(pixels)))
+	ifd0.shorts(tagPlanarConfiguration, 1)
+
+	// EXIF and GPS directories
+	exif := r.exifIFD()
+	gps := r.gpsIFD()
+	ifd0.longs(tagExifIFD, 0)
+	if gps != nil {
+		ifd0.longs(tagGPSIFD, 0)
+	}
+
+	// Preview subimage, which is only embedded when it's a JPEG
+	var preview tiffIFD
+	var previewData []byte
+	if opts.Preview {
+		if data, format, _, _, err := r.thumbnailRaw(); err == nil && format == libraw.THUMBNAIL_JPEG {
+			if config, _, err := image.DecodeConfig(bytes.NewReader(data)); err == nil {
+				previewData = data
+				preview.longs(tagNewSubfileType, 1)
+				preview.longs(tagImageWidth, uint32(config.Width))
+				preview.longs(tagImageLength, uint32(config.Height))
+				preview.shorts(tagBitsPerSample, 8, 8, 8)
+				preview.shorts(tagCompression, compressionJPEG)
+				preview.shorts(tagPhotometric, photometricYCbCr)
+				preview.longs(tagStripOffsets, 0)
+				preview.shorts(tagSamplesPerPixel, 3)
+				preview.longs(tagRowsPerStrip, uint32(config.Height))
+				preview.longs(tagStripByteCounts, uint32(len(data)))
+				preview.shorts(tagPlanarConfiguration, 1)
+				ifd0.longs(tagSubIFDs, 0)
+			}
+		}
+	}
+
+	// Lay out the file: header, directories, preview, then the main image
+	offset := uint64(tiffHeaderSize)
+	ifd0Offset := offset
+	offset += uint64(ifd0.size())
+	exifOffset := offset
+	offset += uint64(exif.size())
+	gpsOffset := offset
+	offset += uint64(gps.size())
+	previewOffset := offset
+	offset += uint64(preview.size())
+	previewDataOffset := offset
+	offset += uint64(len(previewData)+1) &^ 1
+	pixelOffset := offset
+	if pixelOffset+uint64(len(pixels)) > math.MaxUint32 {
+		return media.ErrBadParameter.With("exported image exceeds the 4GB TIFF limit")
+	}
+
+	ifd0.set(tagStripOffsets, uint32(pixelOffset))
+	ifd0.set(tagExifIFD, uint32(exifOffset))
+	ifd0.set(tagGPSIFD, uint32(gpsOffset))
+	ifd0.set(tagSubIFDs, uint32(previewOffset))
+	preview.set(tagStripOffsets, uint32(previewDataOffset))
+
+	// Write the file. Libraw's samples are little-endian, matching the header
+	header := []byte{'I', 'I', 42, 0, byte(ifd0Offset), 0, 0, 0}
+	for _, data := range [][]byte{
+		header,
+		ifd0.encode(uint32(ifd0Offset)),
+		exif.encode(uint32(exifOffset)),
+		gps.encode(uint32(gpsOffset)),
+		preview.encode(uint32(previewOffset)),
+		previewData,
+		make([]byte, len(previewData)&1),
+		pixels,
+	} {
+		if len(data) == 0 {
+			continue
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+
+	// Return success
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE
+
+// exportIFD returns the descriptive tags of the main image, including the
+// DNG colour tags when exporting a DNG
+func (r *RAW) exportIFD(opts *ExportOptions, colorSpace ColorSpace) tiffIFD {
+	other := libraw.Libraw_get_imgother(r.data)
+
+	var ifd tiffIFD
+	ifd.ascii(tagImageDescription, strings.TrimSpace(libraw.ImgOther_desc(other)))
+	ifd.ascii(tagMake, strings.TrimSpace(r.Make()))
+	ifd.ascii(tagModel, strings.TrimSpace(r.Model()))
+	ifd.ascii(tagSoftware, strings.TrimSpace(r.Software()))
+	if ts := libraw.ImgOther_timestamp(other); ts > 0 {
+		ifd.ascii(tagDateTime, wallClock(ts).Format(exifDateTimeFormat))
+	}
+	ifd.ascii(tagArtist, strings.TrimSpace(libraw.ImgOther_artist(other)))
+	if opts.XMP != nil {
+		ifd.bytes(tagXMP, tiffByte, opts.XMP)
+	} else {
+		ifd.bytes(tagXMP, tiffByte, r.XMP())
+	}
+
+	if opts.Format == ExportDNG {
+		// The samples are already white balanced into the output colour
+		// space, so that space is the "camera" space and neutral is 1,1,1
+		ifd.bytes(tagDNGVersion, tiffByte, []byte{1, 4, 0, 0})
+		ifd.bytes(tagDNGBackwardVersion, tiffByte, []byte{1, 1, 0, 0})
+		ifd.ascii(tagUniqueCameraModel, strings.TrimSpace(r.Make()+" "+r.Model()))
+		ifd.srationals(tagColorMatrix1, xyzToRGB[colorSpace].matrix[:]...)
+		ifd.rationals(tagAsShotNeutral, 1, 1, 1)
+		ifd.shorts(tagCalibrationIlluminant1, xyzToRGB[colorSpace].illuminant)
+	}
+
+	return ifd
+}
+
+// exifIFD returns the exposure, lens and serial number tags
+func (r *RAW) exifIFD() tiffIFD {
+	other := libraw.Libraw_get_imgother(r.data)
+
+	var ifd tiffIFD
+	ifd.bytes(tagExifVersion, tiffUndefined, []byte("0230"))
+	if s := r.Shutter(); s > 0 {
+		ifd.rationals(tagExposureTime, float64(s))
+	}
+	if ap := r.Aperture(); ap > 0 {
+		ifd.rationals(tagFNumber, float64(ap))
+	}
+	if iso := r.ISOSpeed(); iso > 0 {
+		ifd.shorts(tagISOSpeedRatings, uint16(math.Min(float64(iso), math.MaxUint16)))
+	}
+	if ts := libraw.ImgOther_timestamp(other); ts > 0 {
+		ifd.ascii(tagDateTimeOriginal, wallClock(ts).Format(exifDateTimeFormat))
+	}
+	if fl := r.FocalLength(); fl > 0 {
+		ifd.rationals(tagFocalLength, float64(fl))
+	}
+	if fl := libraw.LensInfo_focal_length_in_35mm_format(libraw.Libraw_get_lensinfo(r.data)); fl > 0 {
+		ifd.shorts(tagFocalLengthIn35mmFilm, uint16(fl))
+	}
+	ifd.ascii(tagBodySerialNumber, r.SerialNumber())
+	if minFocal, maxFocal := r.LensFocalRange(); minFocal > 0 && maxFocal > 0 {
+		minAp, maxAp := r.LensApertureRange()
+		ifd.rationals(tagLensSpecification, float64(minFocal), float64(maxFocal), float64(minAp), float64(maxAp))
+	}
+	ifd.ascii(tagLensMake, r.LensMake())
+	ifd.ascii(tagLensModel, r.LensModel())
+	ifd.ascii(tagLensSerialNumber, r.LensSerialNumber())
+	return ifd
+}
+
+// gpsIFD returns the GPS position tags, or nil if there is no position
+func (r *RAW) gpsIFD() tiffIFD {
+	gps, ok := libraw.ImgOther_parsed_gps(libraw.Libraw_get_imgother(r.data))
+	if !ok {
+		return nil
+	}
+
+	var ifd tiffIFD
+	ifd.bytes(tagGPSVersionID, tiffByte, []byte{2, 3, 0, 0})
+	if gps.LatitudeRef != 0 {
+		ifd.ascii(tagGPSLatitudeRef, string(rune(gps.LatitudeRef)))
+	}
+	ifd.rationals(tagGPSLatitude, float64(gps.Latitude[0]), float64(gps.Latitude[1]), float64(gps.Latitude[2]))
+	if gps.LongitudeRef != 0 {
+		ifd.ascii(tagGPSLongitudeRef, string(rune(gps.LongitudeRef)))
+	}
+	ifd.rationals(tagGPSLongitude, float64(gps.Longitude[0]), float64(gps.Longitude[1]), float64(gps.Longitude[2]))
+	if gps.Altitude != 0 {
+		ifd.bytes(tagGPSAltitudeRef, tiffByte, []byte{gps.AltitudeRef})
+		ifd.rationals(tagGPSAltitude, float64(gps.Altitude))
+	}
+	return ifd
+}
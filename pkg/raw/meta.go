@@ -72,14 +72,7 @@ func newMetadata(r *RAW) []media.Metadata {
 		add("exif:FocalLength", fmt.Sprintf("%.0fmm", fl), fl)
 	}
 	if ts := libraw.ImgOther_timestamp(other); ts > 0 {
-		// libraw derives timestamp via mktime() on the EXIF wall-clock
-		// string (which carries no timezone), so the epoch is only
-		// meaningful when re-localized on the same host that parsed it.
-		// Recover the wall-clock fields via time.Local (the inverse of
-		// libraw's mktime) and relabel them as UTC, rather than
-		// converting, so the result doesn't depend on the host timezone.
-		local := time.Unix(ts, 0)
-		t := time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), local.Minute(), local.Second(), 0, time.UTC)
+		t := wallClock(ts)
 		add("exif:DateTimeOriginal", t.Format(time.RFC3339), t)
 	}
 	if desc := strings.TrimSpace(libraw.ImgOther_desc(other)); desc != "" {
@@ -89,12 +82,102 @@ func newMetadata(r *RAW) []media.Metadata {
 		add("dc:creator", artist, artist)
 	}
 
+	// Camera body
+	if serial := r.SerialNumber(); serial != "" {
+		add("exif:BodySerialNumber", serial, serial)
+	}
+	if order := r.ShotOrder(); order > 0 {
+		add("aux:ImageNumber", fmt.Sprint(order), order)
+	}
+
+	// Lens
+	if lensMake := r.LensMake(); lensMake != "" {
+		add("exif:LensMake", lensMake, lensMake)
+	}
+	if model := r.LensModel(); model != "" {
+		add("exif:LensModel", model, model)
+	}
+	if serial := r.LensSerialNumber(); serial != "" {
+		add("exif:LensSerialNumber", serial, serial)
+	}
+	minFocal, maxFocal := r.LensFocalRange()
+	minAp, maxAp := r.LensApertureRange()
+	if minFocal > 0 && maxFocal > 0 {
+		add("exif:LensSpecification", lensStr(minFocal, maxFocal, minAp, maxAp), []float32{minFocal, maxFocal, minAp, maxAp})
+	}
+	if fl := libraw.LensInfo_focal_length_in_35mm_format(libraw.Libraw_get_lensinfo(r.data)); fl > 0 {
+		add("exif:FocalLengthIn35mmFilm", fmt.Sprintf("%dmm", fl), fl)
+	}
+
+	// Position, as signed decimal degrees to match the EXIF reader
+	if lat, lon, alt, ok := r.GPS(); ok {
+		add("exif:GPSLatitude", fmt.Sprintf("%.6f", lat), lat)
+		add("exif:GPSLongitude", fmt.Sprintf("%.6f", lon), lon)
+		if alt != 0 {
+			add("exif:GPSAltitude", fmt.Sprintf("%.1f", alt), alt)
+		}
+	}
+
+	// Colour calibration
+	if mul := r.CameraMultipliers(); mul[0] > 0 {
+		add("raw:CameraMultipliers", floatsStr(mul[:]), mul)
+	}
+	if mul := r.PreMultipliers(); mul[0] > 0 {
+		add("raw:PreMultipliers", floatsStr(mul[:]), mul)
+	}
+	if matrix := r.ColorMatrix(); matrix[0][0] != 0 {
+		rows := make([]string, len(matrix))
+		for i := range matrix {
+			rows[i] = floatsStr(matrix[i][:3])
+		}
+		add("raw:ColorMatrix", strings.Join(rows, "; "), matrix)
+	}
+
 	return result
 }
 
+// wallClock returns the capture time as wall-clock fields labelled UTC.
+// libraw derives the timestamp via mktime() on the EXIF wall-clock string
+// (which carries no timezone), so the epoch is only meaningful when
+// re-localized on the same host that parsed it. Recover the wall-clock
+// fields via time.Local (the inverse of libraw's mktime) and relabel them
+// as UTC, rather than converting, so the result doesn't depend on the host
+// timezone.
+func wallClock(ts int64) time.Time {
+	local := time.Unix(ts, 0)
+	return time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), local.Minute(), local.Second(), 0, time.UTC)
+}
+
 func shutterStr(s float32) string {
 	if s >= 1 {
 		return fmt.Sprintf("%.1fs", s)
 	}
 	return fmt.Sprintf("1/%.0f", 1.0/s)
 }
+
+// lensStr formats a lens specification as "12-60mm f/2.8-4.0", collapsing
+// the ranges for prime and constant-aperture lenses.
+func lensStr(minFocal, maxFocal, minAp, maxAp float32) string {
+	var str string
+	if minFocal == maxFocal {
+		str = fmt.Sprintf("%.0fmm", minFocal)
+	} else {
+		str = fmt.Sprintf("%.0f-%.0fmm", minFocal, maxFocal)
+	}
+	switch {
+	case minAp <= 0:
+		return str
+	case maxAp <= 0 || minAp == maxAp:
+		return str + fmt.Sprintf(" f/%.1f", minAp)
+	default:
+		return str + fmt.Sprintf(" f/%.1f-%.1f", minAp, maxAp)
+	}
+}
+
+func floatsStr(values []float32) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = fmt.Sprintf("%.4g", v)
+	}
+	return strings.Join(parts, ",")
+}
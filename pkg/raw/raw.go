@@ -8,6 +8,7 @@ import (
 	_ "image/jpeg"
 	"io"
 	"os"
+	"strings"
 	"time"
 
 	// Packages
@@ -141,6 +142,110 @@ func (r *RAW) RawHeight() int {
 	return libraw.Libraw_get_raw_height(r.data)
 }
 
+// SerialNumber returns the camera body serial number.
+func (r *RAW) SerialNumber() string {
+	info := libraw.Libraw_get_shootinginfo(r.data)
+	if serial := strings.TrimSpace(libraw.ShootingInfo_body_serial(info)); serial != "" {
+		return serial
+	}
+	return strings.TrimSpace(libraw.ShootingInfo_internal_body_serial(info))
+}
+
+// ShotOrder returns the camera's frame counter for the shot, or zero.
+func (r *RAW) ShotOrder() uint {
+	return libraw.ImgOther_shot_order(libraw.Libraw_get_imgother(r.data))
+}
+
+// LensMake returns the lens manufacturer.
+func (r *RAW) LensMake() string {
+	return strings.TrimSpace(libraw.LensInfo_lens_make(libraw.Libraw_get_lensinfo(r.data)))
+}
+
+// LensModel returns the lens model, falling back to the maker notes.
+func (r *RAW) LensModel() string {
+	lens := libraw.Libraw_get_lensinfo(r.data)
+	if model := strings.TrimSpace(libraw.LensInfo_lens(lens)); model != "" {
+		return model
+	}
+	return strings.TrimSpace(libraw.LensInfo_makernotes_lens(lens))
+}
+
+// LensSerialNumber returns the lens serial number.
+func (r *RAW) LensSerialNumber() string {
+	lens := libraw.Libraw_get_lensinfo(r.data)
+	if serial := strings.TrimSpace(libraw.LensInfo_lens_serial(lens)); serial != "" {
+		return serial
+	}
+	return strings.TrimSpace(libraw.LensInfo_internal_lens_serial(lens))
+}
+
+// LensFocalRange returns the minimum and maximum focal length of the lens
+// in mm, which are equal for a prime lens.
+func (r *RAW) LensFocalRange() (float32, float32) {
+	lens := libraw.Libraw_get_lensinfo(r.data)
+	return libraw.LensInfo_min_focal(lens), libraw.LensInfo_max_focal(lens)
+}
+
+// LensApertureRange returns the maximum aperture (smallest f-number) of the
+// lens at its minimum and maximum focal lengths.
+func (r *RAW) LensApertureRange() (float32, float32) {
+	lens := libraw.Libraw_get_lensinfo(r.data)
+	return libraw.LensInfo_max_ap4_min_focal(lens), libraw.LensInfo_max_ap4_max_focal(lens)
+}
+
+// GPS returns the latitude and longitude in signed decimal degrees and the
+// altitude in metres, or false if the file carries no GPS position.
+func (r *RAW) GPS() (lat, lon, alt float64, ok bool) {
+	gps, ok := libraw.ImgOther_parsed_gps(libraw.Libraw_get_imgother(r.data))
+	if !ok {
+		return 0, 0, 0, false
+	}
+	lat = dmsToDegrees(gps.Latitude)
+	if gps.LatitudeRef == 'S' {
+		lat = -lat
+	}
+	lon = dmsToDegrees(gps.Longitude)
+	if gps.LongitudeRef == 'W' {
+		lon = -lon
+	}
+	alt = float64(gps.Altitude)
+	if gps.AltitudeRef == 1 {
+		alt = -alt
+	}
+	return lat, lon, alt, true
+}
+
+// CameraMultipliers returns the as-shot white balance multipliers (cam_mul)
+// for R, G, B and G2.
+func (r *RAW) CameraMultipliers() [4]float32 {
+	var result [4]float32
+	for i := range result {
+		result[i] = libraw.Libraw_get_cam_mul(r.data, i)
+	}
+	return result
+}
+
+// PreMultipliers returns the daylight white balance multipliers (pre_mul)
+// for R, G, B and G2.
+func (r *RAW) PreMultipliers() [4]float32 {
+	var result [4]float32
+	for i := range result {
+		result[i] = libraw.Libraw_get_pre_mul(r.data, i)
+	}
+	return result
+}
+
+// ColorMatrix returns the camera to sRGB colour matrix (rgb_cam).
+func (r *RAW) ColorMatrix() [3][4]float32 {
+	var result [3][4]float32
+	for i := range result {
+		for j := range result[i] {
+			result[i][j] = libraw.Libraw_get_rgb_cam(r.data, i, j)
+		}
+	}
+	return result
+}
+
 // XMP returns the raw XMP packet bytes embedded in the file, or nil if absent.
 func (r *RAW) XMP() []byte {
 	return libraw.IParams_xmpdata(libraw.Libraw_get_iparams(r.data))
@@ -206,18 +311,27 @@ func (r *RAW) thumbnailRaw() ([]byte, libraw.ThumbnailFormat, int, int, error) {
 ////////////////////////////////////////////////////////////////////////////////
 // IMAGE
 
-// Image demosaics and returns the full-resolution image. This is a slow
-// operation; use Thumbnail for quick previews.
+// Image demosaics and returns the full-resolution image with the libraw
+// defaults. This is a slow operation; use Thumbnail for quick previews.
 func (r *RAW) Image() (image.Image, error) {
-	if rc := libraw.Libraw_unpack(r.data); rc != 0 {
-		return nil, media.ErrInternalError.With(libraw.Libraw_strerror(rc))
-	}
-	if rc := libraw.Libraw_dcraw_process(r.data); rc != 0 {
-		return nil, media.ErrInternalError.With(libraw.Libraw_strerror(rc))
+	return r.Develop(nil)
+}
+
+// Develop demosaics and returns the image, with the white balance, exposure,
+// highlight recovery, colour space, bit depth and size taken from opts. A nil
+// opts develops with the libraw defaults, as Image does.
+func (r *RAW) Develop(opts *DevelopOptions) (image.Image, error) {
+	if opts != nil {
+		if err := opts.validate(); err != nil {
+			return nil, err
+		}
+		opts.apply(r.data)
+	} else {
+		resetDevelop(r.data)
 	}
-	img, rc := libraw.Libraw_dcraw_make_mem_image(r.data)
-	if img == nil || rc != 0 {
-		return nil, media.ErrInternalError.With(libraw.Libraw_strerror(rc))
+	img, err := r.process()
+	if err != nil {
+		return nil, err
 	}
 	defer libraw.Libraw_dcraw_clear_mem(img)
 
@@ -240,6 +354,27 @@ func (r *RAW) Image() (image.Image, error) {
 ////////////////////////////////////////////////////////////////////////////////
 // PRIVATE
 
+// process unpacks and develops the RAW data with the current output
+// parameters. The caller must release the image with Libraw_dcraw_clear_mem.
+func (r *RAW) process() (*libraw.ProcessedImage, error) {
+	if rc := libraw.Libraw_unpack(r.data); rc != 0 {
+		return nil, media.ErrInternalError.With(libraw.Libraw_strerror(rc))
+	}
+	if rc := libraw.Libraw_dcraw_process(r.data); rc != 0 {
+		return nil, media.ErrInternalError.With(libraw.Libraw_strerror(rc))
+	}
+	img, rc := libraw.Libraw_dcraw_make_mem_image(r.data)
+	if img == nil || rc != 0 {
+		return nil, media.ErrInternalError.With(libraw.Libraw_strerror(rc))
+	}
+	return img, nil
+}
+
+// dmsToDegrees converts degrees, minutes and seconds to decimal degrees.
+func dmsToDegrees(dms [3]float32) float64 {
+	return float64(dms[0]) + float64(dms[1])/60 + float64(dms[2])/3600
+}
+
 // bitmapToImage converts libraw's interleaved RGB bitmap to image.Image.
 // Libraw stores 16-bit values in little-endian host byte order.
 func bitmapToImage(data []byte, width, height, colors, bits int) image.Image {
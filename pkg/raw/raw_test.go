@@ -1,11 +1,14 @@
 package raw_test
 
 import (
+	"bytes"
 	"encoding/json"
+	"image"
 	"os"
 	"testing"
 
 	"github.com/mutablelogic/go-media/pkg/raw"
+	"golang.org/x/image/tiff"
 )
 
 const testRAW = "../../etc/test/RAW_OLYMPUS_E3.ORF"
@@ -148,6 +151,54 @@ func Test_raw_022(t *testing.T) {
 	}
 }
 
+func Test_raw_023(t *testing.T) {
+	r, err := raw.Open(testRAW)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	t.Logf("body serial=%q shot order=%d", r.SerialNumber(), r.ShotOrder())
+	t.Logf("lens make=%q model=%q serial=%q", r.LensMake(), r.LensModel(), r.LensSerialNumber())
+	minFocal, maxFocal := r.LensFocalRange()
+	if minFocal > maxFocal {
+		t.Errorf("expected min focal %v <= max focal %v", minFocal, maxFocal)
+	}
+	if lat, lon, alt, ok := r.GPS(); ok {
+		t.Logf("gps=%v,%v alt=%v", lat, lon, alt)
+		if lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+			t.Errorf("gps position out of range: %v,%v", lat, lon)
+		}
+	}
+
+	mul := r.CameraMultipliers()
+	if mul[0] <= 0 || mul[1] <= 0 || mul[2] <= 0 {
+		t.Errorf("expected positive camera multipliers, got %v", mul)
+	}
+	t.Logf("pre_mul=%v rgb_cam=%v", r.PreMultipliers(), r.ColorMatrix())
+}
+
+func Test_raw_024(t *testing.T) {
+	r, err := raw.Open(testRAW)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	keys := make(map[string]string)
+	for _, m := range r.Metadata() {
+		keys[m.Key()] = m.Value()
+	}
+	for _, key := range []string{"raw:CameraMultipliers", "raw:ColorMatrix"} {
+		if _, ok := keys[key]; !ok {
+			t.Errorf("expected metadata key %q", key)
+		}
+	}
+	if model := r.LensModel(); model != "" && keys["exif:LensModel"] != model {
+		t.Errorf("expected exif:LensModel=%q, got %q", model, keys["exif:LensModel"])
+	}
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 // THUMBNAIL
 
@@ -206,3 +257,152 @@ func Test_raw_040(t *testing.T) {
 		t.Error("expected non-zero image dimensions")
 	}
 }
+
+func Test_raw_041(t *testing.T) {
+	r, err := raw.Open(testRAW)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	img, err := r.Develop(&raw.DevelopOptions{
+		WhiteBalance: raw.WhiteBalanceAuto,
+		Exposure:     0.5,
+		Highlight:    raw.HighlightBlend,
+		ColorSpace:   raw.ColorSpaceAdobeRGB,
+		Bits:         16,
+		HalfSize:     true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := img.(*image.NRGBA64); !ok {
+		t.Errorf("expected *image.NRGBA64 for 16-bit output, got %T", img)
+	}
+	bounds := img.Bounds()
+	t.Logf("half-size image size=%dx%d", bounds.Dx(), bounds.Dy())
+	if bounds.Dx() == 0 || bounds.Dx() > r.Width()/2+1 {
+		t.Errorf("unexpected half-size width %d (full width %d)", bounds.Dx(), r.Width())
+	}
+}
+
+func Test_raw_042(t *testing.T) {
+	r, err := raw.Open(testRAW)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	if _, err := r.Develop(&raw.DevelopOptions{WhiteBalance: raw.WhiteBalanceCustom}); err == nil {
+		t.Error("expected error for custom white balance without multipliers")
+	}
+	if _, err := r.Develop(&raw.DevelopOptions{Exposure: 5}); err == nil {
+		t.Error("expected error for exposure out of range")
+	}
+	if _, err := r.Develop(&raw.DevelopOptions{Bits: 12}); err == nil {
+		t.Error("expected error for unsupported bits per sample")
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// EXPORT
+
+func Test_raw_050(t *testing.T) {
+	r, err := raw.Open(testRAW)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	var buf bytes.Buffer
+	if err := r.Export(&buf, &raw.ExportOptions{
+		Format:  raw.ExportTIFF,
+		Develop: raw.DevelopOptions{HalfSize: true},
+		Preview: true,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("tiff bytes=%d", buf.Len())
+
+	img, err := tiff.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := img.(*image.RGBA64); !ok {
+		t.Errorf("expected *image.RGBA64 for 16-bit TIFF, got %T", img)
+	}
+	if bounds := img.Bounds(); bounds.Dx() == 0 || bounds.Dy() == 0 {
+		t.Error("expected non-zero image dimensions")
+	}
+}
+
+func Test_raw_051(t *testing.T) {
+	r, err := raw.Open(testRAW)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	var buf bytes.Buffer
+	if err := r.Export(&buf, &raw.ExportOptions{
+		Format:  raw.ExportDNG,
+		Develop: raw.DevelopOptions{HalfSize: true},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte("II*\x00")) {
+		t.Error("expected little-endian TIFF header")
+	}
+	if !bytes.Contains(buf.Bytes(), []byte(r.Model())) {
+		t.Error("expected camera model in DNG")
+	}
+}
+
+func Test_raw_052(t *testing.T) {
+	r, err := raw.Open(testRAW)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	var buf bytes.Buffer
+	if err := r.Export(&buf, &raw.ExportOptions{Format: 99}); err == nil {
+		t.Error("expected error for unsupported export format")
+	}
+	if err := r.Export(&buf, &raw.ExportOptions{Develop: raw.DevelopOptions{Exposure: -5}}); err == nil {
+		t.Error("expected error for exposure out of range")
+	}
+	if buf.Len() != 0 {
+		t.Error("expected nothing written on error")
+	}
+}
+
+func Test_raw_053(t *testing.T) {
+	r, err := raw.Open(testRAW)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	// A linear export does not change the next develop
+	opts := &raw.DevelopOptions{HalfSize: true}
+	before, err := r.Develop(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Export(&bytes.Buffer{}, &raw.ExportOptions{Format: raw.ExportDNG, Develop: *opts}); err != nil {
+		t.Fatal(err)
+	}
+	after, err := r.Develop(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, aok := before.(*image.NRGBA)
+	b, bok := after.(*image.NRGBA)
+	if !aok || !bok {
+		t.Fatalf("expected *image.NRGBA, got %T and %T", before, after)
+	}
+	if !bytes.Equal(a.Pix, b.Pix) {
+		t.Error("expected the same image before and after a linear export")
+	}
+}
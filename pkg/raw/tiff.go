@@ -0,0 +1,177 @@
+package raw
+
+import (
+	"encoding/binary"
+	"math"
+	"sort"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// tiffEntry is a single little-endian TIFF directory entry. Values of up to
+// four bytes are stored inline, larger values in the IFD's data area.
+type tiffEntry struct {
+	tag   uint16
+	typ   uint16
+	count uint32
+	data  []byte
+}
+
+// tiffIFD is an image file directory under construction.
+type tiffIFD []tiffEntry
+
+////////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+// TIFF field types
+const (
+	tiffByte      = 1
+	tiffASCII     = 2
+	tiffShort     = 3
+	tiffLong      = 4
+	tiffRational  = 5
+	tiffUndefined = 7
+	tiffSRational = 10
+)
+
+// tiffHeaderSize is the size of the "II*\0" header and first IFD offset
+const tiffHeaderSize = 8
+
+// rationalDenominator is the largest denominator used to encode rationals,
+// giving six decimal places
+const rationalDenominator = 1000000
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+func (ifd *tiffIFD) add(tag, typ uint16, count int, data []byte) {
+	*ifd = append(*ifd, tiffEntry{tag: tag, typ: typ, count: uint32(count), data: data})
+}
+
+func (ifd *tiffIFD) ascii(tag uint16, value string) {
+	if value == "" {
+		return
+	}
+	data := append([]byte(value), 0)
+	ifd.add(tag, tiffASCII, len(data), data)
+}
+
+func (ifd *tiffIFD) bytes(tag, typ uint16, data []byte) {
+	if len(data) == 0 {
+		return
+	}
+	ifd.add(tag, typ, len(data), data)
+}
+
+func (ifd *tiffIFD) shorts(tag uint16, values ...uint16) {
+	data := make([]byte, 2*len(values))
+	for i, v := range values {
+		binary.LittleEndian.PutUint16(data[2*i:], v)
+	}
+	ifd.add(tag, tiffShort, len(values), data)
+}
+
+func (ifd *tiffIFD) longs(tag uint16, values ...uint32) {
+	data := make([]byte, 4*len(values))
+	for i, v := range values {
+		binary.LittleEndian.PutUint32(data[4*i:], v)
+	}
+	ifd.add(tag, tiffLong, len(values), data)
+}
+
+func (ifd *tiffIFD) rationals(tag uint16, values ...float64) {
+	data := make([]byte, 8*len(values))
+	for i, v := range values {
+		num, den := toRational(math.Max(v, 0), math.MaxUint32)
+		binary.LittleEndian.PutUint32(data[8*i:], uint32(num))
+		binary.LittleEndian.PutUint32(data[8*i+4:], uint32(den))
+	}
+	ifd.add(tag, tiffRational, len(values), data)
+}
+
+func (ifd *tiffIFD) srationals(tag uint16, values ...float64) {
+	data := make([]byte, 8*len(values))
+	for i, v := range values {
+		num, den := toRational(v, math.MaxInt32)
+		binary.LittleEndian.PutUint32(data[8*i:], uint32(int32(num)))
+		binary.LittleEndian.PutUint32(data[8*i+4:], uint32(den))
+	}
+	ifd.add(tag, tiffSRational, len(values), data)
+}
+
+// set replaces the value of a single LONG entry, which is used to patch in
+// offsets once the file layout is known
+func (ifd tiffIFD) set(tag uint16, value uint32) {
+	for i := range ifd {
+		if ifd[i].tag == tag {
+			binary.LittleEndian.PutUint32(ifd[i].data, value)
+		}
+	}
+}
+
+// size returns the encoded size of the directory including its data area,
+// or zero for an empty directory which is not written
+func (ifd tiffIFD) size() uint32 {
+	if len(ifd) == 0 {
+		return 0
+	}
+	size := uint32(2 + 12*len(ifd) + 4)
+	for _, entry := range ifd {
+		if len(entry.data) > 4 {
+			size += uint32(len(entry.data)+1) &^ 1
+		}
+	}
+	return size
+}
+
+// encode returns the directory, sorted by tag, for writing at offset. The
+// next IFD offset is always zero.
+func (ifd tiffIFD) encode(offset uint32) []byte {
+	if len(ifd) == 0 {
+		return nil
+	}
+	sort.SliceStable(ifd, func(i, j int) bool { return ifd[i].tag < ifd[j].tag })
+
+	buf := make([]byte, ifd.size())
+	binary.LittleEndian.PutUint16(buf, uint16(len(ifd)))
+	data := uint32(2 + 12*len(ifd) + 4)
+	for i, entry := range ifd {
+		e := buf[2+12*i:]
+		binary.LittleEndian.PutUint16(e[0:], entry.tag)
+		binary.LittleEndian.PutUint16(e[2:], entry.typ)
+		binary.LittleEndian.PutUint32(e[4:], entry.count)
+		if len(entry.data) <= 4 {
+			copy(e[8:12], entry.data)
+			continue
+		}
+		binary.LittleEndian.PutUint32(e[8:], offset+data)
+		copy(buf[data:], entry.data)
+		data += uint32(len(entry.data)+1) &^ 1
+	}
+	return buf
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE
+
+// toRational approximates v as num/den with |num| <= limit, reduced to
+// lowest terms
+func toRational(v float64, limit float64) (int64, int64) {
+	den := int64(rationalDenominator)
+	for den > 1 && math.Abs(v)*float64(den) > limit {
+		den /= 10
+	}
+	num := int64(math.Round(math.Max(-limit, math.Min(limit, v*float64(den)))))
+	if num == 0 {
+		return 0, 1
+	}
+	a, b := num, den
+	if a < 0 {
+		a = -a
+	}
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return num / a, den / a
+}
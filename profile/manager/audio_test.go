@@ -8,7 +8,7 @@ import (
 	// Packages
 	uuid "github.com/google/uuid"
 	test "github.com/mutablelogic/go-media/profile/test"
-	ff "github.com/mutablelogic/go-media/sys/ffmpeg80"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
 	pg "github.com/mutablelogic/go-pg"
 	require "github.com/stretchr/testify/require"
 )
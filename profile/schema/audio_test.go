@@ -5,7 +5,7 @@ import (
 
 	// Packages
 	schema "github.com/mutablelogic/go-media/profile/schema"
-	ff "github.com/mutablelogic/go-media/sys/ffmpeg80"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
 )
 
 func TestNewAudioProfile_Print(t *testing.T) {
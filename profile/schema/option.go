@@ -5,7 +5,7 @@ import (
 	"math"
 
 	// Packages
-	ff "github.com/mutablelogic/go-media/sys/ffmpeg80"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
 	types "github.com/mutablelogic/go-server/pkg/types"
 )
 
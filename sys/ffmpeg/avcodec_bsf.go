@@ -0,0 +1,212 @@
+package ffmpeg
+
+import (
+	"encoding/json"
+	"io"
+	"syscall"
+	"unsafe"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// CGO
+
+/*
+#cgo pkg-config: libavcodec
+#include <libavcodec/bsf.h>
+#include <stdlib.h>
+*/
+import "C"
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type (
+	AVBitStreamFilter C.struct_AVBitStreamFilter
+	AVBSFContext      C.struct_AVBSFContext
+)
+
+type jsonAVBitStreamFilter struct {
+	Name     string      `json:"name"`
+	CodecIDs []AVCodecID `json:"codec_ids,omitempty"`
+}
+
+type jsonAVBSFContext struct {
+	Filter      *AVBitStreamFilter `json:"filter"`
+	TimeBaseIn  AVRational         `json:"time_base_in"`
+	TimeBaseOut AVRational         `json:"time_base_out"`
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (f *AVBitStreamFilter) MarshalJSON() ([]byte, error) {
+	if f == nil {
+		return json.Marshal(nil)
+	}
+	return json.Marshal(jsonAVBitStreamFilter{
+		Name:     f.Name(),
+		CodecIDs: f.CodecIDs(),
+	})
+}
+
+func (ctx *AVBSFContext) MarshalJSON() ([]byte, error) {
+	if ctx == nil {
+		return json.Marshal(nil)
+	}
+	return json.Marshal(jsonAVBSFContext{
+		Filter:      ctx.Filter(),
+		TimeBaseIn:  ctx.TimeBaseIn(),
+		TimeBaseOut: ctx.TimeBaseOut(),
+	})
+}
+
+func (f *AVBitStreamFilter) String() string {
+	return marshalToString(f)
+}
+
+func (ctx *AVBSFContext) String() string {
+	return marshalToString(ctx)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// BINDINGS - FILTERS
+
+// Return a bitstream filter by name, or nil if it does not exist
+func AVCodec_bsf_get_by_name(name string) *AVBitStreamFilter {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	return (*AVBitStreamFilter)(C.av_bsf_get_by_name(cName))
+}
+
+// Iterate over all registered bitstream filters
+func AVCodec_bsf_iterate(opaque *uintptr) *AVBitStreamFilter {
+	return (*AVBitStreamFilter)(C.av_bsf_iterate((*unsafe.Pointer)(unsafe.Pointer(opaque))))
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// BINDINGS - CONTEXT
+
+// Allocate a context for a bitstream filter. The caller should set the input
+// parameters and timebase, then call AVCodec_bsf_init
+func AVCodec_bsf_alloc(filter *AVBitStreamFilter) (*AVBSFContext, error) {
+	var ctx *C.struct_AVBSFContext
+	if err := AVError(C.av_bsf_alloc((*C.struct_AVBitStreamFilter)(filter), &ctx)); err < 0 {
+		return nil, err
+	}
+	return (*AVBSFContext)(ctx), nil
+}
+
+// Parse a comma-separated chain of filters with options, such as
+// "h264_mp4toannexb,dump_extra=freq=keyframe", into a single context. An empty
+// string returns a filter which passes packets through unchanged.
+func AVCodec_bsf_list_parse_str(str string) (*AVBSFContext, error) {
+	cStr := C.CString(str)
+	defer C.free(unsafe.Pointer(cStr))
+	var ctx *C.struct_AVBSFContext
+	if err := AVError(C.av_bsf_list_parse_str(cStr, &ctx)); err < 0 {
+		return nil, err
+	}
+	return (*AVBSFContext)(ctx), nil
+}
+
+// Prepare the filter for use, after the input parameters have been set
+func AVCodec_bsf_init(ctx *AVBSFContext) error {
+	if err := AVError(C.av_bsf_init((*C.struct_AVBSFContext)(ctx))); err < 0 {
+		return err
+	}
+	return nil
+}
+
+// Free a bitstream filter context
+func AVCodec_bsf_free(ctx *AVBSFContext) {
+	C.av_bsf_free((**C.struct_AVBSFContext)(unsafe.Pointer(&ctx)))
+}
+
+// Reset the filter state, for example after seeking
+func AVCodec_bsf_flush(ctx *AVBSFContext) {
+	C.av_bsf_flush((*C.struct_AVBSFContext)(ctx))
+}
+
+// Submit a packet for filtering. The filter takes ownership of the packet
+// data, leaving pkt blank. A nil packet signals the end of the stream.
+func AVCodec_bsf_send_packet(ctx *AVBSFContext, pkt *AVPacket) error {
+	if err := AVError(C.av_bsf_send_packet((*C.struct_AVBSFContext)(ctx), (*C.struct_AVPacket)(pkt))); err < 0 {
+		if err.IsErrno(syscall.EAGAIN) {
+			return syscall.EAGAIN
+		} else if err == AVERROR_EOF {
+			return io.EOF
+		}
+		return err
+	}
+	return nil
+}
+
+// Retrieve a filtered packet. Returns syscall.EAGAIN when more input is
+// needed, or io.EOF when the filter has been flushed.
+func AVCodec_bsf_receive_packet(ctx *AVBSFContext, pkt *AVPacket) error {
+	if err := AVError(C.av_bsf_receive_packet((*C.struct_AVBSFContext)(ctx), (*C.struct_AVPacket)(pkt))); err < 0 {
+		if err.IsErrno(syscall.EAGAIN) {
+			return syscall.EAGAIN
+		} else if err == AVERROR_EOF {
+			return io.EOF
+		}
+		return err
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PROPERTIES - FILTER
+
+func (f *AVBitStreamFilter) Name() string {
+	return C.GoString(f.name)
+}
+
+// Return the codecs the filter supports, or nil if it supports any codec
+func (f *AVBitStreamFilter) CodecIDs() []AVCodecID {
+	if f.codec_ids == nil {
+		return nil
+	}
+	var result []AVCodecID
+	for ptr := unsafe.Pointer(f.codec_ids); ; ptr = unsafe.Add(ptr, unsafe.Sizeof(*f.codec_ids)) {
+		id := AVCodecID(*(*C.enum_AVCodecID)(ptr))
+		if id == AV_CODEC_ID_NONE {
+			break
+		}
+		result = append(result, id)
+	}
+	return result
+}
+
+func (f *AVBitStreamFilter) PrivClass() *AVClass {
+	return (*AVClass)(f.priv_class)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PROPERTIES - CONTEXT
+
+func (ctx *AVBSFContext) Filter() *AVBitStreamFilter {
+	return (*AVBitStreamFilter)(ctx.filter)
+}
+
+// Parameters of the input stream, which are set before AVCodec_bsf_init
+func (ctx *AVBSFContext) ParIn() *AVCodecParameters {
+	return (*AVCodecParameters)(ctx.par_in)
+}
+
+// Parameters of the output stream, which are set by AVCodec_bsf_init
+func (ctx *AVBSFContext) ParOut() *AVCodecParameters {
+	return (*AVCodecParameters)(ctx.par_out)
+}
+
+func (ctx *AVBSFContext) TimeBaseIn() AVRational {
+	return AVRational(ctx.time_base_in)
+}
+
+func (ctx *AVBSFContext) SetTimeBaseIn(tb AVRational) {
+	ctx.time_base_in = C.struct_AVRational(tb)
+}
+
+func (ctx *AVBSFContext) TimeBaseOut() AVRational {
+	return AVRational(ctx.time_base_out)
+}
@@ -0,0 +1,96 @@
+package ffmpeg
+
+import (
+	"errors"
+	"io"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_avcodec_bsf_iterate_001(t *testing.T) {
+	assert := assert.New(t)
+
+	var opaque uintptr
+	names := make(map[string]bool)
+	for {
+		filter := AVCodec_bsf_iterate(&opaque)
+		if filter == nil {
+			break
+		}
+		names[filter.Name()] = true
+	}
+	t.Logf("Found %d bitstream filters", len(names))
+	for _, name := range []string{"h264_mp4toannexb", "hevc_mp4toannexb", "aac_adtstoasc", "extract_extradata", "dump_extra", "setts", "null"} {
+		assert.True(names[name], name)
+	}
+}
+
+func Test_avcodec_bsf_get_by_name_001(t *testing.T) {
+	assert := assert.New(t)
+
+	filter := AVCodec_bsf_get_by_name("h264_mp4toannexb")
+	if !assert.NotNil(filter) {
+		t.FailNow()
+	}
+	assert.Equal("h264_mp4toannexb", filter.Name())
+	assert.Equal([]AVCodecID{AV_CODEC_ID_H264}, filter.CodecIDs())
+	t.Log(filter)
+
+	// Filters for any codec have no codec list
+	assert.Nil(AVCodec_bsf_get_by_name("null").CodecIDs())
+	assert.Nil(AVCodec_bsf_get_by_name("not_a_filter"))
+}
+
+func Test_avcodec_bsf_alloc_001(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, err := AVCodec_bsf_alloc(AVCodec_bsf_get_by_name("null"))
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer AVCodec_bsf_free(ctx)
+
+	ctx.ParIn().SetCodecType(AVMEDIA_TYPE_VIDEO)
+	ctx.ParIn().SetCodecID(AV_CODEC_ID_MJPEG)
+	ctx.SetTimeBaseIn(AVUtil_rational(1, 25))
+	if !assert.NoError(AVCodec_bsf_init(ctx)) {
+		t.FailNow()
+	}
+	assert.Equal(AV_CODEC_ID_MJPEG, ctx.ParOut().CodecID())
+	assert.Equal(AVUtil_rational(1, 25), ctx.TimeBaseOut())
+	t.Log(ctx)
+
+	// Packets pass through unchanged
+	in := AVCodec_packet_alloc()
+	defer AVCodec_packet_free(in)
+	assert.NoError(AVCodec_packet_from_data(in, []byte{1, 2, 3, 4}))
+	in.SetPts(10)
+	assert.NoError(AVCodec_bsf_send_packet(ctx, in))
+
+	out := AVCodec_packet_alloc()
+	defer AVCodec_packet_free(out)
+	assert.NoError(AVCodec_bsf_receive_packet(ctx, out))
+	assert.Equal([]byte{1, 2, 3, 4}, out.Bytes())
+	assert.Equal(int64(10), out.Pts())
+	AVCodec_packet_unref(out)
+	assert.True(errors.Is(AVCodec_bsf_receive_packet(ctx, out), syscall.EAGAIN))
+
+	// Flush
+	assert.NoError(AVCodec_bsf_send_packet(ctx, nil))
+	assert.True(errors.Is(AVCodec_bsf_receive_packet(ctx, out), io.EOF))
+}
+
+func Test_avcodec_bsf_list_parse_str_001(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, err := AVCodec_bsf_list_parse_str("setts=ts=PTS-STARTPTS,dump_extra")
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	AVCodec_bsf_free(ctx)
+
+	_, err = AVCodec_bsf_list_parse_str("not_a_filter")
+	assert.Error(err)
+}
@@ -13,6 +13,7 @@ import (
 #cgo pkg-config: libavcodec libavutil
 #include <libavcodec/avcodec.h>
 #include <libavutil/opt.h>
+#include <libavutil/mem.h>
 */
 import "C"
 
@@ -37,6 +38,8 @@ const (
 	AV_CODEC_ID_NONE       AVCodecID = C.AV_CODEC_ID_NONE
 	AV_CODEC_ID_MP2        AVCodecID = C.AV_CODEC_ID_MP2
 	AV_CODEC_ID_H264       AVCodecID = C.AV_CODEC_ID_H264
+	AV_CODEC_ID_HEVC       AVCodecID = C.AV_CODEC_ID_HEVC
+	AV_CODEC_ID_AAC        AVCodecID = C.AV_CODEC_ID_AAC
 	AV_CODEC_ID_MPEG1VIDEO AVCodecID = C.AV_CODEC_ID_MPEG1VIDEO
 	AV_CODEC_ID_MPEG2VIDEO AVCodecID = C.AV_CODEC_ID_MPEG2VIDEO
 	AV_CODEC_ID_MJPEG      AVCodecID = C.AV_CODEC_ID_MJPEG
@@ -82,6 +85,11 @@ const (
 	AV_INPUT_BUFFER_PADDING_SIZE int = C.AV_INPUT_BUFFER_PADDING_SIZE
 )
 
+const (
+	// Scale factor between a quantizer and the lambda used by global_quality
+	FF_QP2LAMBDA int = C.FF_QP2LAMBDA
+)
+
 const (
 	AV_CODEC_FLAG_UNALIGNED      AVCodecFlag  = C.AV_CODEC_FLAG_UNALIGNED
 	AV_CODEC_FLAG_QSCALE         AVCodecFlag  = C.AV_CODEC_FLAG_QSCALE
@@ -470,6 +478,16 @@ func (ctx *AVCodecContext) SetTimeBase(time_base AVRational) {
 	ctx.time_base = C.struct_AVRational(time_base)
 }
 
+// Timebase of the packets sent to a decoder, which is used to set the
+// presentation time of decoded subtitles
+func (ctx *AVCodecContext) PktTimeBase() AVRational {
+	return (AVRational)(ctx.pkt_timebase)
+}
+
+func (ctx *AVCodecContext) SetPktTimeBase(time_base AVRational) {
+	ctx.pkt_timebase = C.struct_AVRational(time_base)
+}
+
 func (ctx *AVCodecContext) SampleFormat() AVSampleFormat {
 	return AVSampleFormat(ctx.sample_fmt)
 }
@@ -526,6 +544,96 @@ func (ctx *AVCodecContext) SetMaxBFrames(max_b_frames int) {
 	ctx.max_b_frames = C.int(max_b_frames)
 }
 
+func (ctx *AVCodecContext) Profile() int {
+	return int(ctx.profile)
+}
+
+func (ctx *AVCodecContext) SetProfile(profile int) {
+	ctx.profile = C.int(profile)
+}
+
+func (ctx *AVCodecContext) Level() int {
+	return int(ctx.level)
+}
+
+func (ctx *AVCodecContext) SetLevel(level int) {
+	ctx.level = C.int(level)
+}
+
+func (ctx *AVCodecContext) GlobalQuality() int {
+	return int(ctx.global_quality)
+}
+
+func (ctx *AVCodecContext) SetGlobalQuality(quality int) {
+	ctx.global_quality = C.int(quality)
+}
+
+func (ctx *AVCodecContext) RcMaxRate() int64 {
+	return int64(ctx.rc_max_rate)
+}
+
+func (ctx *AVCodecContext) SetRcMaxRate(rate int64) {
+	ctx.rc_max_rate = C.int64_t(rate)
+}
+
+func (ctx *AVCodecContext) RcBufferSize() int {
+	return int(ctx.rc_buffer_size)
+}
+
+func (ctx *AVCodecContext) SetRcBufferSize(size int) {
+	ctx.rc_buffer_size = C.int(size)
+}
+
+func (ctx *AVCodecContext) ThreadCount() int {
+	return int(ctx.thread_count)
+}
+
+func (ctx *AVCodecContext) SetThreadCount(count int) {
+	ctx.thread_count = C.int(count)
+}
+
+// StatsOut returns the first pass statistics from the encoder, which are
+// updated after each packet and at the end of encoding
+func (ctx *AVCodecContext) StatsOut() string {
+	if ctx.stats_out == nil {
+		return ""
+	}
+	return C.GoString(ctx.stats_out)
+}
+
+// SetStatsIn sets the first pass statistics for a second pass encoder. The
+// previous value is freed, and an empty string releases the statistics.
+func (ctx *AVCodecContext) SetStatsIn(stats string) {
+	C.av_freep(unsafe.Pointer(&ctx.stats_in))
+	if stats != "" {
+		cStats := C.CString(stats)
+		defer C.free(unsafe.Pointer(cStats))
+		ctx.stats_in = C.av_strdup(cStats)
+	}
+}
+
+// SubtitleHeader returns the ASS header of a subtitle codec, with the
+// script info and styles. Decoders set it, and text subtitle encoders need it.
+func (ctx *AVCodecContext) SubtitleHeader() string {
+	if ctx.subtitle_header == nil || ctx.subtitle_header_size <= 0 {
+		return ""
+	}
+	return C.GoStringN((*C.char)(unsafe.Pointer(ctx.subtitle_header)), ctx.subtitle_header_size)
+}
+
+// SetSubtitleHeader sets the ASS header of a subtitle encoder, before it is
+// opened. The previous value is freed.
+func (ctx *AVCodecContext) SetSubtitleHeader(header string) {
+	C.av_freep(unsafe.Pointer(&ctx.subtitle_header))
+	ctx.subtitle_header_size = 0
+	if header != "" {
+		cHeader := C.CString(header)
+		defer C.free(unsafe.Pointer(cHeader))
+		ctx.subtitle_header = (*C.uint8_t)(unsafe.Pointer(C.av_strdup(cHeader)))
+		ctx.subtitle_header_size = C.int(len(header))
+	}
+}
+
 func (ctx *AVCodecContext) PixFmt() AVPixelFormat {
 	return AVPixelFormat(ctx.pix_fmt)
 }
@@ -241,6 +241,28 @@ func Test_avcodec_context_video_properties(t *testing.T) {
 	ctx.SetMaxBFrames(2)
 	assert.Equal(2, ctx.MaxBFrames())
 
+	ctx.SetProfile(100)
+	assert.Equal(100, ctx.Profile())
+
+	ctx.SetLevel(41)
+	assert.Equal(41, ctx.Level())
+
+	ctx.SetGlobalQuality(3 * FF_QP2LAMBDA)
+	assert.Equal(3*FF_QP2LAMBDA, ctx.GlobalQuality())
+
+	ctx.SetRcMaxRate(8000000)
+	assert.Equal(int64(8000000), ctx.RcMaxRate())
+
+	ctx.SetRcBufferSize(16000000)
+	assert.Equal(16000000, ctx.RcBufferSize())
+
+	ctx.SetThreadCount(4)
+	assert.Equal(4, ctx.ThreadCount())
+
+	assert.Equal("", ctx.StatsOut())
+	ctx.SetStatsIn("stats")
+	ctx.SetStatsIn("")
+
 	framerate := AVUtil_rational(30, 1)
 	ctx.SetFramerate(framerate)
 	retrievedFr := ctx.Framerate()
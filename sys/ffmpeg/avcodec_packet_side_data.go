@@ -0,0 +1,164 @@
+package ffmpeg
+
+import (
+	"encoding/json"
+	"errors"
+	"unsafe"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// CGO
+
+/*
+#cgo pkg-config: libavcodec
+#include <libavcodec/packet.h>
+#include <libavcodec/codec_par.h>
+#include <string.h>
+*/
+import "C"
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type (
+	AVPacketSideData     C.struct_AVPacketSideData
+	AVPacketSideDataType C.enum_AVPacketSideDataType
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// CONSTANTS
+
+const (
+	AV_PKT_DATA_PALETTE                    AVPacketSideDataType = C.AV_PKT_DATA_PALETTE
+	AV_PKT_DATA_NEW_EXTRADATA              AVPacketSideDataType = C.AV_PKT_DATA_NEW_EXTRADATA
+	AV_PKT_DATA_PARAM_CHANGE               AVPacketSideDataType = C.AV_PKT_DATA_PARAM_CHANGE
+	AV_PKT_DATA_REPLAYGAIN                 AVPacketSideDataType = C.AV_PKT_DATA_REPLAYGAIN    // AVReplayGain
+	AV_PKT_DATA_DISPLAYMATRIX              AVPacketSideDataType = C.AV_PKT_DATA_DISPLAYMATRIX // 3x3 display matrix
+	AV_PKT_DATA_STEREO3D                   AVPacketSideDataType = C.AV_PKT_DATA_STEREO3D      // AVStereo3D
+	AV_PKT_DATA_AUDIO_SERVICE_TYPE         AVPacketSideDataType = C.AV_PKT_DATA_AUDIO_SERVICE_TYPE
+	AV_PKT_DATA_CPB_PROPERTIES             AVPacketSideDataType = C.AV_PKT_DATA_CPB_PROPERTIES
+	AV_PKT_DATA_SKIP_SAMPLES               AVPacketSideDataType = C.AV_PKT_DATA_SKIP_SAMPLES // Samples to skip at the start and end
+	AV_PKT_DATA_STRINGS_METADATA           AVPacketSideDataType = C.AV_PKT_DATA_STRINGS_METADATA
+	AV_PKT_DATA_METADATA_UPDATE            AVPacketSideDataType = C.AV_PKT_DATA_METADATA_UPDATE
+	AV_PKT_DATA_MASTERING_DISPLAY_METADATA AVPacketSideDataType = C.AV_PKT_DATA_MASTERING_DISPLAY_METADATA // AVMasteringDisplayMetadata
+	AV_PKT_DATA_SPHERICAL                  AVPacketSideDataType = C.AV_PKT_DATA_SPHERICAL                  // AVSphericalMapping
+	AV_PKT_DATA_CONTENT_LIGHT_LEVEL        AVPacketSideDataType = C.AV_PKT_DATA_CONTENT_LIGHT_LEVEL        // AVContentLightMetadata
+	AV_PKT_DATA_A53_CC                     AVPacketSideDataType = C.AV_PKT_DATA_A53_CC                     // ATSC A53 closed captions
+	AV_PKT_DATA_ICC_PROFILE                AVPacketSideDataType = C.AV_PKT_DATA_ICC_PROFILE
+	AV_PKT_DATA_DOVI_CONF                  AVPacketSideDataType = C.AV_PKT_DATA_DOVI_CONF
+	AV_PKT_DATA_S12M_TIMECODE              AVPacketSideDataType = C.AV_PKT_DATA_S12M_TIMECODE
+	AV_PKT_DATA_DYNAMIC_HDR10_PLUS         AVPacketSideDataType = C.AV_PKT_DATA_DYNAMIC_HDR10_PLUS // HDR10+ in ITU-T T.35 form
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (t AVPacketSideDataType) String() string {
+	if name := C.av_packet_side_data_name(C.enum_AVPacketSideDataType(t)); name != nil {
+		return C.GoString(name)
+	}
+	return "unknown"
+}
+
+func (t AVPacketSideDataType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+func (sd *AVPacketSideData) MarshalJSON() ([]byte, error) {
+	type jsonAVPacketSideData struct {
+		Type AVPacketSideDataType `json:"type"`
+		Size int                  `json:"size"`
+	}
+	if sd == nil {
+		return json.Marshal(nil)
+	}
+	return json.Marshal(jsonAVPacketSideData{
+		Type: sd.Type(),
+		Size: int(sd.size),
+	})
+}
+
+func (sd *AVPacketSideData) String() string {
+	return marshalToString(sd)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Return the side data of the given type from a packet, or nil if the packet
+// does not have side data of that type. The data is owned by the packet.
+func AVCodec_packet_get_side_data(pkt *AVPacket, t AVPacketSideDataType) []byte {
+	var size C.size_t
+	data := C.av_packet_get_side_data((*C.struct_AVPacket)(pkt), C.enum_AVPacketSideDataType(t), &size)
+	if data == nil {
+		return nil
+	}
+	return unsafe.Slice((*byte)(unsafe.Pointer(data)), int(size))
+}
+
+// Add side data of the given type to a packet, replacing any side data of
+// the same type, and copy the data into it
+func AVCodec_packet_add_side_data(pkt *AVPacket, t AVPacketSideDataType, data []byte) error {
+	ptr := C.av_packet_new_side_data((*C.struct_AVPacket)(pkt), C.enum_AVPacketSideDataType(t), C.size_t(len(data)))
+	if ptr == nil {
+		return errors.New("failed to allocate packet side data")
+	}
+	if len(data) > 0 {
+		C.memcpy(unsafe.Pointer(ptr), unsafe.Pointer(&data[0]), C.size_t(len(data)))
+	}
+	return nil
+}
+
+// Return the side data entry of the given type from a list, or nil
+func AVCodec_packet_side_data_get(sd []*AVPacketSideData, t AVPacketSideDataType) *AVPacketSideData {
+	for _, entry := range sd {
+		if entry.Type() == t {
+			return entry
+		}
+	}
+	return nil
+}
+
+// Return all side data attached to a packet
+func (ctx *AVPacket) SideData() []*AVPacketSideData {
+	return cAVPacketSideDataSlice(unsafe.Pointer(ctx.side_data), C.int(ctx.side_data_elems))
+}
+
+// Return the side data which applies to the whole stream, such as the
+// display matrix or mastering display metadata
+func (ctx *AVCodecParameters) CodedSideData() []*AVPacketSideData {
+	return cAVPacketSideDataSlice(unsafe.Pointer(ctx.coded_side_data), C.int(ctx.nb_coded_side_data))
+}
+
+// Add stream side data of the given type, replacing any side data of the same
+// type, and copy the data into it
+func (ctx *AVCodecParameters) AddCodedSideData(t AVPacketSideDataType, data []byte) error {
+	sd := C.av_packet_side_data_new(&ctx.coded_side_data, &ctx.nb_coded_side_data, C.enum_AVPacketSideDataType(t), C.size_t(len(data)), 0)
+	if sd == nil {
+		return errors.New("failed to allocate stream side data")
+	}
+	if len(data) > 0 {
+		C.memcpy(unsafe.Pointer(sd.data), unsafe.Pointer(&data[0]), C.size_t(len(data)))
+	}
+	return nil
+}
+
+// Remove the stream side data of the given type
+func (ctx *AVCodecParameters) RemoveCodedSideData(t AVPacketSideDataType) {
+	C.av_packet_side_data_remove(ctx.coded_side_data, &ctx.nb_coded_side_data, C.enum_AVPacketSideDataType(t))
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PROPERTIES
+
+func (sd *AVPacketSideData) Type() AVPacketSideDataType {
+	return AVPacketSideDataType(sd._type)
+}
+
+// Return the side data, which is owned by the packet or parameters
+func (sd *AVPacketSideData) Data() []byte {
+	if sd.data == nil || sd.size == 0 {
+		return nil
+	}
+	return unsafe.Slice((*byte)(unsafe.Pointer(sd.data)), int(sd.size))
+}
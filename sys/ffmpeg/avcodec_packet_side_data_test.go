@@ -0,0 +1,102 @@
+package ffmpeg
+
+import (
+	"path/filepath"
+	"testing"
+
+	// Packages
+	"github.com/stretchr/testify/assert"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TESTS
+
+func Test_avcodec_packet_side_data_001(t *testing.T) {
+	assert := assert.New(t)
+
+	pkt := AVCodec_packet_alloc()
+	if !assert.NotNil(pkt) {
+		t.FailNow()
+	}
+	defer AVCodec_packet_free(pkt)
+
+	// No side data
+	assert.Empty(pkt.SideData())
+	assert.Nil(AVCodec_packet_get_side_data(pkt, AV_PKT_DATA_A53_CC))
+
+	// Add closed captions and a display matrix
+	assert.NoError(AVCodec_packet_add_side_data(pkt, AV_PKT_DATA_A53_CC, []byte{0xFC, 0x94, 0x20}))
+	var matrix AVDisplayMatrix
+	AVUtil_display_rotation_set(&matrix, 90)
+	assert.NoError(AVCodec_packet_add_side_data(pkt, AV_PKT_DATA_DISPLAYMATRIX, matrix.Bytes()))
+
+	assert.Equal([]byte{0xFC, 0x94, 0x20}, AVCodec_packet_get_side_data(pkt, AV_PKT_DATA_A53_CC))
+	sd := pkt.SideData()
+	if assert.Len(sd, 2) {
+		assert.Equal(AV_PKT_DATA_A53_CC, sd[0].Type())
+		assert.Equal(AV_PKT_DATA_DISPLAYMATRIX, sd[1].Type())
+		assert.Equal(sd[1], AVCodec_packet_side_data_get(sd, AV_PKT_DATA_DISPLAYMATRIX))
+		assert.Nil(AVCodec_packet_side_data_get(sd, AV_PKT_DATA_SPHERICAL))
+		t.Log(sd[0], sd[1])
+	}
+
+	// Side data is copied with the packet
+	clone := AVCodec_packet_clone(pkt)
+	defer AVCodec_packet_free(clone)
+	assert.Len(clone.SideData(), 2)
+	if m := AVUtil_display_matrix(AVCodec_packet_get_side_data(clone, AV_PKT_DATA_DISPLAYMATRIX)); assert.NotNil(m) {
+		assert.InDelta(90.0, AVUtil_display_rotation_get(m), 0.01)
+	}
+}
+
+func Test_avcodec_packet_side_data_002(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("Display Matrix", AV_PKT_DATA_DISPLAYMATRIX.String())
+	assert.Equal("ATSC A53 Part 4 Closed Captions", AV_PKT_DATA_A53_CC.String())
+}
+
+func Test_avcodec_packet_side_data_003(t *testing.T) {
+	assert := assert.New(t)
+
+	input, err := AVFormat_open_url(filepath.Join("..", "..", "etc", "test", "sample.mp4"), nil, nil)
+	if !assert.NoError(err) {
+		t.SkipNow()
+	}
+	defer AVFormat_close_input(input)
+
+	// Stream side data can be listed, but the sample may not have any
+	for _, stream := range input.Streams() {
+		for _, sd := range stream.CodecPar().CodedSideData() {
+			assert.NotEmpty(sd.Type().String())
+			t.Log(stream.Index(), sd)
+		}
+	}
+}
+
+func Test_avcodec_packet_side_data_004(t *testing.T) {
+	assert := assert.New(t)
+
+	par := AVCodec_parameters_alloc()
+	if !assert.NotNil(par) {
+		t.FailNow()
+	}
+	defer AVCodec_parameters_free(par)
+
+	// Add a display matrix to the stream, then replace it
+	matrix := new(AVDisplayMatrix)
+	AVUtil_display_rotation_set(matrix, 90)
+	assert.NoError(par.AddCodedSideData(AV_PKT_DATA_DISPLAYMATRIX, matrix.Bytes()))
+	AVUtil_display_rotation_set(matrix, -90)
+	assert.NoError(par.AddCodedSideData(AV_PKT_DATA_DISPLAYMATRIX, matrix.Bytes()))
+	if assert.Len(par.CodedSideData(), 1) {
+		sd := AVCodec_packet_side_data_get(par.CodedSideData(), AV_PKT_DATA_DISPLAYMATRIX)
+		if assert.NotNil(sd) {
+			assert.InDelta(-90.0, AVUtil_display_rotation_get(AVUtil_display_matrix(sd.Data())), 0.001)
+		}
+	}
+
+	// Remove it
+	par.RemoveCodedSideData(AV_PKT_DATA_DISPLAYMATRIX)
+	assert.Empty(par.CodedSideData())
+}
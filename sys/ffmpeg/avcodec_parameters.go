@@ -170,6 +170,14 @@ func (ctx *AVCodecParameters) SetChannelLayout(layout AVChannelLayout) error {
 	return nil
 }
 
+// Codec-specific data, such as the avcC record for H.264 in MP4
+func (ctx *AVCodecParameters) Extradata() []byte {
+	if ctx.extradata == nil || ctx.extradata_size <= 0 {
+		return nil
+	}
+	return C.GoBytes(unsafe.Pointer(ctx.extradata), ctx.extradata_size)
+}
+
 // Audio
 func (ctx *AVCodecParameters) FrameSize() int {
 	return int(ctx.frame_size)
@@ -165,6 +165,15 @@ func (rect *AVSubtitleRect) Data(plane int) []byte {
 	return unsafe.Slice((*byte)(rect.data[plane]), size)
 }
 
+// Get the palette for SUBTITLE_BITMAP type, which has NumColors colors as
+// 32-bit ARGB values, indexed by the bitmap in plane zero
+func (rect *AVSubtitleRect) Palette() []uint32 {
+	if rect.data[1] == nil || rect.nb_colors <= 0 || rect.nb_colors > 256 {
+		return nil
+	}
+	return unsafe.Slice((*uint32)(unsafe.Pointer(rect.data[1])), int(rect.nb_colors))
+}
+
 func (rect *AVSubtitleRect) Linesize(plane int) int {
 	if plane < 0 || plane >= 4 {
 		return 0
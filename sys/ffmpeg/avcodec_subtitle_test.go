@@ -3,7 +3,7 @@ package ffmpeg_test
 import (
 	"testing"
 
-	ff "github.com/mutablelogic/go-media/sys/ffmpeg80"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
 )
 
 func Test_subtitle_type_001(t *testing.T) {
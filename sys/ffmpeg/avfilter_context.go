@@ -60,6 +60,24 @@ func (c *AVFilterContext) NumOutputs() uint {
 	return uint(c.nb_outputs)
 }
 
+// Return the media type of an input pad, or AVMEDIA_TYPE_UNKNOWN if the pad
+// does not exist.
+func (c *AVFilterContext) InputType(pad uint) AVMediaType {
+	if pad >= c.NumInputs() {
+		return AVMEDIA_TYPE_UNKNOWN
+	}
+	return AVMediaType(C.avfilter_pad_get_type(c.input_pads, C.int(pad)))
+}
+
+// Return the media type of an output pad, or AVMEDIA_TYPE_UNKNOWN if the pad
+// does not exist.
+func (c *AVFilterContext) OutputType(pad uint) AVMediaType {
+	if pad >= c.NumOutputs() {
+		return AVMEDIA_TYPE_UNKNOWN
+	}
+	return AVMediaType(C.avfilter_pad_get_type(c.output_pads, C.int(pad)))
+}
+
 // Link two filters together.
 func AVFilterContext_link(src *AVFilterContext, srcpad uint, dst *AVFilterContext, dstpad uint) error {
 	if ret := C.avfilter_link(
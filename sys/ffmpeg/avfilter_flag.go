@@ -11,6 +11,11 @@ import (
 /*
 #cgo pkg-config: libavfilter
 #include <libavfilter/avfilter.h>
+
+// The hardware device flag is not in FFmpeg 6.1
+#ifndef AVFILTER_FLAG_HWDEVICE
+#define AVFILTER_FLAG_HWDEVICE (1 << 4)
+#endif
 */
 import "C"
 
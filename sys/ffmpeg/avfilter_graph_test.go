@@ -4,7 +4,7 @@ import (
 	"testing"
 
 	// Packages
-	ff "github.com/mutablelogic/go-media/sys/ffmpeg80"
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
 	assert "github.com/stretchr/testify/assert"
 )
 
@@ -153,3 +153,36 @@ func Test_avfilter_graph_007(t *testing.T) {
 	var graph *ff.AVFilterGraph
 	assert.Equal(uint(0), graph.NumFilters())
 }
+
+func Test_avfilter_graph_008(t *testing.T) {
+	assert := assert.New(t)
+	graph := ff.AVFilterGraph_alloc()
+	assert.NotNil(graph)
+	defer ff.AVFilterGraph_free(graph)
+
+	// Pad media types of open inputs and outputs
+	in, out, err := ff.AVFilterGraph_parse(graph, "[a][b]overlay[v];[c]anull[d]")
+	assert.NoError(err)
+	defer ff.AVFilterInOut_list_free(in)
+	defer ff.AVFilterInOut_list_free(out)
+
+	assert.Len(in, 3)
+	for _, pad := range in {
+		typ := pad.Filter().InputType(uint(pad.Pad()))
+		if pad.Name() == "c" {
+			assert.Equal(ff.AVMEDIA_TYPE_AUDIO, typ)
+		} else {
+			assert.Equal(ff.AVMEDIA_TYPE_VIDEO, typ)
+		}
+	}
+	assert.Len(out, 2)
+	for _, pad := range out {
+		typ := pad.Filter().OutputType(uint(pad.Pad()))
+		if pad.Name() == "d" {
+			assert.Equal(ff.AVMEDIA_TYPE_AUDIO, typ)
+		} else {
+			assert.Equal(ff.AVMEDIA_TYPE_VIDEO, typ)
+		}
+	}
+	assert.Equal(ff.AVMEDIA_TYPE_UNKNOWN, out[0].Filter().OutputType(99))
+}
@@ -13,7 +13,12 @@ import (
 #cgo pkg-config: libavformat libavutil
 #include <libavformat/avformat.h>
 
+// The buffer of the write callback is only const from FFmpeg 7.0
+#if LIBAVFORMAT_VERSION_MAJOR < 61
+typedef uint8_t const_char_t;
+#else
 typedef const uint8_t const_char_t;
+#endif
 extern int avio_read_callback(void* userInfo, uint8_t* buf, int buf_size);
 extern int avio_write_callback(void* userInfo, const_char_t* buf, int buf_size);
 extern int64_t avio_seek_callback(void* userInfo, int64_t offset, int whence);
@@ -92,6 +97,26 @@ func AVFormat_avio_open(url string, flags AVIOFlag) (*AVIOContextEx, error) {
 	return ctx, nil
 }
 
+// Create and initialize a AVIOContext for accessing the resource indicated by url,
+// with an interrupt callback which can abort blocking operations and protocol
+// options. The callback and options can be nil.
+func AVFormat_avio_open2(url string, flags AVIOFlag, cb *AVIOInterruptCB, options *AVDictionary) (*AVIOContextEx, error) {
+	var opts **C.struct_AVDictionary
+	if options != nil {
+		opts = &options.ctx
+	}
+
+	ctx := new(AVIOContextEx)
+	cUrl := C.CString(url)
+	defer C.free(unsafe.Pointer(cUrl))
+	if err := AVError(C.avio_open2((**C.struct_AVIOContext)(unsafe.Pointer(&ctx.AVIOContext)), cUrl, C.int(flags), (*C.AVIOInterruptCB)(cb), opts)); err != 0 {
+		return nil, err
+	}
+
+	// Return success
+	return ctx, nil
+}
+
 // Close the resource and free it.
 // This function can only be used if it was opened by avio_open().
 func AVFormat_avio_close(ctx *AVIOContextEx) error {
@@ -0,0 +1,129 @@
+package ffmpeg
+
+import (
+	"encoding/json"
+	"unsafe"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// CGO
+
+/*
+#cgo pkg-config: libavformat libavutil
+#include <libavformat/avformat.h>
+#include <libavutil/mem.h>
+
+// Append a chapter to a muxing context. avpriv_new_chapter is not public, so
+// the chapter is allocated here and freed with the context.
+static AVChapter* avformat_new_chapter(AVFormatContext* ctx, int64_t id, AVRational tb, int64_t start, int64_t end) {
+	AVChapter* ch = av_mallocz(sizeof(AVChapter));
+	if (ch == NULL) {
+		return NULL;
+	}
+	if (av_dynarray_add_nofree(&ctx->chapters, (int*)&ctx->nb_chapters, ch) < 0) {
+		av_free(ch);
+		return NULL;
+	}
+	ch->id = id;
+	ch->time_base = tb;
+	ch->start = start;
+	ch->end = end;
+	return ch;
+}
+*/
+import "C"
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type (
+	AVChapter C.struct_AVChapter
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (ch *AVChapter) MarshalJSON() ([]byte, error) {
+	type jsonAVChapter struct {
+		Id       int64         `json:"id"`
+		TimeBase AVRational    `json:"time_base"`
+		Start    AVTimestamp   `json:"start"`
+		End      AVTimestamp   `json:"end"`
+		Metadata *AVDictionary `json:"metadata,omitempty"`
+	}
+	if ch == nil {
+		return json.Marshal(nil)
+	}
+	return json.Marshal(jsonAVChapter{
+		Id:       ch.Id(),
+		TimeBase: ch.TimeBase(),
+		Start:    AVTimestamp(ch.Start()),
+		End:      AVTimestamp(ch.End()),
+		Metadata: ch.Metadata(),
+	})
+}
+
+func (ch *AVChapter) String() string {
+	return marshalToString(ch)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Add a chapter to an output context, before the header is written. The start
+// and end are in units of the timebase. Returns nil if the chapter could not
+// be allocated.
+func AVFormat_new_chapter(ctx *AVFormatContext, id int64, tb AVRational, start, end int64) *AVChapter {
+	return (*AVChapter)(C.avformat_new_chapter((*C.struct_AVFormatContext)(ctx), C.int64_t(id), C.struct_AVRational(tb), C.int64_t(start), C.int64_t(end)))
+}
+
+// Return the chapters of a format context, in the order they are stored
+func (ctx *AVFormatContext) Chapters() []*AVChapter {
+	return cAVChapterSlice(unsafe.Pointer(ctx.chapters), C.int(ctx.nb_chapters))
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PROPERTIES
+
+// Unique identifier of the chapter within the file
+func (ch *AVChapter) Id() int64 {
+	return int64(ch.id)
+}
+
+func (ch *AVChapter) TimeBase() AVRational {
+	return AVRational(ch.time_base)
+}
+
+// Start of the chapter in units of the timebase
+func (ch *AVChapter) Start() int64 {
+	return int64(ch.start)
+}
+
+// End of the chapter in units of the timebase
+func (ch *AVChapter) End() int64 {
+	return int64(ch.end)
+}
+
+// Set the start of the chapter in units of the timebase
+func (ch *AVChapter) SetStart(start int64) {
+	ch.start = C.int64_t(start)
+}
+
+// Set the end of the chapter in units of the timebase
+func (ch *AVChapter) SetEnd(end int64) {
+	ch.end = C.int64_t(end)
+}
+
+func (ch *AVChapter) Metadata() *AVDictionary {
+	return &AVDictionary{ch.metadata}
+}
+
+// Set the chapter metadata, such as the "title". The chapter takes ownership
+// of the dictionary.
+func (ch *AVChapter) SetMetadata(dict *AVDictionary) {
+	if dict == nil {
+		ch.metadata = nil
+	} else {
+		ch.metadata = dict.ctx
+	}
+}
@@ -0,0 +1,76 @@
+package ffmpeg
+
+import (
+	"path/filepath"
+	"testing"
+
+	// Packages
+	"github.com/stretchr/testify/assert"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TESTS
+
+func Test_avformat_chapter_001(t *testing.T) {
+	assert := assert.New(t)
+
+	// The ffmetadata format needs no streams
+	filename := filepath.Join(t.TempDir(), "chapters.txt")
+	output, err := AVFormat_create_file(filename, AVFormat_guess_format("ffmetadata", "", ""))
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+
+	// Add two chapters in milliseconds
+	tb := AVUtil_rational(1, 1000)
+	for i, title := range []string{"One", "Two"} {
+		ch := AVFormat_new_chapter(output, int64(i+1), tb, int64(i)*1000, int64(i+1)*1000)
+		if !assert.NotNil(ch) {
+			t.FailNow()
+		}
+		dict := AVUtil_dict_alloc()
+		assert.NoError(AVUtil_dict_set(dict, "title", title, 0))
+		ch.SetMetadata(dict)
+	}
+	assert.Equal(uint(2), output.NumChapters())
+	assert.Len(output.Chapters(), 2)
+
+	assert.NoError(AVFormat_write_header(output, nil))
+	assert.NoError(AVFormat_write_trailer(output))
+	assert.NoError(AVFormat_close_writer(output))
+
+	// Read the chapters back
+	input, err := AVFormat_open_url(filename, nil, nil)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer AVFormat_close_input(input)
+
+	chapters := input.Chapters()
+	if assert.Len(chapters, 2) {
+		for i, ch := range chapters {
+			assert.Equal(int64(i+1), ch.Id())
+			start := AVUtil_rational_rescale_q(ch.Start(), ch.TimeBase(), tb)
+			end := AVUtil_rational_rescale_q(ch.End(), ch.TimeBase(), tb)
+			assert.Equal(int64(i)*1000, start)
+			assert.Equal(int64(i+1)*1000, end)
+			t.Log(ch)
+		}
+		assert.Equal("One", AVUtil_dict_get(chapters[0].Metadata(), "title", nil, 0).Value())
+		assert.Equal("Two", AVUtil_dict_get(chapters[1].Metadata(), "title", nil, 0).Value())
+	}
+}
+
+func Test_avformat_chapter_002(t *testing.T) {
+	assert := assert.New(t)
+
+	// Files without chapters return an empty slice
+	input, err := AVFormat_open_url(filepath.Join("..", "..", "etc", "test", "sample.mp4"), nil, nil)
+	if !assert.NoError(err) {
+		t.SkipNow()
+	}
+	defer AVFormat_close_input(input)
+
+	assert.Equal(uint(0), input.NumChapters())
+	assert.Empty(input.Chapters())
+}
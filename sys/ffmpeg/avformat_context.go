@@ -28,6 +28,7 @@ func AVFormat_alloc_context() *AVFormatContext {
 
 // Free an AVFormatContext and all its streams.
 func AVFormat_free_context(ctx *AVFormatContext) {
+	removeInterruptCallback(ctx)
 	C.avformat_free_context((*C.struct_AVFormatContext)(ctx))
 }
 
@@ -67,6 +67,34 @@ func AVFormat_open_url(url string, format *AVInputFormat, options *AVDictionary)
 	return ctx, nil
 }
 
+// Open an input stream with a context allocated by AVFormat_alloc_context, so
+// that the interrupt callback or custom I/O can be set before opening. The url
+// may be empty when reading from custom I/O or a device. On failure, the
+// context is freed.
+func AVFormat_open_input(ctx *AVFormatContext, url string, format *AVInputFormat, options *AVDictionary) (*AVFormatContext, error) {
+	var opts **C.struct_AVDictionary
+	if options != nil {
+		opts = &options.ctx
+	}
+
+	// Create a C string for the URL
+	var cUrl *C.char
+	if url != "" {
+		cUrl = C.CString(url)
+		defer C.free(unsafe.Pointer(cUrl))
+	}
+
+	// Open the input, which frees the context on failure
+	ptr := ctx
+	if err := AVError(C.avformat_open_input((**C.struct_AVFormatContext)(unsafe.Pointer(&ctx)), cUrl, (*C.struct_AVInputFormat)(format), opts)); err != 0 {
+		removeInterruptCallback(ptr)
+		return nil, err
+	}
+
+	// Return success
+	return ctx, nil
+}
+
 // Open an input stream from a device.
 func AVFormat_open_device(format *AVInputFormat, options *AVDictionary) (*AVFormatContext, error) {
 	var opts **C.struct_AVDictionary
@@ -91,6 +119,7 @@ func AVFormat_open_device(format *AVInputFormat, options *AVDictionary) (*AVForm
 
 // Close an opened input AVFormatContext, free it and all its contents.
 func AVFormat_close_input(ctx *AVFormatContext) {
+	removeInterruptCallback(ctx)
 	C.avformat_close_input((**C.struct_AVFormatContext)(unsafe.Pointer(&ctx)))
 }
 
@@ -11,6 +11,11 @@ import (
 /*
 #cgo pkg-config: libavformat
 #include <libavformat/avformat.h>
+
+// The multilayer disposition is not in FFmpeg 6.1
+#ifndef AV_DISPOSITION_MULTILAYER
+#define AV_DISPOSITION_MULTILAYER (1 << 21)
+#endif
 */
 import "C"
 
@@ -0,0 +1,86 @@
+package ffmpeg
+
+import (
+	"sync"
+	"unsafe"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// CGO
+
+/*
+#cgo pkg-config: libavformat
+#include <libavformat/avformat.h>
+
+extern int avio_interrupt_callback(void* opaque);
+
+static void avformat_set_interrupt_callback(AVFormatContext* ctx, int enable) {
+	ctx->interrupt_callback.callback = enable ? avio_interrupt_callback : NULL;
+	ctx->interrupt_callback.opaque = enable ? ctx : NULL;
+}
+*/
+import "C"
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type (
+	AVIOInterruptCB C.AVIOInterruptCB
+)
+
+// AVIOInterruptFunc is called during blocking operations, and returns true
+// to abort the operation, which then fails with AVERROR_EXIT
+type AVIOInterruptFunc func() bool
+
+var (
+	interruptLock sync.RWMutex
+	interrupts    = make(map[uintptr]AVIOInterruptFunc)
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Set the function which is called during blocking operations on the context,
+// or nil to remove it. The function is removed when the context is freed.
+func (ctx *AVFormatContext) SetInterruptCallback(fn AVIOInterruptFunc) {
+	ptr := uintptr(unsafe.Pointer(ctx))
+	interruptLock.Lock()
+	defer interruptLock.Unlock()
+	if fn == nil {
+		delete(interrupts, ptr)
+	} else {
+		interrupts[ptr] = fn
+	}
+	C.avformat_set_interrupt_callback((*C.struct_AVFormatContext)(ctx), boolToInt(fn != nil))
+}
+
+// Return the interrupt callback of the context, for opening I/O with
+// AVFormat_avio_open2
+func (ctx *AVFormatContext) InterruptCallback() *AVIOInterruptCB {
+	return (*AVIOInterruptCB)(&ctx.interrupt_callback)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// Remove the interrupt function when a context is freed, without touching
+// the context itself
+func removeInterruptCallback(ctx *AVFormatContext) {
+	interruptLock.Lock()
+	defer interruptLock.Unlock()
+	delete(interrupts, uintptr(unsafe.Pointer(ctx)))
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// CALLBACKS
+
+//export avio_interrupt_callback
+func avio_interrupt_callback(opaque unsafe.Pointer) C.int {
+	interruptLock.RLock()
+	fn, ok := interrupts[uintptr(opaque)]
+	interruptLock.RUnlock()
+	if ok && fn() {
+		return 1
+	}
+	return 0
+}
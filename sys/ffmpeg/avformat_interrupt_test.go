@@ -0,0 +1,84 @@
+package ffmpeg
+
+import (
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_avformat_interrupt_001(t *testing.T) {
+	assert := assert.New(t)
+
+	testFile := filepath.Join("..", "..", "etc", "test", "sample.mp4")
+	if _, err := os.Stat(testFile); os.IsNotExist(err) {
+		t.Skip("Test file not available:", testFile)
+	}
+
+	// Open with a callback which does not interrupt
+	var calls atomic.Int32
+	ctx := AVFormat_alloc_context()
+	if !assert.NotNil(ctx) {
+		t.FailNow()
+	}
+	ctx.SetInterruptCallback(func() bool {
+		calls.Add(1)
+		return false
+	})
+	input, err := AVFormat_open_input(ctx, testFile, nil, nil)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer AVFormat_close_input(input)
+
+	assert.NotNil(input.InterruptCallback())
+	assert.NotZero(calls.Load())
+}
+
+func Test_avformat_interrupt_002(t *testing.T) {
+	assert := assert.New(t)
+
+	testFile := filepath.Join("..", "..", "etc", "test", "sample.mp4")
+	if _, err := os.Stat(testFile); os.IsNotExist(err) {
+		t.Skip("Test file not available:", testFile)
+	}
+
+	// Open with a callback which always interrupts
+	ctx := AVFormat_alloc_context()
+	if !assert.NotNil(ctx) {
+		t.FailNow()
+	}
+	ctx.SetInterruptCallback(func() bool {
+		return true
+	})
+	_, err := AVFormat_open_input(ctx, testFile, nil, nil)
+	assert.ErrorIs(err, AVError(AVERROR_EXIT))
+
+	// The callback is removed when the context is freed
+	interruptLock.RLock()
+	defer interruptLock.RUnlock()
+	assert.Empty(interrupts)
+}
+
+func Test_avformat_interrupt_003(t *testing.T) {
+	assert := assert.New(t)
+
+	// Open an output with the callback of the context
+	filename := filepath.Join(t.TempDir(), "out.mp4")
+	ctx, err := AVFormat_alloc_output_context(nil, filename)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer AVFormat_free_context(ctx)
+
+	ctx.SetInterruptCallback(func() bool {
+		return false
+	})
+	ioctx, err := AVFormat_avio_open2(filename, AVIO_FLAG_WRITE, ctx.InterruptCallback(), nil)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	assert.NoError(AVFormat_avio_close(ioctx))
+}
@@ -38,6 +38,23 @@ func AVFormat_open_writer(writer *AVIOContextEx, format *AVOutputFormat, filenam
 	return ctx, nil
 }
 
+// Allocate an output context without opening any I/O, so that the interrupt
+// callback can be set before opening the output with AVFormat_avio_open2.
+// The format parameter can be nil to guess the format from the filename.
+func AVFormat_alloc_output_context(format *AVOutputFormat, filename string) (*AVFormatContext, error) {
+	var ctx *AVFormatContext
+
+	cFilename := C.CString(filename)
+	defer C.free(unsafe.Pointer(cFilename))
+
+	// Allocate output context
+	if err := AVError(C.avformat_alloc_output_context2((**C.struct_AVFormatContext)(unsafe.Pointer(&ctx)), (*C.struct_AVOutputFormat)(format), nil, cFilename)); err != 0 {
+		return nil, err
+	}
+
+	return ctx, nil
+}
+
 // Create an output file with automatic I/O management.
 // Opens the file for writing and sets up the AVIOContext.
 // The format parameter can be nil to auto-detect from the filename.
@@ -86,6 +103,7 @@ func AVFormat_close_writer(ctx *AVFormatContext) error {
 	}
 
 	// Free the context
+	removeInterruptCallback(ctx)
 	C.avformat_free_context(octx)
 
 	return result
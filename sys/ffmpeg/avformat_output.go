@@ -116,6 +116,11 @@ func AVFormat_guess_format(format, filename, mimetype string) *AVOutputFormat {
 	return (*AVOutputFormat)(C.av_guess_format(cFormat, cFilename, cMimeType))
 }
 
+// Return true if the output format can store streams with the codec
+func AVFormat_query_codec(format *AVOutputFormat, codec_id AVCodecID) bool {
+	return C.avformat_query_codec((*C.struct_AVOutputFormat)(format), C.enum_AVCodecID(codec_id), C.FF_COMPLIANCE_NORMAL) == 1
+}
+
 // Write a packet to an output media file ensuring correct interleaving.
 // This function will buffer the packets internally as needed to make sure the
 // packets in the output file are properly interleaved, usually ordered by
@@ -0,0 +1,186 @@
+package ffmpeg
+
+import (
+	"encoding/json"
+	"unsafe"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// CGO
+
+/*
+#cgo pkg-config: libavformat
+#include <libavformat/avformat.h>
+*/
+import "C"
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type (
+	AVProgram C.struct_AVProgram
+	AVDiscard C.enum_AVDiscard
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// CONSTANTS
+
+const (
+	AVDISCARD_NONE     AVDiscard = C.AVDISCARD_NONE     // Discard nothing
+	AVDISCARD_DEFAULT  AVDiscard = C.AVDISCARD_DEFAULT  // Discard useless packets, such as zero size packets in AVI
+	AVDISCARD_NONREF   AVDiscard = C.AVDISCARD_NONREF   // Discard all non-reference frames
+	AVDISCARD_BIDIR    AVDiscard = C.AVDISCARD_BIDIR    // Discard all bidirectional frames
+	AVDISCARD_NONINTRA AVDiscard = C.AVDISCARD_NONINTRA // Discard all non-intra frames
+	AVDISCARD_NONKEY   AVDiscard = C.AVDISCARD_NONKEY   // Discard all frames except keyframes
+	AVDISCARD_ALL      AVDiscard = C.AVDISCARD_ALL      // Discard all frames
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (v AVDiscard) String() string {
+	switch v {
+	case AVDISCARD_NONE:
+		return "none"
+	case AVDISCARD_DEFAULT:
+		return "default"
+	case AVDISCARD_NONREF:
+		return "nonref"
+	case AVDISCARD_BIDIR:
+		return "bidir"
+	case AVDISCARD_NONINTRA:
+		return "nonintra"
+	case AVDISCARD_NONKEY:
+		return "nonkey"
+	case AVDISCARD_ALL:
+		return "all"
+	default:
+		return "unknown"
+	}
+}
+
+func (v AVDiscard) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.String())
+}
+
+func (p *AVProgram) MarshalJSON() ([]byte, error) {
+	type jsonAVProgram struct {
+		Id            int           `json:"id"`
+		ProgramNum    int           `json:"program_num,omitempty"`
+		PmtPid        int           `json:"pmt_pid,omitempty"`
+		PcrPid        int           `json:"pcr_pid,omitempty"`
+		StreamIndexes []int         `json:"stream_indexes,omitempty"`
+		Discard       AVDiscard     `json:"discard"`
+		StartTime     AVTimestamp   `json:"start_time"`
+		EndTime       AVTimestamp   `json:"end_time"`
+		Metadata      *AVDictionary `json:"metadata,omitempty"`
+	}
+	if p == nil {
+		return json.Marshal(nil)
+	}
+	return json.Marshal(jsonAVProgram{
+		Id:            p.Id(),
+		ProgramNum:    p.ProgramNum(),
+		PmtPid:        p.PmtPid(),
+		PcrPid:        p.PcrPid(),
+		StreamIndexes: p.StreamIndexes(),
+		Discard:       p.Discard(),
+		StartTime:     AVTimestamp(p.StartTime()),
+		EndTime:       AVTimestamp(p.EndTime()),
+		Metadata:      p.Metadata(),
+	})
+}
+
+func (p *AVProgram) String() string {
+	return marshalToString(p)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Add a program with the given identifier to a format context, or return the
+// existing program with that identifier. Returns nil on allocation failure.
+func AVFormat_new_program(ctx *AVFormatContext, id int) *AVProgram {
+	return (*AVProgram)(C.av_new_program((*C.struct_AVFormatContext)(ctx), C.int(id)))
+}
+
+// Add a stream to the program with the given identifier
+func AVFormat_program_add_stream_index(ctx *AVFormatContext, id int, stream int) {
+	C.av_program_add_stream_index((*C.struct_AVFormatContext)(ctx), C.int(id), C.uint(stream))
+}
+
+// Return the programs of a format context. Most formats have none, but an
+// MPEG-TS input has one for each service in the multiplex.
+func (ctx *AVFormatContext) Programs() []*AVProgram {
+	return cAVProgramSlice(unsafe.Pointer(ctx.programs), C.int(ctx.nb_programs))
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PROPERTIES
+
+// Program identifier, which is the program number for MPEG-TS
+func (p *AVProgram) Id() int {
+	return int(p.id)
+}
+
+func (p *AVProgram) ProgramNum() int {
+	return int(p.program_num)
+}
+
+// PID of the program map table, or -1 if not known
+func (p *AVProgram) PmtPid() int {
+	return int(p.pmt_pid)
+}
+
+// PID which carries the program clock reference, or -1 if not known
+func (p *AVProgram) PcrPid() int {
+	return int(p.pcr_pid)
+}
+
+func (p *AVProgram) PmtVersion() int {
+	return int(p.pmt_version)
+}
+
+// Return the indexes of the streams in the program
+func (p *AVProgram) StreamIndexes() []int {
+	if p.stream_index == nil || p.nb_stream_indexes == 0 {
+		return nil
+	}
+	indexes := unsafe.Slice(p.stream_index, int(p.nb_stream_indexes))
+	result := make([]int, len(indexes))
+	for i, index := range indexes {
+		result[i] = int(index)
+	}
+	return result
+}
+
+func (p *AVProgram) Discard() AVDiscard {
+	return AVDiscard(p.discard)
+}
+
+// Set which packets the demuxer should discard for the program
+func (p *AVProgram) SetDiscard(discard AVDiscard) {
+	p.discard = C.enum_AVDiscard(discard)
+}
+
+func (p *AVProgram) StartTime() int64 {
+	return int64(p.start_time)
+}
+
+func (p *AVProgram) EndTime() int64 {
+	return int64(p.end_time)
+}
+
+// Program metadata, which includes "service_name" and "service_provider" for
+// MPEG-TS
+func (p *AVProgram) Metadata() *AVDictionary {
+	return &AVDictionary{p.metadata}
+}
+
+func (p *AVProgram) SetMetadata(dict *AVDictionary) {
+	if dict == nil {
+		p.metadata = nil
+	} else {
+		p.metadata = dict.ctx
+	}
+}
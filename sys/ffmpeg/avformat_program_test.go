@@ -0,0 +1,91 @@
+package ffmpeg
+
+import (
+	"path/filepath"
+	"testing"
+
+	// Packages
+	"github.com/stretchr/testify/assert"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TESTS
+
+func Test_avformat_program_001(t *testing.T) {
+	assert := assert.New(t)
+
+	filename := filepath.Join(t.TempDir(), "programs.ts")
+	output, err := AVFormat_create_file(filename, nil)
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	defer AVFormat_close_writer(output)
+
+	// Add two streams
+	for i := 0; i < 2; i++ {
+		assert.NotNil(AVFormat_new_stream(output, nil))
+	}
+
+	// Add a program for each stream
+	for i, stream := range output.Streams() {
+		program := AVFormat_new_program(output, 100+i)
+		if !assert.NotNil(program) {
+			t.FailNow()
+		}
+		dict := AVUtil_dict_alloc()
+		assert.NoError(AVUtil_dict_set(dict, "service_name", "Service", 0))
+		program.SetMetadata(dict)
+		AVFormat_program_add_stream_index(output, 100+i, stream.Index())
+	}
+
+	// A program with an existing identifier is returned
+	assert.Equal(output.Programs()[0], AVFormat_new_program(output, 100))
+
+	programs := output.Programs()
+	if assert.Len(programs, 2) {
+		for i, program := range programs {
+			assert.Equal(100+i, program.Id())
+			assert.Equal([]int{i}, program.StreamIndexes())
+			assert.Equal("Service", AVUtil_dict_get(program.Metadata(), "service_name", nil, 0).Value())
+			t.Log(program)
+		}
+	}
+}
+
+func Test_avformat_program_002(t *testing.T) {
+	assert := assert.New(t)
+
+	// Files other than transport streams normally have no programs
+	input, err := AVFormat_open_url(filepath.Join("..", "..", "etc", "test", "sample.mp4"), nil, nil)
+	if !assert.NoError(err) {
+		t.SkipNow()
+	}
+	defer AVFormat_close_input(input)
+
+	assert.Equal(uint(0), input.NumPrograms())
+	assert.Empty(input.Programs())
+
+	// Streams are not discarded by default
+	for _, stream := range input.Streams() {
+		assert.Equal(AVDISCARD_DEFAULT, stream.Discard())
+		stream.SetDiscard(AVDISCARD_ALL)
+		assert.Equal(AVDISCARD_ALL, stream.Discard())
+	}
+}
+
+func Test_avformat_program_003(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		discard AVDiscard
+		want    string
+	}{
+		{AVDISCARD_NONE, "none"},
+		{AVDISCARD_DEFAULT, "default"},
+		{AVDISCARD_NONKEY, "nonkey"},
+		{AVDISCARD_ALL, "all"},
+	}
+	for _, test := range tests {
+		assert.Equal(test.want, test.discard.String())
+	}
+}
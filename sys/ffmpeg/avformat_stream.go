@@ -85,6 +85,15 @@ func (ctx *AVStream) SetDisposition(disposition AVDisposition) {
 	ctx.disposition = C.int(disposition)
 }
 
+func (ctx *AVStream) Discard() AVDiscard {
+	return AVDiscard(ctx.discard)
+}
+
+// Set which packets the demuxer should discard for the stream
+func (ctx *AVStream) SetDiscard(discard AVDiscard) {
+	ctx.discard = C.enum_AVDiscard(discard)
+}
+
 func (ctx *AVStream) StartTime() int64 {
 	return int64(ctx.start_time)
 }
@@ -97,6 +106,28 @@ func (ctx *AVStream) NumFrames() int64 {
 	return int64(ctx.nb_frames)
 }
 
+func (ctx *AVStream) Metadata() *AVDictionary {
+	return &AVDictionary{ctx.metadata}
+}
+
+// Set the stream metadata, such as the "language". The stream takes
+// ownership of the dictionary.
+func (ctx *AVStream) SetMetadata(dict *AVDictionary) {
+	if dict == nil {
+		ctx.metadata = nil
+	} else {
+		ctx.metadata = dict.ctx
+	}
+}
+
+func (ctx *AVStream) AvgFrameRate() AVRational {
+	return AVRational(ctx.avg_frame_rate)
+}
+
+func (ctx *AVStream) RFrameRate() AVRational {
+	return AVRational(ctx.r_frame_rate)
+}
+
 func (ctx *AVStream) AttachedPic() *AVPacket {
 	if ctx.disposition&C.AV_DISPOSITION_ATTACHED_PIC == 0 {
 		return nil
@@ -0,0 +1,109 @@
+package ffmpeg
+
+import (
+	"errors"
+	"unsafe"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// CGO
+
+/*
+#cgo pkg-config: libavutil
+#include <libavutil/audio_fifo.h>
+#include <libavutil/frame.h>
+*/
+import "C"
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type (
+	AVAudioFifo C.struct_AVAudioFifo
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// BINDINGS
+
+// Allocate an AVAudioFifo with an initial capacity of nb_samples per channel.
+func AVUtil_audio_fifo_alloc(sample_fmt AVSampleFormat, channels, nb_samples int) *AVAudioFifo {
+	return (*AVAudioFifo)(C.av_audio_fifo_alloc(C.enum_AVSampleFormat(sample_fmt), C.int(channels), C.int(nb_samples)))
+}
+
+// Free an AVAudioFifo.
+func AVUtil_audio_fifo_free(fifo *AVAudioFifo) {
+	if fifo != nil {
+		C.av_audio_fifo_free((*C.struct_AVAudioFifo)(fifo))
+	}
+}
+
+// Reallocate an AVAudioFifo to hold nb_samples per channel.
+func AVUtil_audio_fifo_realloc(fifo *AVAudioFifo, nb_samples int) error {
+	if err := AVError(C.av_audio_fifo_realloc((*C.struct_AVAudioFifo)(fifo), C.int(nb_samples))); err < 0 {
+		return err
+	}
+	return nil
+}
+
+// Write the samples of an audio frame to the fifo, growing it as needed.
+// Returns the number of samples written.
+func AVUtil_audio_fifo_write(fifo *AVAudioFifo, frame *AVFrame) (int, error) {
+	if frame == nil || frame.extended_data == nil {
+		return 0, errors.New("frame has no data")
+	}
+	n := C.av_audio_fifo_write((*C.struct_AVAudioFifo)(fifo), (*unsafe.Pointer)(unsafe.Pointer(frame.extended_data)), frame.nb_samples)
+	if n < 0 {
+		return 0, AVError(n)
+	}
+	return int(n), nil
+}
+
+// Read up to nb_samples per channel from the fifo into the data planes of an
+// allocated audio frame. Returns the number of samples read.
+func AVUtil_audio_fifo_read(fifo *AVAudioFifo, frame *AVFrame, nb_samples int) (int, error) {
+	if frame == nil || frame.extended_data == nil {
+		return 0, errors.New("frame has no data")
+	}
+	n := C.av_audio_fifo_read((*C.struct_AVAudioFifo)(fifo), (*unsafe.Pointer)(unsafe.Pointer(frame.extended_data)), C.int(nb_samples))
+	if n < 0 {
+		return 0, AVError(n)
+	}
+	return int(n), nil
+}
+
+// Peek up to nb_samples per channel from the fifo into the data planes of an
+// allocated audio frame, without removing them. Returns the number of samples.
+func AVUtil_audio_fifo_peek(fifo *AVAudioFifo, frame *AVFrame, nb_samples int) (int, error) {
+	if frame == nil || frame.extended_data == nil {
+		return 0, errors.New("frame has no data")
+	}
+	n := C.av_audio_fifo_peek((*C.struct_AVAudioFifo)(fifo), (*unsafe.Pointer)(unsafe.Pointer(frame.extended_data)), C.int(nb_samples))
+	if n < 0 {
+		return 0, AVError(n)
+	}
+	return int(n), nil
+}
+
+// Remove nb_samples per channel from the fifo.
+func AVUtil_audio_fifo_drain(fifo *AVAudioFifo, nb_samples int) error {
+	if err := AVError(C.av_audio_fifo_drain((*C.struct_AVAudioFifo)(fifo), C.int(nb_samples))); err < 0 {
+		return err
+	}
+	return nil
+}
+
+// Remove all samples from the fifo.
+func AVUtil_audio_fifo_reset(fifo *AVAudioFifo) {
+	C.av_audio_fifo_reset((*C.struct_AVAudioFifo)(fifo))
+}
+
+// Return the number of samples per channel available for reading.
+func AVUtil_audio_fifo_size(fifo *AVAudioFifo) int {
+	return int(C.av_audio_fifo_size((*C.struct_AVAudioFifo)(fifo)))
+}
+
+// Return the number of samples per channel which can be written without
+// growing the fifo.
+func AVUtil_audio_fifo_space(fifo *AVAudioFifo) int {
+	return int(C.av_audio_fifo_space((*C.struct_AVAudioFifo)(fifo)))
+}
@@ -0,0 +1,96 @@
+package ffmpeg
+
+import (
+	"testing"
+
+	// Package imports
+	"github.com/stretchr/testify/assert"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TEST AUDIO FIFO
+
+func Test_avutil_audio_fifo_alloc_free(t *testing.T) {
+	assert := assert.New(t)
+
+	fifo := AVUtil_audio_fifo_alloc(AV_SAMPLE_FMT_FLTP, 2, 1024)
+	assert.NotNil(fifo)
+	assert.Equal(0, AVUtil_audio_fifo_size(fifo))
+	assert.GreaterOrEqual(AVUtil_audio_fifo_space(fifo), 1024)
+
+	AVUtil_audio_fifo_free(fifo)
+}
+
+func Test_avutil_audio_fifo_free_nil(t *testing.T) {
+	// Should not panic
+	AVUtil_audio_fifo_free(nil)
+}
+
+func Test_avutil_audio_fifo_write_read(t *testing.T) {
+	assert := assert.New(t)
+
+	fifo := AVUtil_audio_fifo_alloc(AV_SAMPLE_FMT_FLT, 1, 16)
+	if !assert.NotNil(fifo) {
+		t.FailNow()
+	}
+	defer AVUtil_audio_fifo_free(fifo)
+
+	// Write 100 samples, which grows the fifo
+	in := newFifoFrame(t, 100)
+	defer AVUtil_frame_free(in)
+	for i := range in.Float32(0) {
+		in.Float32(0)[i] = float32(i)
+	}
+	n, err := AVUtil_audio_fifo_write(fifo, in)
+	assert.NoError(err)
+	assert.Equal(100, n)
+	assert.Equal(100, AVUtil_audio_fifo_size(fifo))
+
+	// Peek and then read 64 samples
+	out := newFifoFrame(t, 64)
+	defer AVUtil_frame_free(out)
+	n, err = AVUtil_audio_fifo_peek(fifo, out, 64)
+	assert.NoError(err)
+	assert.Equal(64, n)
+	assert.Equal(100, AVUtil_audio_fifo_size(fifo))
+	n, err = AVUtil_audio_fifo_read(fifo, out, 64)
+	assert.NoError(err)
+	assert.Equal(64, n)
+	assert.Equal(float32(63), out.Float32(0)[63])
+
+	// Only 36 samples remain
+	n, err = AVUtil_audio_fifo_read(fifo, out, 64)
+	assert.NoError(err)
+	assert.Equal(36, n)
+	assert.Equal(float32(64), out.Float32(0)[0])
+	assert.Equal(0, AVUtil_audio_fifo_size(fifo))
+
+	// Drain and reset
+	_, err = AVUtil_audio_fifo_write(fifo, in)
+	assert.NoError(err)
+	assert.NoError(AVUtil_audio_fifo_drain(fifo, 50))
+	assert.Equal(50, AVUtil_audio_fifo_size(fifo))
+	AVUtil_audio_fifo_reset(fifo)
+	assert.Equal(0, AVUtil_audio_fifo_size(fifo))
+}
+
+func newFifoFrame(t *testing.T, nb_samples int) *AVFrame {
+	frame := AVUtil_frame_alloc()
+	if frame == nil {
+		t.Fatal("failed to allocate frame")
+	}
+	var ch AVChannelLayout
+	if err := AVUtil_channel_layout_from_string(&ch, "mono"); err != nil {
+		t.Fatal(err)
+	}
+	frame.SetSampleFormat(AV_SAMPLE_FMT_FLT)
+	if err := frame.SetChannelLayout(ch); err != nil {
+		t.Fatal(err)
+	}
+	frame.SetSampleRate(44100)
+	frame.SetNumSamples(nb_samples)
+	if err := AVUtil_frame_get_buffer(frame, false); err != nil {
+		t.Fatal(err)
+	}
+	return frame
+}
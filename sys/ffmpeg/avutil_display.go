@@ -0,0 +1,92 @@
+package ffmpeg
+
+import (
+	"encoding/json"
+	"unsafe"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// CGO
+
+/*
+#cgo pkg-config: libavutil
+#include <libavutil/display.h>
+*/
+import "C"
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// AVDisplayMatrix is the 3x3 transformation matrix carried in display matrix
+// side data, which describes how a decoded video frame should be rotated and
+// flipped for presentation
+type AVDisplayMatrix [9]int32
+
+////////////////////////////////////////////////////////////////////////////////
+// CONSTANTS
+
+const (
+	// Size of display matrix side data in bytes
+	AV_DISPLAY_MATRIX_SIZE = int(unsafe.Sizeof(AVDisplayMatrix{}))
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (m *AVDisplayMatrix) MarshalJSON() ([]byte, error) {
+	type jsonAVDisplayMatrix struct {
+		Rotation float64  `json:"rotation"`
+		Matrix   [9]int32 `json:"matrix"`
+	}
+	if m == nil {
+		return json.Marshal(nil)
+	}
+	return json.Marshal(jsonAVDisplayMatrix{
+		Rotation: AVUtil_display_rotation_get(m),
+		Matrix:   *m,
+	})
+}
+
+func (m *AVDisplayMatrix) String() string {
+	return marshalToString(m)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Return a copy of the display matrix in side data, or nil if the data is too
+// short to contain one
+func AVUtil_display_matrix(data []byte) *AVDisplayMatrix {
+	if len(data) < AV_DISPLAY_MATRIX_SIZE {
+		return nil
+	}
+	m := *(*AVDisplayMatrix)(unsafe.Pointer(&data[0]))
+	return &m
+}
+
+// Return the rotation angle in degrees, counterclockwise, in the range
+// [-180.0, 180.0]. Returns NaN if the matrix is singular.
+func AVUtil_display_rotation_get(m *AVDisplayMatrix) float64 {
+	return float64(C.av_display_rotation_get((*C.int32_t)(unsafe.Pointer(&m[0]))))
+}
+
+// Set the matrix to a pure counterclockwise rotation by the angle in degrees
+func AVUtil_display_rotation_set(m *AVDisplayMatrix, angle float64) {
+	C.av_display_rotation_set((*C.int32_t)(unsafe.Pointer(&m[0])), C.double(angle))
+}
+
+// Flip the matrix horizontally and/or vertically
+func AVUtil_display_matrix_flip(m *AVDisplayMatrix, hflip, vflip bool) {
+	C.av_display_matrix_flip((*C.int32_t)(unsafe.Pointer(&m[0])), boolToInt(hflip), boolToInt(vflip))
+}
+
+// Return the matrix as side data
+func (m *AVDisplayMatrix) Bytes() []byte {
+	return unsafe.Slice((*byte)(unsafe.Pointer(&m[0])), AV_DISPLAY_MATRIX_SIZE)
+}
+
+// Return true if the matrix mirrors the image, which is the case when the
+// determinant of the upper-left 2x2 matrix is negative
+func (m *AVDisplayMatrix) IsFlipped() bool {
+	return int64(m[0])*int64(m[4])-int64(m[1])*int64(m[3]) < 0
+}
@@ -0,0 +1,54 @@
+package ffmpeg
+
+import (
+	"testing"
+
+	// Packages
+	"github.com/stretchr/testify/assert"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TESTS
+
+func Test_avutil_display_001(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, angle := range []float64{0, 90, -90, 180} {
+		var m AVDisplayMatrix
+		AVUtil_display_rotation_set(&m, angle)
+		assert.InDelta(angle, abs180(AVUtil_display_rotation_get(&m), angle), 0.01)
+		assert.False(m.IsFlipped())
+
+		// Flipping mirrors the image
+		AVUtil_display_matrix_flip(&m, true, false)
+		assert.True(m.IsFlipped())
+	}
+}
+
+func Test_avutil_display_002(t *testing.T) {
+	assert := assert.New(t)
+
+	var m AVDisplayMatrix
+	AVUtil_display_rotation_set(&m, 90)
+
+	// Round trip through side data
+	data := m.Bytes()
+	assert.Len(data, AV_DISPLAY_MATRIX_SIZE)
+	result := AVUtil_display_matrix(data)
+	if assert.NotNil(result) {
+		assert.Equal(m, *result)
+		t.Log(result)
+	}
+
+	// Too short
+	assert.Nil(AVUtil_display_matrix(data[:4]))
+}
+
+// abs180 maps -180 onto 180 when the wanted angle is 180, as both describe the
+// same rotation
+func abs180(angle, want float64) float64 {
+	if want == 180 && angle == -180 {
+		return 180
+	}
+	return angle
+}
@@ -291,6 +291,23 @@ func (ctx *AVFrame) SetPts(pts int64) {
 	ctx.pts = C.int64_t(pts)
 }
 
+// Return the frame timestamp estimated by the decoder, which is set when the
+// presentation timestamp is missing
+func (ctx *AVFrame) BestEffortTimestamp() int64 {
+	return int64(ctx.best_effort_timestamp)
+}
+
+// Return the picture type of a video frame, as decoded
+func (ctx *AVFrame) PictType() AVPictureType {
+	return AVPictureType(ctx.pict_type)
+}
+
+// Set the picture type of a video frame, which some encoders use to force
+// the type of picture, or AV_PICTURE_TYPE_NONE to let the encoder choose
+func (ctx *AVFrame) SetPictType(pict_type AVPictureType) {
+	ctx.pict_type = C.enum_AVPictureType(pict_type)
+}
+
 func (ctx *AVFrame) TimeBase() AVRational {
 	return AVRational(ctx.time_base)
 }
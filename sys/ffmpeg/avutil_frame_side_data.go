@@ -0,0 +1,144 @@
+package ffmpeg
+
+import (
+	"encoding/json"
+	"errors"
+	"unsafe"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// CGO
+
+/*
+#cgo pkg-config: libavutil
+#include <libavutil/frame.h>
+#include <string.h>
+*/
+import "C"
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type (
+	AVFrameSideData     C.struct_AVFrameSideData
+	AVFrameSideDataType C.enum_AVFrameSideDataType
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// CONSTANTS
+
+const (
+	AV_FRAME_DATA_PANSCAN                     AVFrameSideDataType = C.AV_FRAME_DATA_PANSCAN
+	AV_FRAME_DATA_A53_CC                      AVFrameSideDataType = C.AV_FRAME_DATA_A53_CC   // ATSC A53 closed captions
+	AV_FRAME_DATA_STEREO3D                    AVFrameSideDataType = C.AV_FRAME_DATA_STEREO3D // AVStereo3D
+	AV_FRAME_DATA_MATRIXENCODING              AVFrameSideDataType = C.AV_FRAME_DATA_MATRIXENCODING
+	AV_FRAME_DATA_DOWNMIX_INFO                AVFrameSideDataType = C.AV_FRAME_DATA_DOWNMIX_INFO
+	AV_FRAME_DATA_REPLAYGAIN                  AVFrameSideDataType = C.AV_FRAME_DATA_REPLAYGAIN    // AVReplayGain
+	AV_FRAME_DATA_DISPLAYMATRIX               AVFrameSideDataType = C.AV_FRAME_DATA_DISPLAYMATRIX // 3x3 display matrix
+	AV_FRAME_DATA_AFD                         AVFrameSideDataType = C.AV_FRAME_DATA_AFD
+	AV_FRAME_DATA_MOTION_VECTORS              AVFrameSideDataType = C.AV_FRAME_DATA_MOTION_VECTORS
+	AV_FRAME_DATA_SKIP_SAMPLES                AVFrameSideDataType = C.AV_FRAME_DATA_SKIP_SAMPLES // Samples to skip at the start and end
+	AV_FRAME_DATA_AUDIO_SERVICE_TYPE          AVFrameSideDataType = C.AV_FRAME_DATA_AUDIO_SERVICE_TYPE
+	AV_FRAME_DATA_MASTERING_DISPLAY_METADATA  AVFrameSideDataType = C.AV_FRAME_DATA_MASTERING_DISPLAY_METADATA // AVMasteringDisplayMetadata
+	AV_FRAME_DATA_GOP_TIMECODE                AVFrameSideDataType = C.AV_FRAME_DATA_GOP_TIMECODE
+	AV_FRAME_DATA_SPHERICAL                   AVFrameSideDataType = C.AV_FRAME_DATA_SPHERICAL           // AVSphericalMapping
+	AV_FRAME_DATA_CONTENT_LIGHT_LEVEL         AVFrameSideDataType = C.AV_FRAME_DATA_CONTENT_LIGHT_LEVEL // AVContentLightMetadata
+	AV_FRAME_DATA_ICC_PROFILE                 AVFrameSideDataType = C.AV_FRAME_DATA_ICC_PROFILE
+	AV_FRAME_DATA_S12M_TIMECODE               AVFrameSideDataType = C.AV_FRAME_DATA_S12M_TIMECODE
+	AV_FRAME_DATA_DYNAMIC_HDR_PLUS            AVFrameSideDataType = C.AV_FRAME_DATA_DYNAMIC_HDR_PLUS // AVDynamicHDRPlus
+	AV_FRAME_DATA_REGIONS_OF_INTEREST         AVFrameSideDataType = C.AV_FRAME_DATA_REGIONS_OF_INTEREST
+	AV_FRAME_DATA_SEI_UNREGISTERED            AVFrameSideDataType = C.AV_FRAME_DATA_SEI_UNREGISTERED
+	AV_FRAME_DATA_FILM_GRAIN_PARAMS           AVFrameSideDataType = C.AV_FRAME_DATA_FILM_GRAIN_PARAMS
+	AV_FRAME_DATA_DOVI_METADATA               AVFrameSideDataType = C.AV_FRAME_DATA_DOVI_METADATA
+	AV_FRAME_DATA_DYNAMIC_HDR_VIVID           AVFrameSideDataType = C.AV_FRAME_DATA_DYNAMIC_HDR_VIVID
+	AV_FRAME_DATA_AMBIENT_VIEWING_ENVIRONMENT AVFrameSideDataType = C.AV_FRAME_DATA_AMBIENT_VIEWING_ENVIRONMENT
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (t AVFrameSideDataType) String() string {
+	if name := C.av_frame_side_data_name(C.enum_AVFrameSideDataType(t)); name != nil {
+		return C.GoString(name)
+	}
+	return "unknown"
+}
+
+func (t AVFrameSideDataType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+func (sd *AVFrameSideData) MarshalJSON() ([]byte, error) {
+	type jsonAVFrameSideData struct {
+		Type     AVFrameSideDataType `json:"type"`
+		Size     int                 `json:"size"`
+		Metadata *AVDictionary       `json:"metadata,omitempty"`
+	}
+	if sd == nil {
+		return json.Marshal(nil)
+	}
+	var metadata *AVDictionary
+	if sd.metadata != nil {
+		metadata = sd.Metadata()
+	}
+	return json.Marshal(jsonAVFrameSideData{
+		Type:     sd.Type(),
+		Size:     int(sd.size),
+		Metadata: metadata,
+	})
+}
+
+func (sd *AVFrameSideData) String() string {
+	return marshalToString(sd)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Return the side data of the given type from a frame, or nil if the frame
+// does not have side data of that type
+func AVUtil_frame_get_side_data(frame *AVFrame, t AVFrameSideDataType) *AVFrameSideData {
+	return (*AVFrameSideData)(C.av_frame_get_side_data((*C.struct_AVFrame)(frame), C.enum_AVFrameSideDataType(t)))
+}
+
+// Add side data of the given type to a frame and copy the data into it. Any
+// existing side data of the same type is kept, so remove it first to replace it.
+func AVUtil_frame_new_side_data(frame *AVFrame, t AVFrameSideDataType, data []byte) (*AVFrameSideData, error) {
+	sd := C.av_frame_new_side_data((*C.struct_AVFrame)(frame), C.enum_AVFrameSideDataType(t), C.size_t(len(data)))
+	if sd == nil {
+		return nil, errors.New("failed to allocate frame side data")
+	}
+	if len(data) > 0 {
+		C.memcpy(unsafe.Pointer(sd.data), unsafe.Pointer(&data[0]), C.size_t(len(data)))
+	}
+	return (*AVFrameSideData)(sd), nil
+}
+
+// Remove and free all side data of the given type from a frame
+func AVUtil_frame_remove_side_data(frame *AVFrame, t AVFrameSideDataType) {
+	C.av_frame_remove_side_data((*C.struct_AVFrame)(frame), C.enum_AVFrameSideDataType(t))
+}
+
+// Return all side data attached to a frame
+func (ctx *AVFrame) SideData() []*AVFrameSideData {
+	return cAVFrameSideDataSlice(unsafe.Pointer(ctx.side_data), C.int(ctx.nb_side_data))
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PROPERTIES
+
+func (sd *AVFrameSideData) Type() AVFrameSideDataType {
+	return AVFrameSideDataType(sd._type)
+}
+
+// Return the side data, which is owned by the frame
+func (sd *AVFrameSideData) Data() []byte {
+	if sd.data == nil || sd.size == 0 {
+		return nil
+	}
+	return unsafe.Slice((*byte)(unsafe.Pointer(sd.data)), int(sd.size))
+}
+
+func (sd *AVFrameSideData) Metadata() *AVDictionary {
+	return &AVDictionary{sd.metadata}
+}
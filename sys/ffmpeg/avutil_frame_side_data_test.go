@@ -0,0 +1,151 @@
+package ffmpeg
+
+import (
+	"testing"
+
+	// Packages
+	"github.com/stretchr/testify/assert"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TESTS
+
+func Test_avutil_frame_side_data_001(t *testing.T) {
+	assert := assert.New(t)
+
+	frame := AVUtil_frame_alloc()
+	if !assert.NotNil(frame) {
+		t.FailNow()
+	}
+	defer AVUtil_frame_free(frame)
+
+	// No side data
+	assert.Empty(frame.SideData())
+	assert.Nil(AVUtil_frame_get_side_data(frame, AV_FRAME_DATA_A53_CC))
+
+	// Add and remove closed captions
+	sd, err := AVUtil_frame_new_side_data(frame, AV_FRAME_DATA_A53_CC, []byte{0xFC, 0x94, 0x20})
+	if !assert.NoError(err) {
+		t.FailNow()
+	}
+	assert.Equal(AV_FRAME_DATA_A53_CC, sd.Type())
+	assert.Equal([]byte{0xFC, 0x94, 0x20}, sd.Data())
+	assert.Equal(sd, AVUtil_frame_get_side_data(frame, AV_FRAME_DATA_A53_CC))
+	assert.Len(frame.SideData(), 1)
+	t.Log(sd)
+
+	AVUtil_frame_remove_side_data(frame, AV_FRAME_DATA_A53_CC)
+	assert.Nil(AVUtil_frame_get_side_data(frame, AV_FRAME_DATA_A53_CC))
+	assert.Empty(frame.SideData())
+}
+
+func Test_avutil_frame_side_data_002(t *testing.T) {
+	assert := assert.New(t)
+
+	frame := AVUtil_frame_alloc()
+	if !assert.NotNil(frame) {
+		t.FailNow()
+	}
+	defer AVUtil_frame_free(frame)
+
+	// HDR10 mastering display and content light level
+	mdm := AVUtil_mastering_display_metadata_create_side_data(frame)
+	if !assert.NotNil(mdm) {
+		t.FailNow()
+	}
+	assert.False(mdm.HasPrimaries())
+	assert.False(mdm.HasLuminance())
+	mdm.SetLuminance(AVUtil_rational(1, 10000), AVUtil_rational(1000, 1))
+	assert.True(mdm.HasLuminance())
+
+	clm := AVUtil_content_light_metadata_create_side_data(frame)
+	if !assert.NotNil(clm) {
+		t.FailNow()
+	}
+	clm.SetMaxContentLightLevel(1000)
+	clm.SetMaxFrameAverageLightLevel(400)
+
+	// Read back through the side data
+	sd := AVUtil_frame_get_side_data(frame, AV_FRAME_DATA_MASTERING_DISPLAY_METADATA)
+	if assert.NotNil(sd) {
+		m := AVUtil_mastering_display_metadata(sd.Data())
+		if assert.NotNil(m) {
+			assert.Equal(AVUtil_rational(1000, 1), m.MaxLuminance())
+			t.Log(m)
+		}
+	}
+	sd = AVUtil_frame_get_side_data(frame, AV_FRAME_DATA_CONTENT_LIGHT_LEVEL)
+	if assert.NotNil(sd) {
+		m := AVUtil_content_light_metadata(sd.Data())
+		if assert.NotNil(m) {
+			assert.Equal(1000, m.MaxContentLightLevel())
+			assert.Equal(400, m.MaxFrameAverageLightLevel())
+			t.Log(m)
+		}
+	}
+}
+
+func Test_avutil_frame_side_data_003(t *testing.T) {
+	assert := assert.New(t)
+
+	frame := AVUtil_frame_alloc()
+	if !assert.NotNil(frame) {
+		t.FailNow()
+	}
+	defer AVUtil_frame_free(frame)
+
+	// Stereoscopic video
+	s := AVUtil_stereo3d_create_side_data(frame)
+	if !assert.NotNil(s) {
+		t.FailNow()
+	}
+	s.SetType(AV_STEREO3D_SIDEBYSIDE)
+	s.SetFlags(AV_STEREO3D_FLAG_INVERT)
+	if sd := AVUtil_frame_get_side_data(frame, AV_FRAME_DATA_STEREO3D); assert.NotNil(sd) {
+		s := AVUtil_stereo3d(sd.Data())
+		if assert.NotNil(s) {
+			assert.Equal(AV_STEREO3D_SIDEBYSIDE, s.Type())
+			assert.Equal("side by side", s.Type().String())
+			assert.True(s.Inverted())
+			t.Log(s)
+		}
+	}
+
+	// HDR10+
+	hdr := AVUtil_dynamic_hdr_plus_create_side_data(frame)
+	if assert.NotNil(hdr) {
+		assert.Equal(0, hdr.NumWindows())
+		if sd := AVUtil_frame_get_side_data(frame, AV_FRAME_DATA_DYNAMIC_HDR_PLUS); assert.NotNil(sd) {
+			assert.NotNil(AVUtil_dynamic_hdr_plus(sd.Data()))
+		}
+	}
+
+	// Invalid T.35 data
+	_, err := AVUtil_dynamic_hdr_plus_from_t35([]byte{0x00})
+	assert.Error(err)
+}
+
+func Test_avutil_frame_side_data_004(t *testing.T) {
+	assert := assert.New(t)
+
+	// Too short
+	assert.Nil(AVUtil_replaygain([]byte{0x00}))
+	assert.Nil(AVUtil_spherical([]byte{0x00}))
+	assert.Nil(AVUtil_stereo3d(nil))
+	assert.Nil(AVUtil_mastering_display_metadata(nil))
+	assert.Nil(AVUtil_content_light_metadata(nil))
+
+	// Unknown replay gain
+	data := make([]byte, 16)
+	data[3] = 0x80 // INT32_MIN in little-endian for the track gain
+	if rg := AVUtil_replaygain(data); assert.NotNil(rg) {
+		_, ok := rg.TrackGain()
+		assert.False(ok)
+		_, ok = rg.TrackPeak()
+		assert.False(ok)
+		t.Log(rg)
+	}
+
+	assert.Equal("Display Matrix", AV_FRAME_DATA_DISPLAYMATRIX.String())
+	assert.Equal("equirectangular", AV_SPHERICAL_EQUIRECTANGULAR.String())
+}
@@ -0,0 +1,98 @@
+package ffmpeg
+
+import (
+	"encoding/json"
+	"unsafe"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// CGO
+
+/*
+#cgo pkg-config: libavutil
+#include <libavutil/hdr_dynamic_metadata.h>
+*/
+import "C"
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// AVDynamicHDRPlus is HDR10+ dynamic metadata (SMPTE 2094-40)
+type AVDynamicHDRPlus C.AVDynamicHDRPlus
+
+////////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (m *AVDynamicHDRPlus) MarshalJSON() ([]byte, error) {
+	type jsonAVDynamicHDRPlus struct {
+		CountryCode                       int        `json:"country_code"`
+		ApplicationVersion                int        `json:"application_version"`
+		NumWindows                        int        `json:"num_windows"`
+		TargetedSystemDisplayMaxLuminance AVRational `json:"targeted_system_display_maximum_luminance"`
+	}
+	if m == nil {
+		return json.Marshal(nil)
+	}
+	return json.Marshal(jsonAVDynamicHDRPlus{
+		CountryCode:                       m.CountryCode(),
+		ApplicationVersion:                m.ApplicationVersion(),
+		NumWindows:                        m.NumWindows(),
+		TargetedSystemDisplayMaxLuminance: m.TargetedSystemDisplayMaxLuminance(),
+	})
+}
+
+func (m *AVDynamicHDRPlus) String() string {
+	return marshalToString(m)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Return the HDR10+ metadata in frame side data, or nil if the data is too
+// short. The metadata is owned by the side data.
+func AVUtil_dynamic_hdr_plus(data []byte) *AVDynamicHDRPlus {
+	if len(data) < int(unsafe.Sizeof(AVDynamicHDRPlus{})) {
+		return nil
+	}
+	return (*AVDynamicHDRPlus)(unsafe.Pointer(&data[0]))
+}
+
+// Parse HDR10+ metadata in ITU-T T.35 form, as carried in packet side data
+func AVUtil_dynamic_hdr_plus_from_t35(data []byte) (*AVDynamicHDRPlus, error) {
+	var ptr *C.uint8_t
+	if len(data) > 0 {
+		ptr = (*C.uint8_t)(unsafe.Pointer(&data[0]))
+	}
+	m := new(AVDynamicHDRPlus)
+	if err := AVError(C.av_dynamic_hdr_plus_from_t35((*C.AVDynamicHDRPlus)(m), ptr, C.size_t(len(data)))); err < 0 {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Add zeroed HDR10+ metadata to a frame and return it
+func AVUtil_dynamic_hdr_plus_create_side_data(frame *AVFrame) *AVDynamicHDRPlus {
+	return (*AVDynamicHDRPlus)(C.av_dynamic_hdr_plus_create_side_data((*C.struct_AVFrame)(frame)))
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PROPERTIES
+
+// Return the ITU-T T.35 country code
+func (m *AVDynamicHDRPlus) CountryCode() int {
+	return int(m.itu_t_t35_country_code)
+}
+
+func (m *AVDynamicHDRPlus) ApplicationVersion() int {
+	return int(m.application_version)
+}
+
+// Return the number of processing windows, between one and three
+func (m *AVDynamicHDRPlus) NumWindows() int {
+	return int(m.num_windows)
+}
+
+// Return the nominal maximum luminance of the targeted display in cd/m²
+func (m *AVDynamicHDRPlus) TargetedSystemDisplayMaxLuminance() AVRational {
+	return AVRational(m.targeted_system_display_maximum_luminance)
+}
@@ -14,10 +14,16 @@ import (
 #include <libavutil/avutil.h>
 #define MAX_LOG_BUFFER 1024
 
+// The hardware device category is not in FFmpeg 6.1 or 7.1
+#if LIBAVUTIL_VERSION_MAJOR < 60
+#define AV_CLASS_CATEGORY_HWDEVICE 11
+#endif
+
 extern void av_log_cb_(int level,char* message,void* userInfo);
 
 static inline void av_log_cb(void* userInfo,int level,const char* fmt,va_list args) {
-	static char buf[MAX_LOG_BUFFER];
+	// Messages are logged from codec and filter threads, so each call has a buffer
+	char buf[MAX_LOG_BUFFER];
 	if (level <= av_log_get_level()) {
 		vsnprintf(buf, MAX_LOG_BUFFER, fmt, args);
 		av_log_cb_(level, buf, userInfo);
@@ -36,6 +42,19 @@ static void av_log_set_callback_(int def) {
 static void av_log_(void* class, int level, const char* fmt) {
 	av_log(class, level, "%s", fmt);
 }
+
+static const char* av_log_item_name_(void* avcl) {
+	const AVClass* avc = avcl ? *(const AVClass**)avcl : NULL;
+	return (avc && avc->item_name) ? avc->item_name(avcl) : NULL;
+}
+
+static int av_log_category_(void* avcl) {
+	const AVClass* avc = avcl ? *(const AVClass**)avcl : NULL;
+	if (!avc) {
+		return AV_CLASS_CATEGORY_NA;
+	}
+	return avc->get_category ? avc->get_category(avcl) : avc->category;
+}
 */
 import "C"
 
@@ -45,8 +64,9 @@ import "C"
 type AVLogFunc func(level AVLog, message string, userInfo any)
 
 type (
-	AVLog   C.int
-	AVClass C.struct_AVClass
+	AVLog           C.int
+	AVClass         C.struct_AVClass
+	AVClassCategory C.AVClassCategory
 )
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -64,6 +84,21 @@ const (
 	AV_LOG_TRACE   AVLog = 56 // C.AV_LOG_TRACE
 )
 
+const (
+	AV_CLASS_CATEGORY_NA               AVClassCategory = C.AV_CLASS_CATEGORY_NA
+	AV_CLASS_CATEGORY_INPUT            AVClassCategory = C.AV_CLASS_CATEGORY_INPUT
+	AV_CLASS_CATEGORY_OUTPUT           AVClassCategory = C.AV_CLASS_CATEGORY_OUTPUT
+	AV_CLASS_CATEGORY_MUXER            AVClassCategory = C.AV_CLASS_CATEGORY_MUXER
+	AV_CLASS_CATEGORY_DEMUXER          AVClassCategory = C.AV_CLASS_CATEGORY_DEMUXER
+	AV_CLASS_CATEGORY_ENCODER          AVClassCategory = C.AV_CLASS_CATEGORY_ENCODER
+	AV_CLASS_CATEGORY_DECODER          AVClassCategory = C.AV_CLASS_CATEGORY_DECODER
+	AV_CLASS_CATEGORY_FILTER           AVClassCategory = C.AV_CLASS_CATEGORY_FILTER
+	AV_CLASS_CATEGORY_BITSTREAM_FILTER AVClassCategory = C.AV_CLASS_CATEGORY_BITSTREAM_FILTER
+	AV_CLASS_CATEGORY_SWSCALER         AVClassCategory = C.AV_CLASS_CATEGORY_SWSCALER
+	AV_CLASS_CATEGORY_SWRESAMPLER      AVClassCategory = C.AV_CLASS_CATEGORY_SWRESAMPLER
+	AV_CLASS_CATEGORY_HWDEVICE         AVClassCategory = C.AV_CLASS_CATEGORY_HWDEVICE
+)
+
 var cbLog AVLogFunc
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -94,6 +129,37 @@ func (v AVLog) String() string {
 	}
 }
 
+func (v AVClassCategory) String() string {
+	switch v {
+	case AV_CLASS_CATEGORY_NA:
+		return ""
+	case AV_CLASS_CATEGORY_INPUT:
+		return "input"
+	case AV_CLASS_CATEGORY_OUTPUT:
+		return "output"
+	case AV_CLASS_CATEGORY_MUXER:
+		return "muxer"
+	case AV_CLASS_CATEGORY_DEMUXER:
+		return "demuxer"
+	case AV_CLASS_CATEGORY_ENCODER:
+		return "encoder"
+	case AV_CLASS_CATEGORY_DECODER:
+		return "decoder"
+	case AV_CLASS_CATEGORY_FILTER:
+		return "filter"
+	case AV_CLASS_CATEGORY_BITSTREAM_FILTER:
+		return "bitstream_filter"
+	case AV_CLASS_CATEGORY_SWSCALER:
+		return "swscaler"
+	case AV_CLASS_CATEGORY_SWRESAMPLER:
+		return "swresampler"
+	case AV_CLASS_CATEGORY_HWDEVICE:
+		return "hwdevice"
+	default:
+		return fmt.Sprintf("[AVClassCategory:%d]", int(v))
+	}
+}
+
 // MarshalJSON implements the json.Marshaler interface
 func (v AVLog) MarshalJSON() ([]byte, error) {
 	return json.Marshal(v.String())
@@ -129,6 +195,26 @@ func AVUtil_log_set_callback(cb AVLogFunc) {
 	}
 }
 
+// Return the name of the object which logged a message, such as the name of
+// the codec or format, from the userInfo of the log callback. The userInfo is
+// only valid within the callback.
+func AVUtil_log_item_name(userInfo any) string {
+	if ptr, ok := userInfo.(unsafe.Pointer); ok && ptr != nil {
+		return C.GoString(C.av_log_item_name_(ptr))
+	}
+	return ""
+}
+
+// Return the category of the object which logged a message, such as a
+// decoder or demuxer, from the userInfo of the log callback. The userInfo is
+// only valid within the callback.
+func AVUtil_log_category(userInfo any) AVClassCategory {
+	if ptr, ok := userInfo.(unsafe.Pointer); ok && ptr != nil {
+		return AVClassCategory(C.av_log_category_(ptr))
+	}
+	return AV_CLASS_CATEGORY_NA
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 // PRIVATE METHODS
 
@@ -0,0 +1,183 @@
+package ffmpeg
+
+import (
+	"encoding/json"
+	"unsafe"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// CGO
+
+/*
+#cgo pkg-config: libavutil
+#include <libavutil/mastering_display_metadata.h>
+*/
+import "C"
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type (
+	AVMasteringDisplayMetadata C.AVMasteringDisplayMetadata
+	AVContentLightMetadata     C.AVContentLightMetadata
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (m *AVMasteringDisplayMetadata) MarshalJSON() ([]byte, error) {
+	type jsonAVMasteringDisplayMetadata struct {
+		DisplayPrimaries *[3][2]AVRational `json:"display_primaries,omitempty"`
+		WhitePoint       *[2]AVRational    `json:"white_point,omitempty"`
+		MinLuminance     *AVRational       `json:"min_luminance,omitempty"`
+		MaxLuminance     *AVRational       `json:"max_luminance,omitempty"`
+	}
+	if m == nil {
+		return json.Marshal(nil)
+	}
+	var result jsonAVMasteringDisplayMetadata
+	if m.HasPrimaries() {
+		primaries, whitepoint := m.DisplayPrimaries(), m.WhitePoint()
+		result.DisplayPrimaries = &primaries
+		result.WhitePoint = &whitepoint
+	}
+	if m.HasLuminance() {
+		min, max := m.MinLuminance(), m.MaxLuminance()
+		result.MinLuminance = &min
+		result.MaxLuminance = &max
+	}
+	return json.Marshal(result)
+}
+
+func (m *AVContentLightMetadata) MarshalJSON() ([]byte, error) {
+	type jsonAVContentLightMetadata struct {
+		MaxCLL  int `json:"max_cll"`
+		MaxFALL int `json:"max_fall"`
+	}
+	if m == nil {
+		return json.Marshal(nil)
+	}
+	return json.Marshal(jsonAVContentLightMetadata{
+		MaxCLL:  m.MaxContentLightLevel(),
+		MaxFALL: m.MaxFrameAverageLightLevel(),
+	})
+}
+
+func (m *AVMasteringDisplayMetadata) String() string {
+	return marshalToString(m)
+}
+
+func (m *AVContentLightMetadata) String() string {
+	return marshalToString(m)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Return the mastering display metadata in side data, or nil if the data is
+// too short. The metadata is owned by the side data.
+func AVUtil_mastering_display_metadata(data []byte) *AVMasteringDisplayMetadata {
+	if len(data) < int(unsafe.Sizeof(AVMasteringDisplayMetadata{})) {
+		return nil
+	}
+	return (*AVMasteringDisplayMetadata)(unsafe.Pointer(&data[0]))
+}
+
+// Add zeroed mastering display metadata to a frame and return it
+func AVUtil_mastering_display_metadata_create_side_data(frame *AVFrame) *AVMasteringDisplayMetadata {
+	return (*AVMasteringDisplayMetadata)(C.av_mastering_display_metadata_create_side_data((*C.struct_AVFrame)(frame)))
+}
+
+// Return the content light level metadata in side data, or nil if the data
+// is too short. The metadata is owned by the side data.
+func AVUtil_content_light_metadata(data []byte) *AVContentLightMetadata {
+	if len(data) < int(unsafe.Sizeof(AVContentLightMetadata{})) {
+		return nil
+	}
+	return (*AVContentLightMetadata)(unsafe.Pointer(&data[0]))
+}
+
+// Add zeroed content light level metadata to a frame and return it
+func AVUtil_content_light_metadata_create_side_data(frame *AVFrame) *AVContentLightMetadata {
+	return (*AVContentLightMetadata)(C.av_content_light_metadata_create_side_data((*C.struct_AVFrame)(frame)))
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PROPERTIES - MASTERING DISPLAY
+
+// Return the CIE 1931 xy chromaticity of the red, green and blue primaries
+func (m *AVMasteringDisplayMetadata) DisplayPrimaries() [3][2]AVRational {
+	var result [3][2]AVRational
+	for i := range result {
+		for j := range result[i] {
+			result[i][j] = AVRational(m.display_primaries[i][j])
+		}
+	}
+	return result
+}
+
+// Set the CIE 1931 xy chromaticity of the red, green and blue primaries
+func (m *AVMasteringDisplayMetadata) SetDisplayPrimaries(primaries [3][2]AVRational) {
+	for i := range primaries {
+		for j := range primaries[i] {
+			m.display_primaries[i][j] = C.AVRational(primaries[i][j])
+		}
+	}
+	m.has_primaries = 1
+}
+
+// Return the CIE 1931 xy chromaticity of the white point
+func (m *AVMasteringDisplayMetadata) WhitePoint() [2]AVRational {
+	return [2]AVRational{AVRational(m.white_point[0]), AVRational(m.white_point[1])}
+}
+
+func (m *AVMasteringDisplayMetadata) SetWhitePoint(whitepoint [2]AVRational) {
+	m.white_point[0] = C.AVRational(whitepoint[0])
+	m.white_point[1] = C.AVRational(whitepoint[1])
+}
+
+// Return the minimum luminance of the mastering display in cd/m²
+func (m *AVMasteringDisplayMetadata) MinLuminance() AVRational {
+	return AVRational(m.min_luminance)
+}
+
+// Return the maximum luminance of the mastering display in cd/m²
+func (m *AVMasteringDisplayMetadata) MaxLuminance() AVRational {
+	return AVRational(m.max_luminance)
+}
+
+// Set the minimum and maximum luminance of the mastering display in cd/m²
+func (m *AVMasteringDisplayMetadata) SetLuminance(min, max AVRational) {
+	m.min_luminance = C.AVRational(min)
+	m.max_luminance = C.AVRational(max)
+	m.has_luminance = 1
+}
+
+func (m *AVMasteringDisplayMetadata) HasPrimaries() bool {
+	return m.has_primaries != 0
+}
+
+func (m *AVMasteringDisplayMetadata) HasLuminance() bool {
+	return m.has_luminance != 0
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PROPERTIES - CONTENT LIGHT LEVEL
+
+// Return the maximum content light level (MaxCLL) in cd/m²
+func (m *AVContentLightMetadata) MaxContentLightLevel() int {
+	return int(m.MaxCLL)
+}
+
+// Return the maximum frame-average light level (MaxFALL) in cd/m²
+func (m *AVContentLightMetadata) MaxFrameAverageLightLevel() int {
+	return int(m.MaxFALL)
+}
+
+func (m *AVContentLightMetadata) SetMaxContentLightLevel(v int) {
+	m.MaxCLL = C.uint(v)
+}
+
+func (m *AVContentLightMetadata) SetMaxFrameAverageLightLevel(v int) {
+	m.MaxFALL = C.uint(v)
+}
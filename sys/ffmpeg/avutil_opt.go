@@ -15,6 +15,12 @@ import (
 #include <libavutil/avutil.h>
 #include <libavutil/opt.h>
 #include <stdlib.h>
+
+// Unsigned and array options are not in FFmpeg 6.1
+#if LIBAVUTIL_VERSION_MAJOR < 59
+#define AV_OPT_TYPE_UINT 20
+#define AV_OPT_TYPE_FLAG_ARRAY (1 << 16)
+#endif
 */
 import "C"
 
@@ -13,6 +13,79 @@ import (
 #cgo pkg-config: libavutil
 #include <libavutil/avutil.h>
 #include <libavutil/pixdesc.h>
+
+// Pixel formats are AV_PIX_FMT_NONE before the libavutil version which
+// added them
+#if LIBAVUTIL_VERSION_INT < AV_VERSION_INT(58, 15, 100)
+#define AV_PIX_FMT_GBRAP14BE AV_PIX_FMT_NONE
+#define AV_PIX_FMT_GBRAP14LE AV_PIX_FMT_NONE
+#endif
+#if LIBAVUTIL_VERSION_INT < AV_VERSION_INT(58, 36, 100)
+#define AV_PIX_FMT_D3D12 AV_PIX_FMT_NONE
+#endif
+#if LIBAVUTIL_VERSION_INT < AV_VERSION_INT(59, 34, 100)
+#define AV_PIX_FMT_AYUV AV_PIX_FMT_NONE
+#define AV_PIX_FMT_UYVA AV_PIX_FMT_NONE
+#define AV_PIX_FMT_VYU444 AV_PIX_FMT_NONE
+#define AV_PIX_FMT_V30XBE AV_PIX_FMT_NONE
+#define AV_PIX_FMT_V30XLE AV_PIX_FMT_NONE
+#endif
+#if LIBAVUTIL_VERSION_INT < AV_VERSION_INT(59, 36, 100)
+#define AV_PIX_FMT_RGBF16BE AV_PIX_FMT_NONE
+#define AV_PIX_FMT_RGBF16LE AV_PIX_FMT_NONE
+#endif
+#if LIBAVUTIL_VERSION_INT < AV_VERSION_INT(59, 41, 100)
+#define AV_PIX_FMT_RGBA128BE AV_PIX_FMT_NONE
+#define AV_PIX_FMT_RGBA128LE AV_PIX_FMT_NONE
+#define AV_PIX_FMT_RGB96BE AV_PIX_FMT_NONE
+#define AV_PIX_FMT_RGB96LE AV_PIX_FMT_NONE
+#endif
+#if LIBAVUTIL_VERSION_INT < AV_VERSION_INT(59, 42, 100)
+#define AV_PIX_FMT_Y216BE AV_PIX_FMT_NONE
+#define AV_PIX_FMT_Y216LE AV_PIX_FMT_NONE
+#define AV_PIX_FMT_XV48BE AV_PIX_FMT_NONE
+#define AV_PIX_FMT_XV48LE AV_PIX_FMT_NONE
+#endif
+#if LIBAVUTIL_VERSION_INT < AV_VERSION_INT(59, 47, 100)
+#define AV_PIX_FMT_GBRPF16BE AV_PIX_FMT_NONE
+#define AV_PIX_FMT_GBRPF16LE AV_PIX_FMT_NONE
+#define AV_PIX_FMT_GBRAPF16BE AV_PIX_FMT_NONE
+#define AV_PIX_FMT_GBRAPF16LE AV_PIX_FMT_NONE
+#endif
+#if LIBAVUTIL_VERSION_INT < AV_VERSION_INT(59, 48, 100)
+#define AV_PIX_FMT_GRAYF16BE AV_PIX_FMT_NONE
+#define AV_PIX_FMT_GRAYF16LE AV_PIX_FMT_NONE
+#endif
+#if LIBAVUTIL_VERSION_INT < AV_VERSION_INT(59, 52, 100)
+#define AV_PIX_FMT_AMF_SURFACE AV_PIX_FMT_NONE
+#endif
+#if LIBAVUTIL_VERSION_INT < AV_VERSION_INT(59, 53, 100)
+#define AV_PIX_FMT_GRAY32BE AV_PIX_FMT_NONE
+#define AV_PIX_FMT_GRAY32LE AV_PIX_FMT_NONE
+#endif
+#if LIBAVUTIL_VERSION_INT < AV_VERSION_INT(59, 54, 100)
+#define AV_PIX_FMT_YAF32BE AV_PIX_FMT_NONE
+#define AV_PIX_FMT_YAF32LE AV_PIX_FMT_NONE
+#define AV_PIX_FMT_YAF16BE AV_PIX_FMT_NONE
+#define AV_PIX_FMT_YAF16LE AV_PIX_FMT_NONE
+#endif
+#if LIBAVUTIL_VERSION_INT < AV_VERSION_INT(59, 57, 100)
+#define AV_PIX_FMT_GBRAP32BE AV_PIX_FMT_NONE
+#define AV_PIX_FMT_GBRAP32LE AV_PIX_FMT_NONE
+#endif
+#if LIBAVUTIL_VERSION_INT < AV_VERSION_INT(60, 3, 100)
+#define AV_PIX_FMT_YUV444P10MSBBE AV_PIX_FMT_NONE
+#define AV_PIX_FMT_YUV444P10MSBLE AV_PIX_FMT_NONE
+#define AV_PIX_FMT_YUV444P12MSBBE AV_PIX_FMT_NONE
+#define AV_PIX_FMT_YUV444P12MSBLE AV_PIX_FMT_NONE
+#define AV_PIX_FMT_GBRP10MSBBE AV_PIX_FMT_NONE
+#define AV_PIX_FMT_GBRP10MSBLE AV_PIX_FMT_NONE
+#define AV_PIX_FMT_GBRP12MSBBE AV_PIX_FMT_NONE
+#define AV_PIX_FMT_GBRP12MSBLE AV_PIX_FMT_NONE
+#endif
+#if LIBAVUTIL_VERSION_INT < AV_VERSION_INT(60, 5, 100)
+#define AV_PIX_FMT_OHCODEC AV_PIX_FMT_NONE
+#endif
 */
 import "C"
 
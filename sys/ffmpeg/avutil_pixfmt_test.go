@@ -524,3 +524,76 @@ func Test_avutil_pixfmt_enumeration_completeness(t *testing.T) {
 
 	t.Logf("Enumerated %d formats with %d unique names", len(allFormats), len(names))
 }
+
+////////////////////////////////////////////////////////////////////////////////
+// TEST VERSION GUARDS
+
+func Test_avutil_pixfmt_version_guards(t *testing.T) {
+	assert := assert.New(t)
+
+	// Pixel formats are AV_PIX_FMT_NONE before the libavutil version which
+	// added them, and are otherwise distinct formats with a name
+	version := func(major, minor, micro uint) uint {
+		return major<<16 | minor<<8 | micro
+	}
+	tests := []struct {
+		format  AVPixelFormat
+		version uint
+	}{
+		{AV_PIX_FMT_GBRAP14BE, version(58, 15, 100)},
+		{AV_PIX_FMT_GBRAP14LE, version(58, 15, 100)},
+		{AV_PIX_FMT_D3D12, version(58, 36, 100)},
+		{AV_PIX_FMT_AYUV, version(59, 34, 100)},
+		{AV_PIX_FMT_UYVA, version(59, 34, 100)},
+		{AV_PIX_FMT_VYU444, version(59, 34, 100)},
+		{AV_PIX_FMT_V30XBE, version(59, 34, 100)},
+		{AV_PIX_FMT_V30XLE, version(59, 34, 100)},
+		{AV_PIX_FMT_RGBF16BE, version(59, 36, 100)},
+		{AV_PIX_FMT_RGBF16LE, version(59, 36, 100)},
+		{AV_PIX_FMT_RGBA128BE, version(59, 41, 100)},
+		{AV_PIX_FMT_RGBA128LE, version(59, 41, 100)},
+		{AV_PIX_FMT_RGB96BE, version(59, 41, 100)},
+		{AV_PIX_FMT_RGB96LE, version(59, 41, 100)},
+		{AV_PIX_FMT_Y216BE, version(59, 42, 100)},
+		{AV_PIX_FMT_Y216LE, version(59, 42, 100)},
+		{AV_PIX_FMT_XV48BE, version(59, 42, 100)},
+		{AV_PIX_FMT_XV48LE, version(59, 42, 100)},
+		{AV_PIX_FMT_GBRPF16BE, version(59, 47, 100)},
+		{AV_PIX_FMT_GBRPF16LE, version(59, 47, 100)},
+		{AV_PIX_FMT_GBRAPF16BE, version(59, 47, 100)},
+		{AV_PIX_FMT_GBRAPF16LE, version(59, 47, 100)},
+		{AV_PIX_FMT_GRAYF16BE, version(59, 48, 100)},
+		{AV_PIX_FMT_GRAYF16LE, version(59, 48, 100)},
+		{AV_PIX_FMT_AMF_SURFACE, version(59, 52, 100)},
+		{AV_PIX_FMT_GRAY32BE, version(59, 53, 100)},
+		{AV_PIX_FMT_GRAY32LE, version(59, 53, 100)},
+		{AV_PIX_FMT_YAF32BE, version(59, 54, 100)},
+		{AV_PIX_FMT_YAF32LE, version(59, 54, 100)},
+		{AV_PIX_FMT_YAF16BE, version(59, 54, 100)},
+		{AV_PIX_FMT_YAF16LE, version(59, 54, 100)},
+		{AV_PIX_FMT_GBRAP32BE, version(59, 57, 100)},
+		{AV_PIX_FMT_GBRAP32LE, version(59, 57, 100)},
+		{AV_PIX_FMT_YUV444P10MSBBE, version(60, 3, 100)},
+		{AV_PIX_FMT_YUV444P10MSBLE, version(60, 3, 100)},
+		{AV_PIX_FMT_YUV444P12MSBBE, version(60, 3, 100)},
+		{AV_PIX_FMT_YUV444P12MSBLE, version(60, 3, 100)},
+		{AV_PIX_FMT_GBRP10MSBBE, version(60, 3, 100)},
+		{AV_PIX_FMT_GBRP10MSBLE, version(60, 3, 100)},
+		{AV_PIX_FMT_GBRP12MSBBE, version(60, 3, 100)},
+		{AV_PIX_FMT_GBRP12MSBLE, version(60, 3, 100)},
+		{AV_PIX_FMT_OHCODEC, version(60, 5, 100)},
+	}
+
+	seen := make(map[AVPixelFormat]bool)
+	for _, tc := range tests {
+		if AVUtil_version() < tc.version {
+			assert.Equal(AV_PIX_FMT_NONE, tc.format)
+			continue
+		}
+		name := AVUtil_get_pix_fmt_name(tc.format)
+		assert.NotEqual(AV_PIX_FMT_NONE, tc.format, "format added in %x", tc.version)
+		assert.NotEmpty(name, "format %d", tc.format)
+		assert.False(seen[tc.format], "format %q is not distinct", name)
+		seen[tc.format] = true
+	}
+}
@@ -0,0 +1,108 @@
+package ffmpeg
+
+import (
+	"encoding/json"
+	"math"
+	"unsafe"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// CGO
+
+/*
+#cgo pkg-config: libavutil
+#include <libavutil/replaygain.h>
+*/
+import "C"
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type AVReplayGain C.AVReplayGain
+
+////////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (rg *AVReplayGain) MarshalJSON() ([]byte, error) {
+	type jsonAVReplayGain struct {
+		TrackGain *float64 `json:"track_gain,omitempty"`
+		TrackPeak *float64 `json:"track_peak,omitempty"`
+		AlbumGain *float64 `json:"album_gain,omitempty"`
+		AlbumPeak *float64 `json:"album_peak,omitempty"`
+	}
+	if rg == nil {
+		return json.Marshal(nil)
+	}
+	var result jsonAVReplayGain
+	if v, ok := rg.TrackGain(); ok {
+		result.TrackGain = &v
+	}
+	if v, ok := rg.TrackPeak(); ok {
+		result.TrackPeak = &v
+	}
+	if v, ok := rg.AlbumGain(); ok {
+		result.AlbumGain = &v
+	}
+	if v, ok := rg.AlbumPeak(); ok {
+		result.AlbumPeak = &v
+	}
+	return json.Marshal(result)
+}
+
+func (rg *AVReplayGain) String() string {
+	return marshalToString(rg)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Return the replay gain in side data, or nil if the data is too short. The
+// replay gain is owned by the side data.
+func AVUtil_replaygain(data []byte) *AVReplayGain {
+	if len(data) < int(unsafe.Sizeof(AVReplayGain{})) {
+		return nil
+	}
+	return (*AVReplayGain)(unsafe.Pointer(&data[0]))
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PROPERTIES
+
+// Return the track gain in dB, and false if it is unknown
+func (rg *AVReplayGain) TrackGain() (float64, bool) {
+	return replayGain(int32(rg.track_gain))
+}
+
+// Return the track peak, where 1.0 is full scale, and false if it is unknown
+func (rg *AVReplayGain) TrackPeak() (float64, bool) {
+	return replayPeak(uint32(rg.track_peak))
+}
+
+// Return the album gain in dB, and false if it is unknown
+func (rg *AVReplayGain) AlbumGain() (float64, bool) {
+	return replayGain(int32(rg.album_gain))
+}
+
+// Return the album peak, where 1.0 is full scale, and false if it is unknown
+func (rg *AVReplayGain) AlbumPeak() (float64, bool) {
+	return replayPeak(uint32(rg.album_peak))
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE
+
+// Gains are in microbels, with INT32_MIN when unknown
+func replayGain(v int32) (float64, bool) {
+	if v == math.MinInt32 {
+		return 0, false
+	}
+	return float64(v) / 100000, true
+}
+
+// Peaks are in units of 1/100000 of full scale, with zero when unknown
+func replayPeak(v uint32) (float64, bool) {
+	if v == 0 {
+		return 0, false
+	}
+	return float64(v) / 100000, true
+}
@@ -0,0 +1,99 @@
+package ffmpeg
+
+import (
+	"encoding/json"
+	"unsafe"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// CGO
+
+/*
+#cgo pkg-config: libavutil
+#include <libavutil/spherical.h>
+*/
+import "C"
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type (
+	AVSphericalMapping    C.AVSphericalMapping
+	AVSphericalProjection C.enum_AVSphericalProjection
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// CONSTANTS
+
+const (
+	AV_SPHERICAL_EQUIRECTANGULAR      AVSphericalProjection = C.AV_SPHERICAL_EQUIRECTANGULAR
+	AV_SPHERICAL_CUBEMAP              AVSphericalProjection = C.AV_SPHERICAL_CUBEMAP
+	AV_SPHERICAL_EQUIRECTANGULAR_TILE AVSphericalProjection = C.AV_SPHERICAL_EQUIRECTANGULAR_TILE
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (p AVSphericalProjection) String() string {
+	return C.GoString(C.av_spherical_projection_name(C.enum_AVSphericalProjection(p)))
+}
+
+func (p AVSphericalProjection) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+func (s *AVSphericalMapping) MarshalJSON() ([]byte, error) {
+	type jsonAVSphericalMapping struct {
+		Projection AVSphericalProjection `json:"projection"`
+		Yaw        float64               `json:"yaw"`
+		Pitch      float64               `json:"pitch"`
+		Roll       float64               `json:"roll"`
+	}
+	if s == nil {
+		return json.Marshal(nil)
+	}
+	return json.Marshal(jsonAVSphericalMapping{
+		Projection: s.Projection(),
+		Yaw:        s.Yaw(),
+		Pitch:      s.Pitch(),
+		Roll:       s.Roll(),
+	})
+}
+
+func (s *AVSphericalMapping) String() string {
+	return marshalToString(s)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Return the spherical mapping in side data, or nil if the data is too short.
+// The mapping is owned by the side data.
+func AVUtil_spherical(data []byte) *AVSphericalMapping {
+	if len(data) < int(unsafe.Sizeof(AVSphericalMapping{})) {
+		return nil
+	}
+	return (*AVSphericalMapping)(unsafe.Pointer(&data[0]))
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PROPERTIES
+
+func (s *AVSphericalMapping) Projection() AVSphericalProjection {
+	return AVSphericalProjection(s.projection)
+}
+
+// Return the rotation around the up vector in degrees
+func (s *AVSphericalMapping) Yaw() float64 {
+	return float64(s.yaw) / (1 << 16)
+}
+
+// Return the rotation around the right vector in degrees
+func (s *AVSphericalMapping) Pitch() float64 {
+	return float64(s.pitch) / (1 << 16)
+}
+
+// Return the rotation around the forward vector in degrees
+func (s *AVSphericalMapping) Roll() float64 {
+	return float64(s.roll) / (1 << 16)
+}
@@ -0,0 +1,119 @@
+package ffmpeg
+
+import (
+	"encoding/json"
+	"unsafe"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// CGO
+
+/*
+#cgo pkg-config: libavutil
+#include <libavutil/avutil.h>
+#include <libavutil/stereo3d.h>
+
+// The unspecified stereo type is not in FFmpeg 6.1
+#if LIBAVUTIL_VERSION_MAJOR < 59
+#define AV_STEREO3D_UNSPEC (AV_STEREO3D_COLUMNS + 1)
+#endif
+*/
+import "C"
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type (
+	AVStereo3D     C.AVStereo3D
+	AVStereo3DType C.enum_AVStereo3DType
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// CONSTANTS
+
+const (
+	AV_STEREO3D_2D                  AVStereo3DType = C.AV_STEREO3D_2D
+	AV_STEREO3D_SIDEBYSIDE          AVStereo3DType = C.AV_STEREO3D_SIDEBYSIDE
+	AV_STEREO3D_TOPBOTTOM           AVStereo3DType = C.AV_STEREO3D_TOPBOTTOM
+	AV_STEREO3D_FRAMESEQUENCE       AVStereo3DType = C.AV_STEREO3D_FRAMESEQUENCE
+	AV_STEREO3D_CHECKERBOARD        AVStereo3DType = C.AV_STEREO3D_CHECKERBOARD
+	AV_STEREO3D_SIDEBYSIDE_QUINCUNX AVStereo3DType = C.AV_STEREO3D_SIDEBYSIDE_QUINCUNX
+	AV_STEREO3D_LINES               AVStereo3DType = C.AV_STEREO3D_LINES
+	AV_STEREO3D_COLUMNS             AVStereo3DType = C.AV_STEREO3D_COLUMNS
+	AV_STEREO3D_UNSPEC              AVStereo3DType = C.AV_STEREO3D_UNSPEC
+)
+
+const (
+	AV_STEREO3D_FLAG_INVERT = C.AV_STEREO3D_FLAG_INVERT // Views are swapped
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (t AVStereo3DType) String() string {
+	return C.GoString(C.av_stereo3d_type_name(C.uint(t)))
+}
+
+func (t AVStereo3DType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+func (s *AVStereo3D) MarshalJSON() ([]byte, error) {
+	type jsonAVStereo3D struct {
+		Type     AVStereo3DType `json:"type"`
+		Inverted bool           `json:"inverted,omitempty"`
+	}
+	if s == nil {
+		return json.Marshal(nil)
+	}
+	return json.Marshal(jsonAVStereo3D{
+		Type:     s.Type(),
+		Inverted: s.Inverted(),
+	})
+}
+
+func (s *AVStereo3D) String() string {
+	return marshalToString(s)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Return the stereoscopic information in side data, or nil if the data is
+// too short. The information is owned by the side data.
+func AVUtil_stereo3d(data []byte) *AVStereo3D {
+	if len(data) < int(unsafe.Sizeof(AVStereo3D{})) {
+		return nil
+	}
+	return (*AVStereo3D)(unsafe.Pointer(&data[0]))
+}
+
+// Add stereoscopic information to a frame and return it
+func AVUtil_stereo3d_create_side_data(frame *AVFrame) *AVStereo3D {
+	return (*AVStereo3D)(C.av_stereo3d_create_side_data((*C.struct_AVFrame)(frame)))
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PROPERTIES
+
+// Return how the views are packed within the video
+func (s *AVStereo3D) Type() AVStereo3DType {
+	return AVStereo3DType(s._type)
+}
+
+func (s *AVStereo3D) SetType(t AVStereo3DType) {
+	s._type = C.enum_AVStereo3DType(t)
+}
+
+func (s *AVStereo3D) Flags() int {
+	return int(s.flags)
+}
+
+func (s *AVStereo3D) SetFlags(flags int) {
+	s.flags = C.int(flags)
+}
+
+// Return true if the left and right views are swapped
+func (s *AVStereo3D) Inverted() bool {
+	return s.flags&AV_STEREO3D_FLAG_INVERT != 0
+}
@@ -5,7 +5,15 @@ package ffmpeg
 
 /*
 #cgo pkg-config: libavutil
+#cgo ffmpeg61 CPPFLAGS: -DAVUTIL_REQUIRED_VERSION_MAJOR=58
+#cgo ffmpeg71 CPPFLAGS: -DAVUTIL_REQUIRED_VERSION_MAJOR=59
+#cgo ffmpeg80 CPPFLAGS: -DAVUTIL_REQUIRED_VERSION_MAJOR=60
 #include <libavutil/avutil.h>
+
+// Fail the build when a version tag does not match the FFmpeg headers
+#if defined(AVUTIL_REQUIRED_VERSION_MAJOR) && LIBAVUTIL_VERSION_MAJOR != AVUTIL_REQUIRED_VERSION_MAJOR
+#error "FFmpeg version does not match the ffmpeg61, ffmpeg71 or ffmpeg80 build tag"
+#endif
 */
 import "C"
 
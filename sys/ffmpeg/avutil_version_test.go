@@ -0,0 +1,37 @@
+package ffmpeg
+
+import (
+	"testing"
+
+)
+
+func Test_avutil_version_000(t *testing.T) {
+	t.Log("avutil_version=", AVUtil_version())
+}
+
+func Test_avutil_version_001(t *testing.T) {
+	t.Log("avutil_configuration=", AVUtil_configuration())
+}
+
+func Test_avutil_version_002(t *testing.T) {
+	t.Log("avutil_license=", AVUtil_license())
+}
+
+func Test_avutil_version_003(t *testing.T) {
+	// The bindings support FFmpeg 6.1, 7.1 and 8.0
+	if major := AVUtil_version() >> 16; major < 58 || major > 60 {
+		t.Errorf("unsupported libavutil major version %d", major)
+	}
+}
+
+func Test_avutil_version_004(t *testing.T) {
+	// Newer pixel formats are AV_PIX_FMT_NONE unless the libraries know them
+	for _, pixfmt := range []AVPixelFormat{AV_PIX_FMT_D3D12, AV_PIX_FMT_OHCODEC, AV_PIX_FMT_YAF16LE} {
+		if pixfmt == AV_PIX_FMT_NONE {
+			continue
+		}
+		if name := AVUtil_get_pix_fmt_name(pixfmt); name == "" {
+			t.Errorf("pixel format %d has no name", pixfmt)
+		}
+	}
+}
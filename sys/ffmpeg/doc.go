@@ -0,0 +1,15 @@
+/*
+The low-level ffmpeg bindings for ffmpeg versions 6.1, 7.1 and 8.0. It's more
+likely you would use the higher-level package github.com/mutablelogic/go-media/pkg/ffmpeg
+which provides a more idiomatic interface to ffmpeg.
+
+The bindings are built against the FFmpeg libraries found with pkg-config.
+Constants which are newer than those libraries are defined with values which
+the libraries never return, and pixel formats which they do not support are
+AV_PIX_FMT_NONE. To make sure a build uses the expected libraries, build with
+one of the ffmpeg61, ffmpeg71 or ffmpeg80 tags, which fail the build when
+the FFmpeg headers are a different version.
+
+Ref: https://ffmpeg.org/doxygen/8.0/index.html
+*/
+package ffmpeg
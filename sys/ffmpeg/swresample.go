@@ -0,0 +1,27 @@
+package ffmpeg
+
+////////////////////////////////////////////////////////////////////////////////
+// CGO
+
+/*
+#cgo pkg-config: libswresample
+#include <libswresample/swresample.h>
+*/
+import "C"
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type (
+	SWRContext C.struct_SwrContext
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (ctx *SWRContext) String() string {
+	if ctx == nil {
+		return "<nil>"
+	}
+	return "<SWRContext>"
+}
@@ -127,6 +127,40 @@ func cAVStreamSlice(p unsafe.Pointer, sz C.int) []*AVStream {
 	return (*[1 << 30]*AVStream)(p)[:int(sz)]
 }
 
+func cAVChapterSlice(p unsafe.Pointer, sz C.int) []*AVChapter {
+	if p == nil || sz <= 0 {
+		return nil
+	}
+	return (*[1 << 30]*AVChapter)(p)[:int(sz)]
+}
+
+func cAVProgramSlice(p unsafe.Pointer, sz C.int) []*AVProgram {
+	if p == nil || sz <= 0 {
+		return nil
+	}
+	return (*[1 << 30]*AVProgram)(p)[:int(sz)]
+}
+
+// Convert a C array of side data structures to a slice of pointers into it
+func cAVPacketSideDataSlice(p unsafe.Pointer, sz C.int) []*AVPacketSideData {
+	if p == nil || sz <= 0 {
+		return nil
+	}
+	entries := unsafe.Slice((*AVPacketSideData)(p), int(sz))
+	result := make([]*AVPacketSideData, len(entries))
+	for i := range entries {
+		result[i] = &entries[i]
+	}
+	return result
+}
+
+func cAVFrameSideDataSlice(p unsafe.Pointer, sz C.int) []*AVFrameSideData {
+	if p == nil || sz <= 0 {
+		return nil
+	}
+	return (*[1 << 30]*AVFrameSideData)(p)[:int(sz)]
+}
+
 func cAVDeviceInfoSlice(p unsafe.Pointer, sz C.int) []*AVDeviceInfo {
 	if p == nil || sz <= 0 {
 		return nil
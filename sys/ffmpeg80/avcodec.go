@@ -0,0 +1,213 @@
+package ffmpeg
+
+import (
+	// Packages
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type (
+	AVBitStreamFilter    = ff.AVBitStreamFilter
+	AVBSFContext         = ff.AVBSFContext
+	AVCodec              = ff.AVCodec
+	AVCodecCap           = ff.AVCodecCap
+	AVCodecContext       = ff.AVCodecContext
+	AVCodecFlag          = ff.AVCodecFlag
+	AVCodecFlag2         = ff.AVCodecFlag2
+	AVCodecID            = ff.AVCodecID
+	AVCodecParameters    = ff.AVCodecParameters
+	AVProfile            = ff.AVProfile
+	AVPacket             = ff.AVPacket
+	AVPacketSideData     = ff.AVPacketSideData
+	AVPacketSideDataType = ff.AVPacketSideDataType
+	AVCodecParser        = ff.AVCodecParser
+	AVCodecParserContext = ff.AVCodecParserContext
+	AVSubtitle           = ff.AVSubtitle
+	AVSubtitleRect       = ff.AVSubtitleRect
+	AVSubtitleType       = ff.AVSubtitleType
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// CONSTANTS
+
+const (
+	AV_CODEC_ID_NONE                       = ff.AV_CODEC_ID_NONE
+	AV_CODEC_ID_MP2                        = ff.AV_CODEC_ID_MP2
+	AV_CODEC_ID_H264                       = ff.AV_CODEC_ID_H264
+	AV_CODEC_ID_HEVC                       = ff.AV_CODEC_ID_HEVC
+	AV_CODEC_ID_AAC                        = ff.AV_CODEC_ID_AAC
+	AV_CODEC_ID_MPEG1VIDEO                 = ff.AV_CODEC_ID_MPEG1VIDEO
+	AV_CODEC_ID_MPEG2VIDEO                 = ff.AV_CODEC_ID_MPEG2VIDEO
+	AV_CODEC_ID_MJPEG                      = ff.AV_CODEC_ID_MJPEG
+	AV_CODEC_ID_PNG                        = ff.AV_CODEC_ID_PNG
+	AV_CODEC_ID_GIF                        = ff.AV_CODEC_ID_GIF
+	AV_CODEC_ID_BMP                        = ff.AV_CODEC_ID_BMP
+	AV_CODEC_ID_WEBP                       = ff.AV_CODEC_ID_WEBP
+	AV_CODEC_ID_FIRST_SUBTITLE             = ff.AV_CODEC_ID_FIRST_SUBTITLE
+	AV_CODEC_ID_DVD_SUBTITLE               = ff.AV_CODEC_ID_DVD_SUBTITLE
+	AV_CODEC_ID_DVB_SUBTITLE               = ff.AV_CODEC_ID_DVB_SUBTITLE
+	AV_CODEC_ID_TEXT                       = ff.AV_CODEC_ID_TEXT
+	AV_CODEC_ID_XSUB                       = ff.AV_CODEC_ID_XSUB
+	AV_CODEC_ID_SSA                        = ff.AV_CODEC_ID_SSA
+	AV_CODEC_ID_MOV_TEXT                   = ff.AV_CODEC_ID_MOV_TEXT
+	AV_CODEC_ID_HDMV_PGS_SUBTITLE          = ff.AV_CODEC_ID_HDMV_PGS_SUBTITLE
+	AV_CODEC_ID_DVB_TELETEXT               = ff.AV_CODEC_ID_DVB_TELETEXT
+	AV_CODEC_ID_SRT                        = ff.AV_CODEC_ID_SRT
+	AV_CODEC_ID_MICRODVD                   = ff.AV_CODEC_ID_MICRODVD
+	AV_CODEC_ID_EIA_608                    = ff.AV_CODEC_ID_EIA_608
+	AV_CODEC_ID_JACOSUB                    = ff.AV_CODEC_ID_JACOSUB
+	AV_CODEC_ID_SAMI                       = ff.AV_CODEC_ID_SAMI
+	AV_CODEC_ID_REALTEXT                   = ff.AV_CODEC_ID_REALTEXT
+	AV_CODEC_ID_STL                        = ff.AV_CODEC_ID_STL
+	AV_CODEC_ID_SUBRIP                     = ff.AV_CODEC_ID_SUBRIP
+	AV_CODEC_ID_SUBVIEWER1                 = ff.AV_CODEC_ID_SUBVIEWER1
+	AV_CODEC_ID_SUBVIEWER                  = ff.AV_CODEC_ID_SUBVIEWER
+	AV_CODEC_ID_SUBRIP_WEBVTT              = ff.AV_CODEC_ID_SUBRIP_WEBVTT
+	AV_CODEC_ID_MPL2                       = ff.AV_CODEC_ID_MPL2
+	AV_CODEC_ID_VPLAYER                    = ff.AV_CODEC_ID_VPLAYER
+	AV_CODEC_ID_PJS                        = ff.AV_CODEC_ID_PJS
+	AV_CODEC_ID_ASS                        = ff.AV_CODEC_ID_ASS
+	AV_CODEC_ID_HDMV_TEXT_SUBTITLE         = ff.AV_CODEC_ID_HDMV_TEXT_SUBTITLE
+	AV_CODEC_ID_TTML                       = ff.AV_CODEC_ID_TTML
+	AV_CODEC_ID_ARIB_CAPTION               = ff.AV_CODEC_ID_ARIB_CAPTION
+	AV_CODEC_ID_WEBVTT                     = ff.AV_CODEC_ID_WEBVTT
+	AV_INPUT_BUFFER_PADDING_SIZE           = ff.AV_INPUT_BUFFER_PADDING_SIZE
+	FF_QP2LAMBDA                           = ff.FF_QP2LAMBDA
+	AV_CODEC_FLAG_UNALIGNED                = ff.AV_CODEC_FLAG_UNALIGNED
+	AV_CODEC_FLAG_QSCALE                   = ff.AV_CODEC_FLAG_QSCALE
+	AV_CODEC_FLAG_4MV                      = ff.AV_CODEC_FLAG_4MV
+	AV_CODEC_FLAG_OUTPUT_CORRUPT           = ff.AV_CODEC_FLAG_OUTPUT_CORRUPT
+	AV_CODEC_FLAG_QPEL                     = ff.AV_CODEC_FLAG_QPEL
+	AV_CODEC_FLAG_RECON_FRAME              = ff.AV_CODEC_FLAG_RECON_FRAME
+	AV_CODEC_FLAG_COPY_OPAQUE              = ff.AV_CODEC_FLAG_COPY_OPAQUE
+	AV_CODEC_FLAG_FRAME_DURATION           = ff.AV_CODEC_FLAG_FRAME_DURATION
+	AV_CODEC_FLAG_PASS1                    = ff.AV_CODEC_FLAG_PASS1
+	AV_CODEC_FLAG_PASS2                    = ff.AV_CODEC_FLAG_PASS2
+	AV_CODEC_FLAG_LOOP_FILTER              = ff.AV_CODEC_FLAG_LOOP_FILTER
+	AV_CODEC_FLAG_GRAY                     = ff.AV_CODEC_FLAG_GRAY
+	AV_CODEC_FLAG_PSNR                     = ff.AV_CODEC_FLAG_PSNR
+	AV_CODEC_FLAG_INTERLACED_DCT           = ff.AV_CODEC_FLAG_INTERLACED_DCT
+	AV_CODEC_FLAG_LOW_DELAY                = ff.AV_CODEC_FLAG_LOW_DELAY
+	AV_CODEC_FLAG_GLOBAL_HEADER            = ff.AV_CODEC_FLAG_GLOBAL_HEADER
+	AV_CODEC_FLAG_BITEXACT                 = ff.AV_CODEC_FLAG_BITEXACT
+	AV_CODEC_FLAG_AC_PRED                  = ff.AV_CODEC_FLAG_AC_PRED
+	AV_CODEC_FLAG_INTERLACED_ME            = ff.AV_CODEC_FLAG_INTERLACED_ME
+	AV_CODEC_FLAG_CLOSED_GOP               = ff.AV_CODEC_FLAG_CLOSED_GOP
+	AV_CODEC_FLAG2_FAST                    = ff.AV_CODEC_FLAG2_FAST
+	AV_CODEC_FLAG2_NO_OUTPUT               = ff.AV_CODEC_FLAG2_NO_OUTPUT
+	AV_CODEC_FLAG2_LOCAL_HEADER            = ff.AV_CODEC_FLAG2_LOCAL_HEADER
+	AV_CODEC_FLAG2_CHUNKS                  = ff.AV_CODEC_FLAG2_CHUNKS
+	AV_CODEC_FLAG2_IGNORE_CROP             = ff.AV_CODEC_FLAG2_IGNORE_CROP
+	AV_CODEC_FLAG2_SHOW_ALL                = ff.AV_CODEC_FLAG2_SHOW_ALL
+	AV_CODEC_FLAG2_EXPORT_MVS              = ff.AV_CODEC_FLAG2_EXPORT_MVS
+	AV_CODEC_FLAG2_SKIP_MANUAL             = ff.AV_CODEC_FLAG2_SKIP_MANUAL
+	AV_CODEC_FLAG2_RO_FLUSH_NOOP           = ff.AV_CODEC_FLAG2_RO_FLUSH_NOOP
+	AV_CODEC_FLAG2_ICC_PROFILES            = ff.AV_CODEC_FLAG2_ICC_PROFILES
+	AV_CODEC_CAP_NONE                      = ff.AV_CODEC_CAP_NONE
+	AV_CODEC_CAP_DRAW_HORIZ_BAND           = ff.AV_CODEC_CAP_DRAW_HORIZ_BAND
+	AV_CODEC_CAP_DR1                       = ff.AV_CODEC_CAP_DR1
+	AV_CODEC_CAP_DELAY                     = ff.AV_CODEC_CAP_DELAY
+	AV_CODEC_CAP_SMALL_LAST_FRAME          = ff.AV_CODEC_CAP_SMALL_LAST_FRAME
+	AV_CODEC_CAP_EXPERIMENTAL              = ff.AV_CODEC_CAP_EXPERIMENTAL
+	AV_CODEC_CAP_CHANNEL_CONF              = ff.AV_CODEC_CAP_CHANNEL_CONF
+	AV_CODEC_CAP_FRAME_THREADS             = ff.AV_CODEC_CAP_FRAME_THREADS
+	AV_CODEC_CAP_SLICE_THREADS             = ff.AV_CODEC_CAP_SLICE_THREADS
+	AV_CODEC_CAP_PARAM_CHANGE              = ff.AV_CODEC_CAP_PARAM_CHANGE
+	AV_CODEC_CAP_OTHER_THREADS             = ff.AV_CODEC_CAP_OTHER_THREADS
+	AV_CODEC_CAP_VARIABLE_FRAME_SIZE       = ff.AV_CODEC_CAP_VARIABLE_FRAME_SIZE
+	AV_CODEC_CAP_AVOID_PROBING             = ff.AV_CODEC_CAP_AVOID_PROBING
+	AV_CODEC_CAP_HARDWARE                  = ff.AV_CODEC_CAP_HARDWARE
+	AV_CODEC_CAP_HYBRID                    = ff.AV_CODEC_CAP_HYBRID
+	AV_CODEC_CAP_ENCODER_REORDERED_OPAQUE  = ff.AV_CODEC_CAP_ENCODER_REORDERED_OPAQUE
+	AV_CODEC_CAP_ENCODER_FLUSH             = ff.AV_CODEC_CAP_ENCODER_FLUSH
+	AV_CODEC_CAP_ENCODER_RECON_FRAME       = ff.AV_CODEC_CAP_ENCODER_RECON_FRAME
+	AV_CODEC_CAP_MAX                       = ff.AV_CODEC_CAP_MAX
+	AV_PKT_FLAG_KEY                        = ff.AV_PKT_FLAG_KEY
+	AV_PKT_FLAG_CORRUPT                    = ff.AV_PKT_FLAG_CORRUPT
+	AV_PKT_DATA_PALETTE                    = ff.AV_PKT_DATA_PALETTE
+	AV_PKT_DATA_NEW_EXTRADATA              = ff.AV_PKT_DATA_NEW_EXTRADATA
+	AV_PKT_DATA_PARAM_CHANGE               = ff.AV_PKT_DATA_PARAM_CHANGE
+	AV_PKT_DATA_REPLAYGAIN                 = ff.AV_PKT_DATA_REPLAYGAIN
+	AV_PKT_DATA_DISPLAYMATRIX              = ff.AV_PKT_DATA_DISPLAYMATRIX
+	AV_PKT_DATA_STEREO3D                   = ff.AV_PKT_DATA_STEREO3D
+	AV_PKT_DATA_AUDIO_SERVICE_TYPE         = ff.AV_PKT_DATA_AUDIO_SERVICE_TYPE
+	AV_PKT_DATA_CPB_PROPERTIES             = ff.AV_PKT_DATA_CPB_PROPERTIES
+	AV_PKT_DATA_SKIP_SAMPLES               = ff.AV_PKT_DATA_SKIP_SAMPLES
+	AV_PKT_DATA_STRINGS_METADATA           = ff.AV_PKT_DATA_STRINGS_METADATA
+	AV_PKT_DATA_METADATA_UPDATE            = ff.AV_PKT_DATA_METADATA_UPDATE
+	AV_PKT_DATA_MASTERING_DISPLAY_METADATA = ff.AV_PKT_DATA_MASTERING_DISPLAY_METADATA
+	AV_PKT_DATA_SPHERICAL                  = ff.AV_PKT_DATA_SPHERICAL
+	AV_PKT_DATA_CONTENT_LIGHT_LEVEL        = ff.AV_PKT_DATA_CONTENT_LIGHT_LEVEL
+	AV_PKT_DATA_A53_CC                     = ff.AV_PKT_DATA_A53_CC
+	AV_PKT_DATA_ICC_PROFILE                = ff.AV_PKT_DATA_ICC_PROFILE
+	AV_PKT_DATA_DOVI_CONF                  = ff.AV_PKT_DATA_DOVI_CONF
+	AV_PKT_DATA_S12M_TIMECODE              = ff.AV_PKT_DATA_S12M_TIMECODE
+	AV_PKT_DATA_DYNAMIC_HDR10_PLUS         = ff.AV_PKT_DATA_DYNAMIC_HDR10_PLUS
+	SUBTITLE_NONE                          = ff.SUBTITLE_NONE
+	SUBTITLE_BITMAP                        = ff.SUBTITLE_BITMAP
+	SUBTITLE_TEXT                          = ff.SUBTITLE_TEXT
+	SUBTITLE_ASS                           = ff.SUBTITLE_ASS
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// FUNCTIONS
+
+var (
+	AVCodec_bsf_get_by_name         = ff.AVCodec_bsf_get_by_name
+	AVCodec_bsf_iterate             = ff.AVCodec_bsf_iterate
+	AVCodec_bsf_alloc               = ff.AVCodec_bsf_alloc
+	AVCodec_bsf_list_parse_str      = ff.AVCodec_bsf_list_parse_str
+	AVCodec_bsf_init                = ff.AVCodec_bsf_init
+	AVCodec_bsf_free                = ff.AVCodec_bsf_free
+	AVCodec_bsf_flush               = ff.AVCodec_bsf_flush
+	AVCodec_bsf_send_packet         = ff.AVCodec_bsf_send_packet
+	AVCodec_bsf_receive_packet      = ff.AVCodec_bsf_receive_packet
+	AVCodec_alloc_context           = ff.AVCodec_alloc_context
+	AVCodec_free_context            = ff.AVCodec_free_context
+	AVCodec_parameters_copy         = ff.AVCodec_parameters_copy
+	AVCodec_parameters_from_context = ff.AVCodec_parameters_from_context
+	AVCodec_parameters_to_context   = ff.AVCodec_parameters_to_context
+	AVCodec_open                    = ff.AVCodec_open
+	AVCodec_iterate                 = ff.AVCodec_iterate
+	AVCodec_find_decoder            = ff.AVCodec_find_decoder
+	AVCodec_find_decoder_by_name    = ff.AVCodec_find_decoder_by_name
+	AVCodec_find_encoder            = ff.AVCodec_find_encoder
+	AVCodec_find_encoder_by_name    = ff.AVCodec_find_encoder_by_name
+	AVCodec_is_encoder              = ff.AVCodec_is_encoder
+	AVCodec_is_decoder              = ff.AVCodec_is_decoder
+	AVCodec_supported_sampleformat  = ff.AVCodec_supported_sampleformat
+	AVCodec_supported_pixelformat   = ff.AVCodec_supported_pixelformat
+	AVCodec_receive_frame           = ff.AVCodec_receive_frame
+	AVCodec_send_packet             = ff.AVCodec_send_packet
+	AVCodec_decode_subtitle         = ff.AVCodec_decode_subtitle
+	AVCodec_flush_buffers           = ff.AVCodec_flush_buffers
+	AVCodec_send_frame              = ff.AVCodec_send_frame
+	AVCodec_receive_packet          = ff.AVCodec_receive_packet
+	AVCodec_encode_subtitle         = ff.AVCodec_encode_subtitle
+	AVCodec_packet_alloc            = ff.AVCodec_packet_alloc
+	AVCodec_packet_free             = ff.AVCodec_packet_free
+	AVCodec_packet_freep            = ff.AVCodec_packet_freep
+	AVCodec_packet_unref            = ff.AVCodec_packet_unref
+	AVCodec_packet_ref              = ff.AVCodec_packet_ref
+	AVCodec_packet_clone            = ff.AVCodec_packet_clone
+	AVCodec_new_packet              = ff.AVCodec_new_packet
+	AVCodec_shrink_packet           = ff.AVCodec_shrink_packet
+	AVCodec_grow_packet             = ff.AVCodec_grow_packet
+	AVCodec_packet_rescale_ts       = ff.AVCodec_packet_rescale_ts
+	AVCodec_packet_from_data        = ff.AVCodec_packet_from_data
+	AVCodec_packet_get_side_data    = ff.AVCodec_packet_get_side_data
+	AVCodec_packet_add_side_data    = ff.AVCodec_packet_add_side_data
+	AVCodec_packet_side_data_get    = ff.AVCodec_packet_side_data_get
+	AVCodec_parameters_alloc        = ff.AVCodec_parameters_alloc
+	AVCodec_parameters_free         = ff.AVCodec_parameters_free
+	AVCodec_parser_iterate          = ff.AVCodec_parser_iterate
+	AVCodec_parser_init             = ff.AVCodec_parser_init
+	AVCodec_parser_close            = ff.AVCodec_parser_close
+	AVCodec_parser_parse            = ff.AVCodec_parser_parse
+	AVSubtitle_free                 = ff.AVSubtitle_free
+	AVCodec_version                 = ff.AVCodec_version
+	AVCodec_configuration           = ff.AVCodec_configuration
+	AVCodec_license                 = ff.AVCodec_license
+)
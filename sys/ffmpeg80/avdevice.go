@@ -0,0 +1,37 @@
+package ffmpeg
+
+import (
+	// Packages
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type (
+	AVAppToDevMessageType = ff.AVAppToDevMessageType
+	AVDevToAppMessageType = ff.AVDevToAppMessageType
+	AVDeviceInfoList      = ff.AVDeviceInfoList
+	AVDeviceInfo          = ff.AVDeviceInfo
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// FUNCTIONS
+
+var (
+	AVDevice_list_devices              = ff.AVDevice_list_devices
+	AVDevice_free_list_devices         = ff.AVDevice_free_list_devices
+	AVDevice_input_audio_device_first  = ff.AVDevice_input_audio_device_first
+	AVDevice_input_audio_device_next   = ff.AVDevice_input_audio_device_next
+	AVDevice_input_video_device_first  = ff.AVDevice_input_video_device_first
+	AVDevice_input_video_device_next   = ff.AVDevice_input_video_device_next
+	AVDevice_list_input_sources        = ff.AVDevice_list_input_sources
+	AVDevice_output_audio_device_first = ff.AVDevice_output_audio_device_first
+	AVDevice_output_audio_device_next  = ff.AVDevice_output_audio_device_next
+	AVDevice_output_video_device_first = ff.AVDevice_output_video_device_first
+	AVDevice_output_video_device_next  = ff.AVDevice_output_video_device_next
+	AVDevice_list_output_sinks         = ff.AVDevice_list_output_sinks
+	AVDevice_version                   = ff.AVDevice_version
+	AVDevice_configuration             = ff.AVDevice_configuration
+	AVDevice_license                   = ff.AVDevice_license
+)
@@ -0,0 +1,79 @@
+package ffmpeg
+
+import (
+	// Packages
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type (
+	AVBufferSrcFlag = ff.AVBufferSrcFlag
+	AVFilterContext = ff.AVFilterContext
+	AVFilter        = ff.AVFilter
+	AVFilterFlag    = ff.AVFilterFlag
+	AVFilterGraph   = ff.AVFilterGraph
+	AVFilterInOut   = ff.AVFilterInOut
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// CONSTANTS
+
+const (
+	AV_BUFFERSRC_FLAG_NONE                  = ff.AV_BUFFERSRC_FLAG_NONE
+	AV_BUFFERSRC_FLAG_NO_CHECK_FORMAT       = ff.AV_BUFFERSRC_FLAG_NO_CHECK_FORMAT
+	AV_BUFFERSRC_FLAG_PUSH                  = ff.AV_BUFFERSRC_FLAG_PUSH
+	AV_BUFFERSRC_FLAG_KEEP_REF              = ff.AV_BUFFERSRC_FLAG_KEEP_REF
+	AVFILTER_FLAG_NONE                      = ff.AVFILTER_FLAG_NONE
+	AVFILTER_FLAG_DYNAMIC_INPUTS            = ff.AVFILTER_FLAG_DYNAMIC_INPUTS
+	AVFILTER_FLAG_DYNAMIC_OUTPUTS           = ff.AVFILTER_FLAG_DYNAMIC_OUTPUTS
+	AVFILTER_FLAG_SLICE_THREADS             = ff.AVFILTER_FLAG_SLICE_THREADS
+	AVFILTER_FLAG_METADATA_ONLY             = ff.AVFILTER_FLAG_METADATA_ONLY
+	AVFILTER_FLAG_HWDEVICE                  = ff.AVFILTER_FLAG_HWDEVICE
+	AVFILTER_FLAG_SUPPORT_TIMELINE_GENERIC  = ff.AVFILTER_FLAG_SUPPORT_TIMELINE_GENERIC
+	AVFILTER_FLAG_SUPPORT_TIMELINE_INTERNAL = ff.AVFILTER_FLAG_SUPPORT_TIMELINE_INTERNAL
+	AVFILTER_FLAG_SUPPORT_TIMELINE          = ff.AVFILTER_FLAG_SUPPORT_TIMELINE
+	AVFILTER_FLAG_MAX                       = ff.AVFILTER_FLAG_MAX
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// FUNCTIONS
+
+var (
+	AVBufferSrc_add_frame_flags          = ff.AVBufferSrc_add_frame_flags
+	AVBufferSrc_add_frame                = ff.AVBufferSrc_add_frame
+	AVBufferSrc_write_frame              = ff.AVBufferSrc_write_frame
+	AVBufferSrc_close                    = ff.AVBufferSrc_close
+	AVBufferSink_get_frame               = ff.AVBufferSink_get_frame
+	AVBufferSink_get_frame_flags         = ff.AVBufferSink_get_frame_flags
+	AVBufferSink_set_frame_size          = ff.AVBufferSink_set_frame_size
+	AVBufferSink_get_frame_rate          = ff.AVBufferSink_get_frame_rate
+	AVBufferSink_get_sample_aspect_ratio = ff.AVBufferSink_get_sample_aspect_ratio
+	AVBufferSink_get_w                   = ff.AVBufferSink_get_w
+	AVBufferSink_get_h                   = ff.AVBufferSink_get_h
+	AVBufferSink_get_format              = ff.AVBufferSink_get_format
+	AVBufferSink_get_time_base           = ff.AVBufferSink_get_time_base
+	AVBufferSink_get_sample_rate         = ff.AVBufferSink_get_sample_rate
+	AVBufferSink_get_ch_layout           = ff.AVBufferSink_get_ch_layout
+	AVFilterContext_link                 = ff.AVFilterContext_link
+	AVFilterContext_free                 = ff.AVFilterContext_free
+	AVFilter_iterate                     = ff.AVFilter_iterate
+	AVFilter_get_by_name                 = ff.AVFilter_get_by_name
+	AVFilter_inputs                      = ff.AVFilter_inputs
+	AVFilter_outputs                     = ff.AVFilter_outputs
+	AVFilterGraph_alloc                  = ff.AVFilterGraph_alloc
+	AVFilterGraph_free                   = ff.AVFilterGraph_free
+	AVFilterGraph_config                 = ff.AVFilterGraph_config
+	AVFilterGraph_dump                   = ff.AVFilterGraph_dump
+	AVFilterGraph_create_filter          = ff.AVFilterGraph_create_filter
+	AVFilterGraph_parse                  = ff.AVFilterGraph_parse
+	AVFilterInOut_alloc                  = ff.AVFilterInOut_alloc
+	AVFilterInOut_free                   = ff.AVFilterInOut_free
+	AVFilterInOut_link                   = ff.AVFilterInOut_link
+	AVFilterInOut_list                   = ff.AVFilterInOut_list
+	AVFilterInOut_list_free              = ff.AVFilterInOut_list_free
+	AVFilter_version                     = ff.AVFilter_version
+	AVFilter_configuration               = ff.AVFilter_configuration
+	AVFilter_license                     = ff.AVFilter_license
+)
@@ -0,0 +1,172 @@
+package ffmpeg
+
+import (
+	// Packages
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type (
+	AVIOContext         = ff.AVIOContext
+	AVIOContextEx       = ff.AVIOContextEx
+	AVIOContextCallback = ff.AVIOContextCallback
+	AVIOFlag            = ff.AVIOFlag
+	AVChapter           = ff.AVChapter
+	AVFormatContext     = ff.AVFormatContext
+	AVDisposition       = ff.AVDisposition
+	AVFormatFlag        = ff.AVFormatFlag
+	AVFormat            = ff.AVFormat
+	AVInputFormat       = ff.AVInputFormat
+	AVIOInterruptCB     = ff.AVIOInterruptCB
+	AVIOInterruptFunc   = ff.AVIOInterruptFunc
+	AVOutputFormat      = ff.AVOutputFormat
+	AVProgram           = ff.AVProgram
+	AVDiscard           = ff.AVDiscard
+	AVSeekFlag          = ff.AVSeekFlag
+	AVStream            = ff.AVStream
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// CONSTANTS
+
+const (
+	AVIO_FLAG_NONE                  = ff.AVIO_FLAG_NONE
+	AVIO_FLAG_READ                  = ff.AVIO_FLAG_READ
+	AVIO_FLAG_WRITE                 = ff.AVIO_FLAG_WRITE
+	AVIO_FLAG_READ_WRITE            = ff.AVIO_FLAG_READ_WRITE
+	AVIO_FLAG_NONBLOCK              = ff.AVIO_FLAG_NONBLOCK
+	AVIO_FLAG_DIRECT                = ff.AVIO_FLAG_DIRECT
+	AVIO_FLAG_MIN                   = ff.AVIO_FLAG_MIN
+	AVIO_FLAG_MAX                   = ff.AVIO_FLAG_MAX
+	AV_DISPOSITION_DEFAULT          = ff.AV_DISPOSITION_DEFAULT
+	AV_DISPOSITION_DUB              = ff.AV_DISPOSITION_DUB
+	AV_DISPOSITION_ORIGINAL         = ff.AV_DISPOSITION_ORIGINAL
+	AV_DISPOSITION_COMMENT          = ff.AV_DISPOSITION_COMMENT
+	AV_DISPOSITION_LYRICS           = ff.AV_DISPOSITION_LYRICS
+	AV_DISPOSITION_KARAOKE          = ff.AV_DISPOSITION_KARAOKE
+	AV_DISPOSITION_FORCED           = ff.AV_DISPOSITION_FORCED
+	AV_DISPOSITION_HEARING_IMPAIRED = ff.AV_DISPOSITION_HEARING_IMPAIRED
+	AV_DISPOSITION_VISUAL_IMPAIRED  = ff.AV_DISPOSITION_VISUAL_IMPAIRED
+	AV_DISPOSITION_CLEAN_EFFECTS    = ff.AV_DISPOSITION_CLEAN_EFFECTS
+	AV_DISPOSITION_ATTACHED_PIC     = ff.AV_DISPOSITION_ATTACHED_PIC
+	AV_DISPOSITION_TIMED_THUMBNAILS = ff.AV_DISPOSITION_TIMED_THUMBNAILS
+	AV_DISPOSITION_NON_DIEGETIC     = ff.AV_DISPOSITION_NON_DIEGETIC
+	AV_DISPOSITION_CAPTIONS         = ff.AV_DISPOSITION_CAPTIONS
+	AV_DISPOSITION_DESCRIPTIONS     = ff.AV_DISPOSITION_DESCRIPTIONS
+	AV_DISPOSITION_METADATA         = ff.AV_DISPOSITION_METADATA
+	AV_DISPOSITION_DEPENDENT        = ff.AV_DISPOSITION_DEPENDENT
+	AV_DISPOSITION_STILL_IMAGE      = ff.AV_DISPOSITION_STILL_IMAGE
+	AV_DISPOSITION_MULTILAYER       = ff.AV_DISPOSITION_MULTILAYER
+	AV_DISPOSITION_MIN              = ff.AV_DISPOSITION_MIN
+	AV_DISPOSITION_MAX              = ff.AV_DISPOSITION_MAX
+	AVFMT_FLAG_NONE                 = ff.AVFMT_FLAG_NONE
+	AVFMT_FLAG_GENPTS               = ff.AVFMT_FLAG_GENPTS
+	AVFMT_FLAG_IGNIDX               = ff.AVFMT_FLAG_IGNIDX
+	AVFMT_FLAG_NONBLOCK             = ff.AVFMT_FLAG_NONBLOCK
+	AVFMT_FLAG_IGNDTS               = ff.AVFMT_FLAG_IGNDTS
+	AVFMT_FLAG_NOFILLIN             = ff.AVFMT_FLAG_NOFILLIN
+	AVFMT_FLAG_NOPARSE              = ff.AVFMT_FLAG_NOPARSE
+	AVFMT_FLAG_NOBUFFER             = ff.AVFMT_FLAG_NOBUFFER
+	AVFMT_FLAG_CUSTOM_IO            = ff.AVFMT_FLAG_CUSTOM_IO
+	AVFMT_FLAG_DISCARD_CORRUPT      = ff.AVFMT_FLAG_DISCARD_CORRUPT
+	AVFMT_FLAG_FLUSH_PACKETS        = ff.AVFMT_FLAG_FLUSH_PACKETS
+	AVFMT_FLAG_BITEXACT             = ff.AVFMT_FLAG_BITEXACT
+	AVFMT_FLAG_SORT_DTS             = ff.AVFMT_FLAG_SORT_DTS
+	AVFMT_FLAG_FAST_SEEK            = ff.AVFMT_FLAG_FAST_SEEK
+	AVFMT_FLAG_AUTO_BSF             = ff.AVFMT_FLAG_AUTO_BSF
+	AVFMT_FLAG_MIN                  = ff.AVFMT_FLAG_MIN
+	AVFMT_FLAG_MAX                  = ff.AVFMT_FLAG_MAX
+	AVFMT_NONE                      = ff.AVFMT_NONE
+	AVFMT_NOFILE                    = ff.AVFMT_NOFILE
+	AVFMT_NEEDNUMBER                = ff.AVFMT_NEEDNUMBER
+	AVFMT_EXPERIMENTAL              = ff.AVFMT_EXPERIMENTAL
+	AVFMT_SHOWIDS                   = ff.AVFMT_SHOWIDS
+	AVFMT_GLOBALHEADER              = ff.AVFMT_GLOBALHEADER
+	AVFMT_NOTIMESTAMPS              = ff.AVFMT_NOTIMESTAMPS
+	AVFMT_GENERICINDEX              = ff.AVFMT_GENERICINDEX
+	AVFMT_TSDISCONT                 = ff.AVFMT_TSDISCONT
+	AVFMT_VARIABLEFPS               = ff.AVFMT_VARIABLEFPS
+	AVFMT_NODIMENSIONS              = ff.AVFMT_NODIMENSIONS
+	AVFMT_NOSTREAMS                 = ff.AVFMT_NOSTREAMS
+	AVFMT_NOBINSEARCH               = ff.AVFMT_NOBINSEARCH
+	AVFMT_NOGENSEARCH               = ff.AVFMT_NOGENSEARCH
+	AVFMT_NOBYTESEEK                = ff.AVFMT_NOBYTESEEK
+	AVFMT_TS_NONSTRICT              = ff.AVFMT_TS_NONSTRICT
+	AVFMT_TS_NEGATIVE               = ff.AVFMT_TS_NEGATIVE
+	AVFMT_SEEK_TO_PTS               = ff.AVFMT_SEEK_TO_PTS
+	AVFMT_MIN                       = ff.AVFMT_MIN
+	AVFMT_MAX                       = ff.AVFMT_MAX
+	AVDISCARD_NONE                  = ff.AVDISCARD_NONE
+	AVDISCARD_DEFAULT               = ff.AVDISCARD_DEFAULT
+	AVDISCARD_NONREF                = ff.AVDISCARD_NONREF
+	AVDISCARD_BIDIR                 = ff.AVDISCARD_BIDIR
+	AVDISCARD_NONINTRA              = ff.AVDISCARD_NONINTRA
+	AVDISCARD_NONKEY                = ff.AVDISCARD_NONKEY
+	AVDISCARD_ALL                   = ff.AVDISCARD_ALL
+	AVSEEK_SIZE                     = ff.AVSEEK_SIZE
+	AVSEEK_FORCE                    = ff.AVSEEK_FORCE
+	AVSEEK_FLAG_NONE                = ff.AVSEEK_FLAG_NONE
+	AVSEEK_FLAG_BACKWARD            = ff.AVSEEK_FLAG_BACKWARD
+	AVSEEK_FLAG_BYTE                = ff.AVSEEK_FLAG_BYTE
+	AVSEEK_FLAG_ANY                 = ff.AVSEEK_FLAG_ANY
+	AVSEEK_FLAG_FRAME               = ff.AVSEEK_FLAG_FRAME
+	AVSEEK_FLAG_MIN                 = ff.AVSEEK_FLAG_MIN
+	AVSEEK_FLAG_MAX                 = ff.AVSEEK_FLAG_MAX
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// FUNCTIONS
+
+var (
+	AVFormat_avio_alloc_context       = ff.AVFormat_avio_alloc_context
+	AVFormat_avio_open                = ff.AVFormat_avio_open
+	AVFormat_avio_open2               = ff.AVFormat_avio_open2
+	AVFormat_avio_close               = ff.AVFormat_avio_close
+	AVFormat_avio_context_free        = ff.AVFormat_avio_context_free
+	AVFormat_avio_w8                  = ff.AVFormat_avio_w8
+	AVFormat_avio_write               = ff.AVFormat_avio_write
+	AVFormat_avio_wl64                = ff.AVFormat_avio_wl64
+	AVFormat_avio_put_str             = ff.AVFormat_avio_put_str
+	AVFormat_avio_seek                = ff.AVFormat_avio_seek
+	AVFormat_avio_flush               = ff.AVFormat_avio_flush
+	AVFormat_avio_read                = ff.AVFormat_avio_read
+	AVFormat_new_chapter              = ff.AVFormat_new_chapter
+	AVFormat_alloc_context            = ff.AVFormat_alloc_context
+	AVFormat_free_context             = ff.AVFormat_free_context
+	AVFormat_open_reader              = ff.AVFormat_open_reader
+	AVFormat_open_url                 = ff.AVFormat_open_url
+	AVFormat_open_input               = ff.AVFormat_open_input
+	AVFormat_open_device              = ff.AVFormat_open_device
+	AVFormat_close_input              = ff.AVFormat_close_input
+	AVFormat_find_stream_info         = ff.AVFormat_find_stream_info
+	AVFormat_read_frame               = ff.AVFormat_read_frame
+	AVFormat_flush                    = ff.AVFormat_flush
+	AVFormat_read_play                = ff.AVFormat_read_play
+	AVFormat_read_pause               = ff.AVFormat_read_pause
+	AVFormat_dump_format              = ff.AVFormat_dump_format
+	AVFormat_find_input_format        = ff.AVFormat_find_input_format
+	AVFormat_demuxer_iterate          = ff.AVFormat_demuxer_iterate
+	AVFormat_open_writer              = ff.AVFormat_open_writer
+	AVFormat_alloc_output_context     = ff.AVFormat_alloc_output_context
+	AVFormat_create_file              = ff.AVFormat_create_file
+	AVFormat_close_writer             = ff.AVFormat_close_writer
+	AVFormat_init_output              = ff.AVFormat_init_output
+	AVFormat_write_header             = ff.AVFormat_write_header
+	AVFormat_write_trailer            = ff.AVFormat_write_trailer
+	AVFormat_muxer_iterate            = ff.AVFormat_muxer_iterate
+	AVFormat_guess_format             = ff.AVFormat_guess_format
+	AVFormat_query_codec              = ff.AVFormat_query_codec
+	AVFormat_interleaved_write_frame  = ff.AVFormat_interleaved_write_frame
+	AVFormat_write_frame              = ff.AVFormat_write_frame
+	AVFormat_new_program              = ff.AVFormat_new_program
+	AVFormat_program_add_stream_index = ff.AVFormat_program_add_stream_index
+	AVFormat_seek_frame               = ff.AVFormat_seek_frame
+	AVFormat_seek_file                = ff.AVFormat_seek_file
+	AVFormat_new_stream               = ff.AVFormat_new_stream
+	AVFormat_find_best_stream         = ff.AVFormat_find_best_stream
+	AVFormat_version                  = ff.AVFormat_version
+	AVFormat_configuration            = ff.AVFormat_configuration
+	AVFormat_license                  = ff.AVFormat_license
+)
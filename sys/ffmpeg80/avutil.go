@@ -0,0 +1,687 @@
+package ffmpeg
+
+import (
+	// Packages
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type (
+	AVAudioFifo                = ff.AVAudioFifo
+	AVChannel                  = ff.AVChannel
+	AVChannelLayout            = ff.AVChannelLayout
+	AVChannelOrder             = ff.AVChannelOrder
+	AVDictionary               = ff.AVDictionary
+	AVDictionaryEntry          = ff.AVDictionaryEntry
+	AVDictionaryFlag           = ff.AVDictionaryFlag
+	AVDisplayMatrix            = ff.AVDisplayMatrix
+	AVError                    = ff.AVError
+	AVFrame                    = ff.AVFrame
+	AVFrameSideData            = ff.AVFrameSideData
+	AVFrameSideDataType        = ff.AVFrameSideDataType
+	AVDynamicHDRPlus           = ff.AVDynamicHDRPlus
+	AVLogFunc                  = ff.AVLogFunc
+	AVLog                      = ff.AVLog
+	AVClass                    = ff.AVClass
+	AVClassCategory            = ff.AVClassCategory
+	AVMasteringDisplayMetadata = ff.AVMasteringDisplayMetadata
+	AVContentLightMetadata     = ff.AVContentLightMetadata
+	AVMediaType                = ff.AVMediaType
+	AVOption                   = ff.AVOption
+	AVOptionType               = ff.AVOptionType
+	AVOptionRanges             = ff.AVOptionRanges
+	AVOptionRange              = ff.AVOptionRange
+	AVPictureType              = ff.AVPictureType
+	AVPixelFormat              = ff.AVPixelFormat
+	AVPixFmtDescriptor         = ff.AVPixFmtDescriptor
+	AVColorSpace               = ff.AVColorSpace
+	AVColorRange               = ff.AVColorRange
+	AVRational                 = ff.AVRational
+	AVRounding                 = ff.AVRounding
+	AVReplayGain               = ff.AVReplayGain
+	AVSampleFormat             = ff.AVSampleFormat
+	AVSphericalMapping         = ff.AVSphericalMapping
+	AVSphericalProjection      = ff.AVSphericalProjection
+	AVStereo3D                 = ff.AVStereo3D
+	AVStereo3DType             = ff.AVStereo3DType
+	AVTimestamp                = ff.AVTimestamp
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// CONSTANTS
+
+const (
+	AV_DICT_NONE                              = ff.AV_DICT_NONE
+	AV_DICT_MATCH_CASE                        = ff.AV_DICT_MATCH_CASE
+	AV_DICT_IGNORE_SUFFIX                     = ff.AV_DICT_IGNORE_SUFFIX
+	AV_DICT_DONT_STRDUP_KEY                   = ff.AV_DICT_DONT_STRDUP_KEY
+	AV_DICT_DONT_STRDUP_VAL                   = ff.AV_DICT_DONT_STRDUP_VAL
+	AV_DICT_DONT_OVERWRITE                    = ff.AV_DICT_DONT_OVERWRITE
+	AV_DICT_APPEND                            = ff.AV_DICT_APPEND
+	AV_DICT_MULTIKEY                          = ff.AV_DICT_MULTIKEY
+	AV_DISPLAY_MATRIX_SIZE                    = ff.AV_DISPLAY_MATRIX_SIZE
+	AVERROR_BSF_NOT_FOUND                     = ff.AVERROR_BSF_NOT_FOUND
+	AVERROR_BUG                               = ff.AVERROR_BUG
+	AVERROR_BUFFER_TOO_SMALL                  = ff.AVERROR_BUFFER_TOO_SMALL
+	AVERROR_DECODER_NOT_FOUND                 = ff.AVERROR_DECODER_NOT_FOUND
+	AVERROR_DEMUXER_NOT_FOUND                 = ff.AVERROR_DEMUXER_NOT_FOUND
+	AVERROR_ENCODER_NOT_FOUND                 = ff.AVERROR_ENCODER_NOT_FOUND
+	AVERROR_EOF                               = ff.AVERROR_EOF
+	AVERROR_EXIT                              = ff.AVERROR_EXIT
+	AVERROR_EXTERNAL                          = ff.AVERROR_EXTERNAL
+	AVERROR_FILTER_NOT_FOUND                  = ff.AVERROR_FILTER_NOT_FOUND
+	AVERROR_INVALIDDATA                       = ff.AVERROR_INVALIDDATA
+	AVERROR_MUXER_NOT_FOUND                   = ff.AVERROR_MUXER_NOT_FOUND
+	AVERROR_OPTION_NOT_FOUND                  = ff.AVERROR_OPTION_NOT_FOUND
+	AVERROR_PATCHWELCOME                      = ff.AVERROR_PATCHWELCOME
+	AVERROR_PROTOCOL_NOT_FOUND                = ff.AVERROR_PROTOCOL_NOT_FOUND
+	AVERROR_STREAM_NOT_FOUND                  = ff.AVERROR_STREAM_NOT_FOUND
+	AVERROR_BUG2                              = ff.AVERROR_BUG2
+	AVERROR_UNKNOWN                           = ff.AVERROR_UNKNOWN
+	AVERROR_EXPERIMENTAL                      = ff.AVERROR_EXPERIMENTAL
+	AVERROR_INPUT_CHANGED                     = ff.AVERROR_INPUT_CHANGED
+	AVERROR_OUTPUT_CHANGED                    = ff.AVERROR_OUTPUT_CHANGED
+	AVERROR_HTTP_BAD_REQUEST                  = ff.AVERROR_HTTP_BAD_REQUEST
+	AVERROR_HTTP_UNAUTHORIZED                 = ff.AVERROR_HTTP_UNAUTHORIZED
+	AVERROR_HTTP_FORBIDDEN                    = ff.AVERROR_HTTP_FORBIDDEN
+	AVERROR_HTTP_NOT_FOUND                    = ff.AVERROR_HTTP_NOT_FOUND
+	AVERROR_HTTP_OTHER_4XX                    = ff.AVERROR_HTTP_OTHER_4XX
+	AVERROR_HTTP_SERVER_ERROR                 = ff.AVERROR_HTTP_SERVER_ERROR
+	AV_FRAME_DATA_PANSCAN                     = ff.AV_FRAME_DATA_PANSCAN
+	AV_FRAME_DATA_A53_CC                      = ff.AV_FRAME_DATA_A53_CC
+	AV_FRAME_DATA_STEREO3D                    = ff.AV_FRAME_DATA_STEREO3D
+	AV_FRAME_DATA_MATRIXENCODING              = ff.AV_FRAME_DATA_MATRIXENCODING
+	AV_FRAME_DATA_DOWNMIX_INFO                = ff.AV_FRAME_DATA_DOWNMIX_INFO
+	AV_FRAME_DATA_REPLAYGAIN                  = ff.AV_FRAME_DATA_REPLAYGAIN
+	AV_FRAME_DATA_DISPLAYMATRIX               = ff.AV_FRAME_DATA_DISPLAYMATRIX
+	AV_FRAME_DATA_AFD                         = ff.AV_FRAME_DATA_AFD
+	AV_FRAME_DATA_MOTION_VECTORS              = ff.AV_FRAME_DATA_MOTION_VECTORS
+	AV_FRAME_DATA_SKIP_SAMPLES                = ff.AV_FRAME_DATA_SKIP_SAMPLES
+	AV_FRAME_DATA_AUDIO_SERVICE_TYPE          = ff.AV_FRAME_DATA_AUDIO_SERVICE_TYPE
+	AV_FRAME_DATA_MASTERING_DISPLAY_METADATA  = ff.AV_FRAME_DATA_MASTERING_DISPLAY_METADATA
+	AV_FRAME_DATA_GOP_TIMECODE                = ff.AV_FRAME_DATA_GOP_TIMECODE
+	AV_FRAME_DATA_SPHERICAL                   = ff.AV_FRAME_DATA_SPHERICAL
+	AV_FRAME_DATA_CONTENT_LIGHT_LEVEL         = ff.AV_FRAME_DATA_CONTENT_LIGHT_LEVEL
+	AV_FRAME_DATA_ICC_PROFILE                 = ff.AV_FRAME_DATA_ICC_PROFILE
+	AV_FRAME_DATA_S12M_TIMECODE               = ff.AV_FRAME_DATA_S12M_TIMECODE
+	AV_FRAME_DATA_DYNAMIC_HDR_PLUS            = ff.AV_FRAME_DATA_DYNAMIC_HDR_PLUS
+	AV_FRAME_DATA_REGIONS_OF_INTEREST         = ff.AV_FRAME_DATA_REGIONS_OF_INTEREST
+	AV_FRAME_DATA_SEI_UNREGISTERED            = ff.AV_FRAME_DATA_SEI_UNREGISTERED
+	AV_FRAME_DATA_FILM_GRAIN_PARAMS           = ff.AV_FRAME_DATA_FILM_GRAIN_PARAMS
+	AV_FRAME_DATA_DOVI_METADATA               = ff.AV_FRAME_DATA_DOVI_METADATA
+	AV_FRAME_DATA_DYNAMIC_HDR_VIVID           = ff.AV_FRAME_DATA_DYNAMIC_HDR_VIVID
+	AV_FRAME_DATA_AMBIENT_VIEWING_ENVIRONMENT = ff.AV_FRAME_DATA_AMBIENT_VIEWING_ENVIRONMENT
+	AV_LOG_QUIET                              = ff.AV_LOG_QUIET
+	AV_LOG_PANIC                              = ff.AV_LOG_PANIC
+	AV_LOG_FATAL                              = ff.AV_LOG_FATAL
+	AV_LOG_ERROR                              = ff.AV_LOG_ERROR
+	AV_LOG_WARNING                            = ff.AV_LOG_WARNING
+	AV_LOG_INFO                               = ff.AV_LOG_INFO
+	AV_LOG_VERBOSE                            = ff.AV_LOG_VERBOSE
+	AV_LOG_DEBUG                              = ff.AV_LOG_DEBUG
+	AV_LOG_TRACE                              = ff.AV_LOG_TRACE
+	AV_CLASS_CATEGORY_NA                      = ff.AV_CLASS_CATEGORY_NA
+	AV_CLASS_CATEGORY_INPUT                   = ff.AV_CLASS_CATEGORY_INPUT
+	AV_CLASS_CATEGORY_OUTPUT                  = ff.AV_CLASS_CATEGORY_OUTPUT
+	AV_CLASS_CATEGORY_MUXER                   = ff.AV_CLASS_CATEGORY_MUXER
+	AV_CLASS_CATEGORY_DEMUXER                 = ff.AV_CLASS_CATEGORY_DEMUXER
+	AV_CLASS_CATEGORY_ENCODER                 = ff.AV_CLASS_CATEGORY_ENCODER
+	AV_CLASS_CATEGORY_DECODER                 = ff.AV_CLASS_CATEGORY_DECODER
+	AV_CLASS_CATEGORY_FILTER                  = ff.AV_CLASS_CATEGORY_FILTER
+	AV_CLASS_CATEGORY_BITSTREAM_FILTER        = ff.AV_CLASS_CATEGORY_BITSTREAM_FILTER
+	AV_CLASS_CATEGORY_SWSCALER                = ff.AV_CLASS_CATEGORY_SWSCALER
+	AV_CLASS_CATEGORY_SWRESAMPLER             = ff.AV_CLASS_CATEGORY_SWRESAMPLER
+	AV_CLASS_CATEGORY_HWDEVICE                = ff.AV_CLASS_CATEGORY_HWDEVICE
+	AVMEDIA_TYPE_UNKNOWN                      = ff.AVMEDIA_TYPE_UNKNOWN
+	AVMEDIA_TYPE_VIDEO                        = ff.AVMEDIA_TYPE_VIDEO
+	AVMEDIA_TYPE_AUDIO                        = ff.AVMEDIA_TYPE_AUDIO
+	AVMEDIA_TYPE_DATA                         = ff.AVMEDIA_TYPE_DATA
+	AVMEDIA_TYPE_SUBTITLE                     = ff.AVMEDIA_TYPE_SUBTITLE
+	AVMEDIA_TYPE_ATTACHMENT                   = ff.AVMEDIA_TYPE_ATTACHMENT
+	AV_OPT_TYPE_FLAGS                         = ff.AV_OPT_TYPE_FLAGS
+	AV_OPT_TYPE_INT                           = ff.AV_OPT_TYPE_INT
+	AV_OPT_TYPE_INT64                         = ff.AV_OPT_TYPE_INT64
+	AV_OPT_TYPE_UINT                          = ff.AV_OPT_TYPE_UINT
+	AV_OPT_TYPE_UINT64                        = ff.AV_OPT_TYPE_UINT64
+	AV_OPT_TYPE_DOUBLE                        = ff.AV_OPT_TYPE_DOUBLE
+	AV_OPT_TYPE_FLOAT                         = ff.AV_OPT_TYPE_FLOAT
+	AV_OPT_TYPE_STRING                        = ff.AV_OPT_TYPE_STRING
+	AV_OPT_TYPE_RATIONAL                      = ff.AV_OPT_TYPE_RATIONAL
+	AV_OPT_TYPE_BINARY                        = ff.AV_OPT_TYPE_BINARY
+	AV_OPT_TYPE_DICT                          = ff.AV_OPT_TYPE_DICT
+	AV_OPT_TYPE_CONST                         = ff.AV_OPT_TYPE_CONST
+	AV_OPT_TYPE_IMAGE_SIZE                    = ff.AV_OPT_TYPE_IMAGE_SIZE
+	AV_OPT_TYPE_PIXEL_FMT                     = ff.AV_OPT_TYPE_PIXEL_FMT
+	AV_OPT_TYPE_SAMPLE_FMT                    = ff.AV_OPT_TYPE_SAMPLE_FMT
+	AV_OPT_TYPE_VIDEO_RATE                    = ff.AV_OPT_TYPE_VIDEO_RATE
+	AV_OPT_TYPE_DURATION                      = ff.AV_OPT_TYPE_DURATION
+	AV_OPT_TYPE_COLOR                         = ff.AV_OPT_TYPE_COLOR
+	AV_OPT_TYPE_BOOL                          = ff.AV_OPT_TYPE_BOOL
+	AV_OPT_TYPE_CHLAYOUT                      = ff.AV_OPT_TYPE_CHLAYOUT
+	AV_OPT_TYPE_FLAG_ARRAY                    = ff.AV_OPT_TYPE_FLAG_ARRAY
+	AV_OPT_SEARCH_CHILDREN                    = ff.AV_OPT_SEARCH_CHILDREN
+	AV_OPT_SEARCH_FAKE_OBJ                    = ff.AV_OPT_SEARCH_FAKE_OBJ
+	AV_OPT_FLAG_IMPLICIT_KEY                  = ff.AV_OPT_FLAG_IMPLICIT_KEY
+	AV_OPT_SERIALIZE_SKIP_DEFAULTS            = ff.AV_OPT_SERIALIZE_SKIP_DEFAULTS
+	AV_OPT_SERIALIZE_OPT_FLAGS_EXACT          = ff.AV_OPT_SERIALIZE_OPT_FLAGS_EXACT
+	AV_PICTURE_TYPE_NONE                      = ff.AV_PICTURE_TYPE_NONE
+	AV_PICTURE_TYPE_I                         = ff.AV_PICTURE_TYPE_I
+	AV_PICTURE_TYPE_P                         = ff.AV_PICTURE_TYPE_P
+	AV_PICTURE_TYPE_B                         = ff.AV_PICTURE_TYPE_B
+	AV_PICTURE_TYPE_S                         = ff.AV_PICTURE_TYPE_S
+	AV_PICTURE_TYPE_SI                        = ff.AV_PICTURE_TYPE_SI
+	AV_PICTURE_TYPE_SP                        = ff.AV_PICTURE_TYPE_SP
+	AV_PICTURE_TYPE_BI                        = ff.AV_PICTURE_TYPE_BI
+	AV_PIX_FMT_NONE                           = ff.AV_PIX_FMT_NONE
+	AV_PIX_FMT_YUV420P                        = ff.AV_PIX_FMT_YUV420P
+	AV_PIX_FMT_YUYV422                        = ff.AV_PIX_FMT_YUYV422
+	AV_PIX_FMT_RGB24                          = ff.AV_PIX_FMT_RGB24
+	AV_PIX_FMT_BGR24                          = ff.AV_PIX_FMT_BGR24
+	AV_PIX_FMT_YUV422P                        = ff.AV_PIX_FMT_YUV422P
+	AV_PIX_FMT_YUV444P                        = ff.AV_PIX_FMT_YUV444P
+	AV_PIX_FMT_YUV410P                        = ff.AV_PIX_FMT_YUV410P
+	AV_PIX_FMT_YUV411P                        = ff.AV_PIX_FMT_YUV411P
+	AV_PIX_FMT_GRAY8                          = ff.AV_PIX_FMT_GRAY8
+	AV_PIX_FMT_MONOWHITE                      = ff.AV_PIX_FMT_MONOWHITE
+	AV_PIX_FMT_MONOBLACK                      = ff.AV_PIX_FMT_MONOBLACK
+	AV_PIX_FMT_PAL8                           = ff.AV_PIX_FMT_PAL8
+	AV_PIX_FMT_YUVJ420P                       = ff.AV_PIX_FMT_YUVJ420P
+	AV_PIX_FMT_YUVJ422P                       = ff.AV_PIX_FMT_YUVJ422P
+	AV_PIX_FMT_YUVJ444P                       = ff.AV_PIX_FMT_YUVJ444P
+	AV_PIX_FMT_UYVY422                        = ff.AV_PIX_FMT_UYVY422
+	AV_PIX_FMT_UYYVYY411                      = ff.AV_PIX_FMT_UYYVYY411
+	AV_PIX_FMT_BGR8                           = ff.AV_PIX_FMT_BGR8
+	AV_PIX_FMT_BGR4                           = ff.AV_PIX_FMT_BGR4
+	AV_PIX_FMT_BGR4_BYTE                      = ff.AV_PIX_FMT_BGR4_BYTE
+	AV_PIX_FMT_RGB8                           = ff.AV_PIX_FMT_RGB8
+	AV_PIX_FMT_RGB4                           = ff.AV_PIX_FMT_RGB4
+	AV_PIX_FMT_RGB4_BYTE                      = ff.AV_PIX_FMT_RGB4_BYTE
+	AV_PIX_FMT_NV12                           = ff.AV_PIX_FMT_NV12
+	AV_PIX_FMT_NV21                           = ff.AV_PIX_FMT_NV21
+	AV_PIX_FMT_ARGB                           = ff.AV_PIX_FMT_ARGB
+	AV_PIX_FMT_RGBA                           = ff.AV_PIX_FMT_RGBA
+	AV_PIX_FMT_ABGR                           = ff.AV_PIX_FMT_ABGR
+	AV_PIX_FMT_BGRA                           = ff.AV_PIX_FMT_BGRA
+	AV_PIX_FMT_GRAY16BE                       = ff.AV_PIX_FMT_GRAY16BE
+	AV_PIX_FMT_GRAY16LE                       = ff.AV_PIX_FMT_GRAY16LE
+	AV_PIX_FMT_YUV440P                        = ff.AV_PIX_FMT_YUV440P
+	AV_PIX_FMT_YUVJ440P                       = ff.AV_PIX_FMT_YUVJ440P
+	AV_PIX_FMT_YUVA420P                       = ff.AV_PIX_FMT_YUVA420P
+	AV_PIX_FMT_RGB48BE                        = ff.AV_PIX_FMT_RGB48BE
+	AV_PIX_FMT_RGB48LE                        = ff.AV_PIX_FMT_RGB48LE
+	AV_PIX_FMT_RGB565BE                       = ff.AV_PIX_FMT_RGB565BE
+	AV_PIX_FMT_RGB565LE                       = ff.AV_PIX_FMT_RGB565LE
+	AV_PIX_FMT_RGB555BE                       = ff.AV_PIX_FMT_RGB555BE
+	AV_PIX_FMT_RGB555LE                       = ff.AV_PIX_FMT_RGB555LE
+	AV_PIX_FMT_BGR565BE                       = ff.AV_PIX_FMT_BGR565BE
+	AV_PIX_FMT_BGR565LE                       = ff.AV_PIX_FMT_BGR565LE
+	AV_PIX_FMT_BGR555BE                       = ff.AV_PIX_FMT_BGR555BE
+	AV_PIX_FMT_BGR555LE                       = ff.AV_PIX_FMT_BGR555LE
+	AV_PIX_FMT_VAAPI                          = ff.AV_PIX_FMT_VAAPI
+	AV_PIX_FMT_YUV420P16LE                    = ff.AV_PIX_FMT_YUV420P16LE
+	AV_PIX_FMT_YUV420P16BE                    = ff.AV_PIX_FMT_YUV420P16BE
+	AV_PIX_FMT_YUV422P16LE                    = ff.AV_PIX_FMT_YUV422P16LE
+	AV_PIX_FMT_YUV422P16BE                    = ff.AV_PIX_FMT_YUV422P16BE
+	AV_PIX_FMT_YUV444P16LE                    = ff.AV_PIX_FMT_YUV444P16LE
+	AV_PIX_FMT_YUV444P16BE                    = ff.AV_PIX_FMT_YUV444P16BE
+	AV_PIX_FMT_DXVA2_VLD                      = ff.AV_PIX_FMT_DXVA2_VLD
+	AV_PIX_FMT_RGB444LE                       = ff.AV_PIX_FMT_RGB444LE
+	AV_PIX_FMT_RGB444BE                       = ff.AV_PIX_FMT_RGB444BE
+	AV_PIX_FMT_BGR444LE                       = ff.AV_PIX_FMT_BGR444LE
+	AV_PIX_FMT_BGR444BE                       = ff.AV_PIX_FMT_BGR444BE
+	AV_PIX_FMT_YA8                            = ff.AV_PIX_FMT_YA8
+	AV_PIX_FMT_Y400A                          = ff.AV_PIX_FMT_Y400A
+	AV_PIX_FMT_GRAY8A                         = ff.AV_PIX_FMT_GRAY8A
+	AV_PIX_FMT_BGR48BE                        = ff.AV_PIX_FMT_BGR48BE
+	AV_PIX_FMT_BGR48LE                        = ff.AV_PIX_FMT_BGR48LE
+	AV_PIX_FMT_YUV420P9BE                     = ff.AV_PIX_FMT_YUV420P9BE
+	AV_PIX_FMT_YUV420P9LE                     = ff.AV_PIX_FMT_YUV420P9LE
+	AV_PIX_FMT_YUV420P10BE                    = ff.AV_PIX_FMT_YUV420P10BE
+	AV_PIX_FMT_YUV420P10LE                    = ff.AV_PIX_FMT_YUV420P10LE
+	AV_PIX_FMT_YUV422P10BE                    = ff.AV_PIX_FMT_YUV422P10BE
+	AV_PIX_FMT_YUV422P10LE                    = ff.AV_PIX_FMT_YUV422P10LE
+	AV_PIX_FMT_YUV444P9BE                     = ff.AV_PIX_FMT_YUV444P9BE
+	AV_PIX_FMT_YUV444P9LE                     = ff.AV_PIX_FMT_YUV444P9LE
+	AV_PIX_FMT_YUV444P10BE                    = ff.AV_PIX_FMT_YUV444P10BE
+	AV_PIX_FMT_YUV444P10LE                    = ff.AV_PIX_FMT_YUV444P10LE
+	AV_PIX_FMT_YUV422P9BE                     = ff.AV_PIX_FMT_YUV422P9BE
+	AV_PIX_FMT_YUV422P9LE                     = ff.AV_PIX_FMT_YUV422P9LE
+	AV_PIX_FMT_GBRP                           = ff.AV_PIX_FMT_GBRP
+	AV_PIX_FMT_GBR24P                         = ff.AV_PIX_FMT_GBR24P
+	AV_PIX_FMT_GBRP9BE                        = ff.AV_PIX_FMT_GBRP9BE
+	AV_PIX_FMT_GBRP9LE                        = ff.AV_PIX_FMT_GBRP9LE
+	AV_PIX_FMT_GBRP10BE                       = ff.AV_PIX_FMT_GBRP10BE
+	AV_PIX_FMT_GBRP10LE                       = ff.AV_PIX_FMT_GBRP10LE
+	AV_PIX_FMT_GBRP16BE                       = ff.AV_PIX_FMT_GBRP16BE
+	AV_PIX_FMT_GBRP16LE                       = ff.AV_PIX_FMT_GBRP16LE
+	AV_PIX_FMT_YUVA422P                       = ff.AV_PIX_FMT_YUVA422P
+	AV_PIX_FMT_YUVA444P                       = ff.AV_PIX_FMT_YUVA444P
+	AV_PIX_FMT_YUVA420P9BE                    = ff.AV_PIX_FMT_YUVA420P9BE
+	AV_PIX_FMT_YUVA420P9LE                    = ff.AV_PIX_FMT_YUVA420P9LE
+	AV_PIX_FMT_YUVA422P9BE                    = ff.AV_PIX_FMT_YUVA422P9BE
+	AV_PIX_FMT_YUVA422P9LE                    = ff.AV_PIX_FMT_YUVA422P9LE
+	AV_PIX_FMT_YUVA444P9BE                    = ff.AV_PIX_FMT_YUVA444P9BE
+	AV_PIX_FMT_YUVA444P9LE                    = ff.AV_PIX_FMT_YUVA444P9LE
+	AV_PIX_FMT_YUVA420P10BE                   = ff.AV_PIX_FMT_YUVA420P10BE
+	AV_PIX_FMT_YUVA420P10LE                   = ff.AV_PIX_FMT_YUVA420P10LE
+	AV_PIX_FMT_YUVA422P10BE                   = ff.AV_PIX_FMT_YUVA422P10BE
+	AV_PIX_FMT_YUVA422P10LE                   = ff.AV_PIX_FMT_YUVA422P10LE
+	AV_PIX_FMT_YUVA444P10BE                   = ff.AV_PIX_FMT_YUVA444P10BE
+	AV_PIX_FMT_YUVA444P10LE                   = ff.AV_PIX_FMT_YUVA444P10LE
+	AV_PIX_FMT_YUVA420P16BE                   = ff.AV_PIX_FMT_YUVA420P16BE
+	AV_PIX_FMT_YUVA420P16LE                   = ff.AV_PIX_FMT_YUVA420P16LE
+	AV_PIX_FMT_YUVA422P16BE                   = ff.AV_PIX_FMT_YUVA422P16BE
+	AV_PIX_FMT_YUVA422P16LE                   = ff.AV_PIX_FMT_YUVA422P16LE
+	AV_PIX_FMT_YUVA444P16BE                   = ff.AV_PIX_FMT_YUVA444P16BE
+	AV_PIX_FMT_YUVA444P16LE                   = ff.AV_PIX_FMT_YUVA444P16LE
+	AV_PIX_FMT_VDPAU                          = ff.AV_PIX_FMT_VDPAU
+	AV_PIX_FMT_XYZ12LE                        = ff.AV_PIX_FMT_XYZ12LE
+	AV_PIX_FMT_XYZ12BE                        = ff.AV_PIX_FMT_XYZ12BE
+	AV_PIX_FMT_NV16                           = ff.AV_PIX_FMT_NV16
+	AV_PIX_FMT_NV20LE                         = ff.AV_PIX_FMT_NV20LE
+	AV_PIX_FMT_NV20BE                         = ff.AV_PIX_FMT_NV20BE
+	AV_PIX_FMT_RGBA64BE                       = ff.AV_PIX_FMT_RGBA64BE
+	AV_PIX_FMT_RGBA64LE                       = ff.AV_PIX_FMT_RGBA64LE
+	AV_PIX_FMT_BGRA64BE                       = ff.AV_PIX_FMT_BGRA64BE
+	AV_PIX_FMT_BGRA64LE                       = ff.AV_PIX_FMT_BGRA64LE
+	AV_PIX_FMT_YVYU422                        = ff.AV_PIX_FMT_YVYU422
+	AV_PIX_FMT_YA16BE                         = ff.AV_PIX_FMT_YA16BE
+	AV_PIX_FMT_YA16LE                         = ff.AV_PIX_FMT_YA16LE
+	AV_PIX_FMT_GBRAP                          = ff.AV_PIX_FMT_GBRAP
+	AV_PIX_FMT_GBRAP16BE                      = ff.AV_PIX_FMT_GBRAP16BE
+	AV_PIX_FMT_GBRAP16LE                      = ff.AV_PIX_FMT_GBRAP16LE
+	AV_PIX_FMT_QSV                            = ff.AV_PIX_FMT_QSV
+	AV_PIX_FMT_MMAL                           = ff.AV_PIX_FMT_MMAL
+	AV_PIX_FMT_D3D11VA_VLD                    = ff.AV_PIX_FMT_D3D11VA_VLD
+	AV_PIX_FMT_CUDA                           = ff.AV_PIX_FMT_CUDA
+	AV_PIX_FMT_0RGB                           = ff.AV_PIX_FMT_0RGB
+	AV_PIX_FMT_RGB0                           = ff.AV_PIX_FMT_RGB0
+	AV_PIX_FMT_0BGR                           = ff.AV_PIX_FMT_0BGR
+	AV_PIX_FMT_BGR0                           = ff.AV_PIX_FMT_BGR0
+	AV_PIX_FMT_YUV420P12BE                    = ff.AV_PIX_FMT_YUV420P12BE
+	AV_PIX_FMT_YUV420P12LE                    = ff.AV_PIX_FMT_YUV420P12LE
+	AV_PIX_FMT_YUV420P14BE                    = ff.AV_PIX_FMT_YUV420P14BE
+	AV_PIX_FMT_YUV420P14LE                    = ff.AV_PIX_FMT_YUV420P14LE
+	AV_PIX_FMT_YUV422P12BE                    = ff.AV_PIX_FMT_YUV422P12BE
+	AV_PIX_FMT_YUV422P12LE                    = ff.AV_PIX_FMT_YUV422P12LE
+	AV_PIX_FMT_YUV422P14BE                    = ff.AV_PIX_FMT_YUV422P14BE
+	AV_PIX_FMT_YUV422P14LE                    = ff.AV_PIX_FMT_YUV422P14LE
+	AV_PIX_FMT_YUV444P12BE                    = ff.AV_PIX_FMT_YUV444P12BE
+	AV_PIX_FMT_YUV444P12LE                    = ff.AV_PIX_FMT_YUV444P12LE
+	AV_PIX_FMT_YUV444P14BE                    = ff.AV_PIX_FMT_YUV444P14BE
+	AV_PIX_FMT_YUV444P14LE                    = ff.AV_PIX_FMT_YUV444P14LE
+	AV_PIX_FMT_GBRP12BE                       = ff.AV_PIX_FMT_GBRP12BE
+	AV_PIX_FMT_GBRP12LE                       = ff.AV_PIX_FMT_GBRP12LE
+	AV_PIX_FMT_GBRP14BE                       = ff.AV_PIX_FMT_GBRP14BE
+	AV_PIX_FMT_GBRP14LE                       = ff.AV_PIX_FMT_GBRP14LE
+	AV_PIX_FMT_YUVJ411P                       = ff.AV_PIX_FMT_YUVJ411P
+	AV_PIX_FMT_BAYER_BGGR8                    = ff.AV_PIX_FMT_BAYER_BGGR8
+	AV_PIX_FMT_BAYER_RGGB8                    = ff.AV_PIX_FMT_BAYER_RGGB8
+	AV_PIX_FMT_BAYER_GBRG8                    = ff.AV_PIX_FMT_BAYER_GBRG8
+	AV_PIX_FMT_BAYER_GRBG8                    = ff.AV_PIX_FMT_BAYER_GRBG8
+	AV_PIX_FMT_BAYER_BGGR16LE                 = ff.AV_PIX_FMT_BAYER_BGGR16LE
+	AV_PIX_FMT_BAYER_BGGR16BE                 = ff.AV_PIX_FMT_BAYER_BGGR16BE
+	AV_PIX_FMT_BAYER_RGGB16LE                 = ff.AV_PIX_FMT_BAYER_RGGB16LE
+	AV_PIX_FMT_BAYER_RGGB16BE                 = ff.AV_PIX_FMT_BAYER_RGGB16BE
+	AV_PIX_FMT_BAYER_GBRG16LE                 = ff.AV_PIX_FMT_BAYER_GBRG16LE
+	AV_PIX_FMT_BAYER_GBRG16BE                 = ff.AV_PIX_FMT_BAYER_GBRG16BE
+	AV_PIX_FMT_BAYER_GRBG16LE                 = ff.AV_PIX_FMT_BAYER_GRBG16LE
+	AV_PIX_FMT_BAYER_GRBG16BE                 = ff.AV_PIX_FMT_BAYER_GRBG16BE
+	AV_PIX_FMT_YUV440P10LE                    = ff.AV_PIX_FMT_YUV440P10LE
+	AV_PIX_FMT_YUV440P10BE                    = ff.AV_PIX_FMT_YUV440P10BE
+	AV_PIX_FMT_YUV440P12LE                    = ff.AV_PIX_FMT_YUV440P12LE
+	AV_PIX_FMT_YUV440P12BE                    = ff.AV_PIX_FMT_YUV440P12BE
+	AV_PIX_FMT_AYUV64LE                       = ff.AV_PIX_FMT_AYUV64LE
+	AV_PIX_FMT_AYUV64BE                       = ff.AV_PIX_FMT_AYUV64BE
+	AV_PIX_FMT_VIDEOTOOLBOX                   = ff.AV_PIX_FMT_VIDEOTOOLBOX
+	AV_PIX_FMT_P010LE                         = ff.AV_PIX_FMT_P010LE
+	AV_PIX_FMT_P010BE                         = ff.AV_PIX_FMT_P010BE
+	AV_PIX_FMT_GBRAP12BE                      = ff.AV_PIX_FMT_GBRAP12BE
+	AV_PIX_FMT_GBRAP12LE                      = ff.AV_PIX_FMT_GBRAP12LE
+	AV_PIX_FMT_GBRAP10BE                      = ff.AV_PIX_FMT_GBRAP10BE
+	AV_PIX_FMT_GBRAP10LE                      = ff.AV_PIX_FMT_GBRAP10LE
+	AV_PIX_FMT_MEDIACODEC                     = ff.AV_PIX_FMT_MEDIACODEC
+	AV_PIX_FMT_GRAY12BE                       = ff.AV_PIX_FMT_GRAY12BE
+	AV_PIX_FMT_GRAY12LE                       = ff.AV_PIX_FMT_GRAY12LE
+	AV_PIX_FMT_GRAY10BE                       = ff.AV_PIX_FMT_GRAY10BE
+	AV_PIX_FMT_GRAY10LE                       = ff.AV_PIX_FMT_GRAY10LE
+	AV_PIX_FMT_P016LE                         = ff.AV_PIX_FMT_P016LE
+	AV_PIX_FMT_P016BE                         = ff.AV_PIX_FMT_P016BE
+	AV_PIX_FMT_D3D11                          = ff.AV_PIX_FMT_D3D11
+	AV_PIX_FMT_GRAY9BE                        = ff.AV_PIX_FMT_GRAY9BE
+	AV_PIX_FMT_GRAY9LE                        = ff.AV_PIX_FMT_GRAY9LE
+	AV_PIX_FMT_GBRPF32BE                      = ff.AV_PIX_FMT_GBRPF32BE
+	AV_PIX_FMT_GBRPF32LE                      = ff.AV_PIX_FMT_GBRPF32LE
+	AV_PIX_FMT_GBRAPF32BE                     = ff.AV_PIX_FMT_GBRAPF32BE
+	AV_PIX_FMT_GBRAPF32LE                     = ff.AV_PIX_FMT_GBRAPF32LE
+	AV_PIX_FMT_DRM_PRIME                      = ff.AV_PIX_FMT_DRM_PRIME
+	AV_PIX_FMT_OPENCL                         = ff.AV_PIX_FMT_OPENCL
+	AV_PIX_FMT_GRAY14BE                       = ff.AV_PIX_FMT_GRAY14BE
+	AV_PIX_FMT_GRAY14LE                       = ff.AV_PIX_FMT_GRAY14LE
+	AV_PIX_FMT_GRAYF32BE                      = ff.AV_PIX_FMT_GRAYF32BE
+	AV_PIX_FMT_GRAYF32LE                      = ff.AV_PIX_FMT_GRAYF32LE
+	AV_PIX_FMT_YUVA422P12BE                   = ff.AV_PIX_FMT_YUVA422P12BE
+	AV_PIX_FMT_YUVA422P12LE                   = ff.AV_PIX_FMT_YUVA422P12LE
+	AV_PIX_FMT_YUVA444P12BE                   = ff.AV_PIX_FMT_YUVA444P12BE
+	AV_PIX_FMT_YUVA444P12LE                   = ff.AV_PIX_FMT_YUVA444P12LE
+	AV_PIX_FMT_NV24                           = ff.AV_PIX_FMT_NV24
+	AV_PIX_FMT_NV42                           = ff.AV_PIX_FMT_NV42
+	AV_PIX_FMT_VULKAN                         = ff.AV_PIX_FMT_VULKAN
+	AV_PIX_FMT_Y210BE                         = ff.AV_PIX_FMT_Y210BE
+	AV_PIX_FMT_Y210LE                         = ff.AV_PIX_FMT_Y210LE
+	AV_PIX_FMT_X2RGB10LE                      = ff.AV_PIX_FMT_X2RGB10LE
+	AV_PIX_FMT_X2RGB10BE                      = ff.AV_PIX_FMT_X2RGB10BE
+	AV_PIX_FMT_X2BGR10LE                      = ff.AV_PIX_FMT_X2BGR10LE
+	AV_PIX_FMT_X2BGR10BE                      = ff.AV_PIX_FMT_X2BGR10BE
+	AV_PIX_FMT_P210BE                         = ff.AV_PIX_FMT_P210BE
+	AV_PIX_FMT_P210LE                         = ff.AV_PIX_FMT_P210LE
+	AV_PIX_FMT_P410BE                         = ff.AV_PIX_FMT_P410BE
+	AV_PIX_FMT_P410LE                         = ff.AV_PIX_FMT_P410LE
+	AV_PIX_FMT_P216BE                         = ff.AV_PIX_FMT_P216BE
+	AV_PIX_FMT_P216LE                         = ff.AV_PIX_FMT_P216LE
+	AV_PIX_FMT_P416BE                         = ff.AV_PIX_FMT_P416BE
+	AV_PIX_FMT_P416LE                         = ff.AV_PIX_FMT_P416LE
+	AV_PIX_FMT_VUYA                           = ff.AV_PIX_FMT_VUYA
+	AV_PIX_FMT_RGBAF16BE                      = ff.AV_PIX_FMT_RGBAF16BE
+	AV_PIX_FMT_RGBAF16LE                      = ff.AV_PIX_FMT_RGBAF16LE
+	AV_PIX_FMT_VUYX                           = ff.AV_PIX_FMT_VUYX
+	AV_PIX_FMT_P012LE                         = ff.AV_PIX_FMT_P012LE
+	AV_PIX_FMT_P012BE                         = ff.AV_PIX_FMT_P012BE
+	AV_PIX_FMT_Y212BE                         = ff.AV_PIX_FMT_Y212BE
+	AV_PIX_FMT_Y212LE                         = ff.AV_PIX_FMT_Y212LE
+	AV_PIX_FMT_XV30BE                         = ff.AV_PIX_FMT_XV30BE
+	AV_PIX_FMT_XV30LE                         = ff.AV_PIX_FMT_XV30LE
+	AV_PIX_FMT_XV36BE                         = ff.AV_PIX_FMT_XV36BE
+	AV_PIX_FMT_XV36LE                         = ff.AV_PIX_FMT_XV36LE
+	AV_PIX_FMT_RGBF32BE                       = ff.AV_PIX_FMT_RGBF32BE
+	AV_PIX_FMT_RGBF32LE                       = ff.AV_PIX_FMT_RGBF32LE
+	AV_PIX_FMT_RGBAF32BE                      = ff.AV_PIX_FMT_RGBAF32BE
+	AV_PIX_FMT_RGBAF32LE                      = ff.AV_PIX_FMT_RGBAF32LE
+	AV_PIX_FMT_P212BE                         = ff.AV_PIX_FMT_P212BE
+	AV_PIX_FMT_P212LE                         = ff.AV_PIX_FMT_P212LE
+	AV_PIX_FMT_P412BE                         = ff.AV_PIX_FMT_P412BE
+	AV_PIX_FMT_P412LE                         = ff.AV_PIX_FMT_P412LE
+	AV_PIX_FMT_GBRAP14BE                      = ff.AV_PIX_FMT_GBRAP14BE
+	AV_PIX_FMT_GBRAP14LE                      = ff.AV_PIX_FMT_GBRAP14LE
+	AV_PIX_FMT_D3D12                          = ff.AV_PIX_FMT_D3D12
+	AV_PIX_FMT_AYUV                           = ff.AV_PIX_FMT_AYUV
+	AV_PIX_FMT_UYVA                           = ff.AV_PIX_FMT_UYVA
+	AV_PIX_FMT_VYU444                         = ff.AV_PIX_FMT_VYU444
+	AV_PIX_FMT_V30XBE                         = ff.AV_PIX_FMT_V30XBE
+	AV_PIX_FMT_V30XLE                         = ff.AV_PIX_FMT_V30XLE
+	AV_PIX_FMT_RGBF16BE                       = ff.AV_PIX_FMT_RGBF16BE
+	AV_PIX_FMT_RGBF16LE                       = ff.AV_PIX_FMT_RGBF16LE
+	AV_PIX_FMT_RGBA128BE                      = ff.AV_PIX_FMT_RGBA128BE
+	AV_PIX_FMT_RGBA128LE                      = ff.AV_PIX_FMT_RGBA128LE
+	AV_PIX_FMT_RGB96BE                        = ff.AV_PIX_FMT_RGB96BE
+	AV_PIX_FMT_RGB96LE                        = ff.AV_PIX_FMT_RGB96LE
+	AV_PIX_FMT_Y216BE                         = ff.AV_PIX_FMT_Y216BE
+	AV_PIX_FMT_Y216LE                         = ff.AV_PIX_FMT_Y216LE
+	AV_PIX_FMT_XV48BE                         = ff.AV_PIX_FMT_XV48BE
+	AV_PIX_FMT_XV48LE                         = ff.AV_PIX_FMT_XV48LE
+	AV_PIX_FMT_GBRPF16BE                      = ff.AV_PIX_FMT_GBRPF16BE
+	AV_PIX_FMT_GBRPF16LE                      = ff.AV_PIX_FMT_GBRPF16LE
+	AV_PIX_FMT_GBRAPF16BE                     = ff.AV_PIX_FMT_GBRAPF16BE
+	AV_PIX_FMT_GBRAPF16LE                     = ff.AV_PIX_FMT_GBRAPF16LE
+	AV_PIX_FMT_GRAYF16BE                      = ff.AV_PIX_FMT_GRAYF16BE
+	AV_PIX_FMT_GRAYF16LE                      = ff.AV_PIX_FMT_GRAYF16LE
+	AV_PIX_FMT_AMF_SURFACE                    = ff.AV_PIX_FMT_AMF_SURFACE
+	AV_PIX_FMT_GRAY32BE                       = ff.AV_PIX_FMT_GRAY32BE
+	AV_PIX_FMT_GRAY32LE                       = ff.AV_PIX_FMT_GRAY32LE
+	AV_PIX_FMT_YAF32BE                        = ff.AV_PIX_FMT_YAF32BE
+	AV_PIX_FMT_YAF32LE                        = ff.AV_PIX_FMT_YAF32LE
+	AV_PIX_FMT_YAF16BE                        = ff.AV_PIX_FMT_YAF16BE
+	AV_PIX_FMT_YAF16LE                        = ff.AV_PIX_FMT_YAF16LE
+	AV_PIX_FMT_GBRAP32BE                      = ff.AV_PIX_FMT_GBRAP32BE
+	AV_PIX_FMT_GBRAP32LE                      = ff.AV_PIX_FMT_GBRAP32LE
+	AV_PIX_FMT_YUV444P10MSBBE                 = ff.AV_PIX_FMT_YUV444P10MSBBE
+	AV_PIX_FMT_YUV444P10MSBLE                 = ff.AV_PIX_FMT_YUV444P10MSBLE
+	AV_PIX_FMT_YUV444P12MSBBE                 = ff.AV_PIX_FMT_YUV444P12MSBBE
+	AV_PIX_FMT_YUV444P12MSBLE                 = ff.AV_PIX_FMT_YUV444P12MSBLE
+	AV_PIX_FMT_GBRP10MSBBE                    = ff.AV_PIX_FMT_GBRP10MSBBE
+	AV_PIX_FMT_GBRP10MSBLE                    = ff.AV_PIX_FMT_GBRP10MSBLE
+	AV_PIX_FMT_GBRP12MSBBE                    = ff.AV_PIX_FMT_GBRP12MSBBE
+	AV_PIX_FMT_GBRP12MSBLE                    = ff.AV_PIX_FMT_GBRP12MSBLE
+	AV_PIX_FMT_OHCODEC                        = ff.AV_PIX_FMT_OHCODEC
+	AVCOL_SPC_RGB                             = ff.AVCOL_SPC_RGB
+	AVCOL_SPC_BT709                           = ff.AVCOL_SPC_BT709
+	AVCOL_SPC_UNSPECIFIED                     = ff.AVCOL_SPC_UNSPECIFIED
+	AVCOL_SPC_RESERVED                        = ff.AVCOL_SPC_RESERVED
+	AVCOL_SPC_FCC                             = ff.AVCOL_SPC_FCC
+	AVCOL_SPC_BT470BG                         = ff.AVCOL_SPC_BT470BG
+	AVCOL_SPC_SMPTE170M                       = ff.AVCOL_SPC_SMPTE170M
+	AVCOL_SPC_SMPTE240M                       = ff.AVCOL_SPC_SMPTE240M
+	AVCOL_SPC_YCGCO                           = ff.AVCOL_SPC_YCGCO
+	AVCOL_SPC_BT2020_NCL                      = ff.AVCOL_SPC_BT2020_NCL
+	AVCOL_SPC_BT2020_CL                       = ff.AVCOL_SPC_BT2020_CL
+	AVCOL_SPC_SMPTE2085                       = ff.AVCOL_SPC_SMPTE2085
+	AVCOL_SPC_CHROMA_DERIVED_NCL              = ff.AVCOL_SPC_CHROMA_DERIVED_NCL
+	AVCOL_SPC_CHROMA_DERIVED_CL               = ff.AVCOL_SPC_CHROMA_DERIVED_CL
+	AVCOL_SPC_ICTCP                           = ff.AVCOL_SPC_ICTCP
+	AVCOL_RANGE_UNSPECIFIED                   = ff.AVCOL_RANGE_UNSPECIFIED
+	AVCOL_RANGE_MPEG                          = ff.AVCOL_RANGE_MPEG
+	AVCOL_RANGE_JPEG                          = ff.AVCOL_RANGE_JPEG
+	AV_PIX_FMT_FLAG_BE                        = ff.AV_PIX_FMT_FLAG_BE
+	AV_PIX_FMT_FLAG_PAL                       = ff.AV_PIX_FMT_FLAG_PAL
+	AV_PIX_FMT_FLAG_BITSTREAM                 = ff.AV_PIX_FMT_FLAG_BITSTREAM
+	AV_PIX_FMT_FLAG_HWACCEL                   = ff.AV_PIX_FMT_FLAG_HWACCEL
+	AV_PIX_FMT_FLAG_PLANAR                    = ff.AV_PIX_FMT_FLAG_PLANAR
+	AV_PIX_FMT_FLAG_RGB                       = ff.AV_PIX_FMT_FLAG_RGB
+	AV_PIX_FMT_FLAG_ALPHA                     = ff.AV_PIX_FMT_FLAG_ALPHA
+	AV_PIX_FMT_FLAG_BAYER                     = ff.AV_PIX_FMT_FLAG_BAYER
+	AV_PIX_FMT_FLAG_FLOAT                     = ff.AV_PIX_FMT_FLAG_FLOAT
+	AV_PIX_FMT_FLAG_XYZ                       = ff.AV_PIX_FMT_FLAG_XYZ
+	AV_ROUND_ZERO                             = ff.AV_ROUND_ZERO
+	AV_ROUND_INF                              = ff.AV_ROUND_INF
+	AV_ROUND_DOWN                             = ff.AV_ROUND_DOWN
+	AV_ROUND_UP                               = ff.AV_ROUND_UP
+	AV_ROUND_NEAR_INF                         = ff.AV_ROUND_NEAR_INF
+	AV_ROUND_PASS_MINMAX                      = ff.AV_ROUND_PASS_MINMAX
+	AV_SAMPLE_FMT_NONE                        = ff.AV_SAMPLE_FMT_NONE
+	AV_SAMPLE_FMT_U8                          = ff.AV_SAMPLE_FMT_U8
+	AV_SAMPLE_FMT_S16                         = ff.AV_SAMPLE_FMT_S16
+	AV_SAMPLE_FMT_S32                         = ff.AV_SAMPLE_FMT_S32
+	AV_SAMPLE_FMT_FLT                         = ff.AV_SAMPLE_FMT_FLT
+	AV_SAMPLE_FMT_DBL                         = ff.AV_SAMPLE_FMT_DBL
+	AV_SAMPLE_FMT_U8P                         = ff.AV_SAMPLE_FMT_U8P
+	AV_SAMPLE_FMT_S16P                        = ff.AV_SAMPLE_FMT_S16P
+	AV_SAMPLE_FMT_S32P                        = ff.AV_SAMPLE_FMT_S32P
+	AV_SAMPLE_FMT_FLTP                        = ff.AV_SAMPLE_FMT_FLTP
+	AV_SAMPLE_FMT_DBLP                        = ff.AV_SAMPLE_FMT_DBLP
+	AV_SAMPLE_FMT_S64                         = ff.AV_SAMPLE_FMT_S64
+	AV_SAMPLE_FMT_S64P                        = ff.AV_SAMPLE_FMT_S64P
+	AV_SAMPLE_FMT_NB                          = ff.AV_SAMPLE_FMT_NB
+	AV_SPHERICAL_EQUIRECTANGULAR              = ff.AV_SPHERICAL_EQUIRECTANGULAR
+	AV_SPHERICAL_CUBEMAP                      = ff.AV_SPHERICAL_CUBEMAP
+	AV_SPHERICAL_EQUIRECTANGULAR_TILE         = ff.AV_SPHERICAL_EQUIRECTANGULAR_TILE
+	AV_STEREO3D_2D                            = ff.AV_STEREO3D_2D
+	AV_STEREO3D_SIDEBYSIDE                    = ff.AV_STEREO3D_SIDEBYSIDE
+	AV_STEREO3D_TOPBOTTOM                     = ff.AV_STEREO3D_TOPBOTTOM
+	AV_STEREO3D_FRAMESEQUENCE                 = ff.AV_STEREO3D_FRAMESEQUENCE
+	AV_STEREO3D_CHECKERBOARD                  = ff.AV_STEREO3D_CHECKERBOARD
+	AV_STEREO3D_SIDEBYSIDE_QUINCUNX           = ff.AV_STEREO3D_SIDEBYSIDE_QUINCUNX
+	AV_STEREO3D_LINES                         = ff.AV_STEREO3D_LINES
+	AV_STEREO3D_COLUMNS                       = ff.AV_STEREO3D_COLUMNS
+	AV_STEREO3D_UNSPEC                        = ff.AV_STEREO3D_UNSPEC
+	AV_STEREO3D_FLAG_INVERT                   = ff.AV_STEREO3D_FLAG_INVERT
+	AV_NOPTS_VALUE                            = ff.AV_NOPTS_VALUE
+	AV_TIME_BASE                              = ff.AV_TIME_BASE
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// FUNCTIONS
+
+var (
+	AVUtil_audio_fifo_alloc                            = ff.AVUtil_audio_fifo_alloc
+	AVUtil_audio_fifo_free                             = ff.AVUtil_audio_fifo_free
+	AVUtil_audio_fifo_realloc                          = ff.AVUtil_audio_fifo_realloc
+	AVUtil_audio_fifo_write                            = ff.AVUtil_audio_fifo_write
+	AVUtil_audio_fifo_read                             = ff.AVUtil_audio_fifo_read
+	AVUtil_audio_fifo_peek                             = ff.AVUtil_audio_fifo_peek
+	AVUtil_audio_fifo_drain                            = ff.AVUtil_audio_fifo_drain
+	AVUtil_audio_fifo_reset                            = ff.AVUtil_audio_fifo_reset
+	AVUtil_audio_fifo_size                             = ff.AVUtil_audio_fifo_size
+	AVUtil_audio_fifo_space                            = ff.AVUtil_audio_fifo_space
+	AVUtil_channel_name                                = ff.AVUtil_channel_name
+	AVUtil_channel_description                         = ff.AVUtil_channel_description
+	AVUtil_channel_from_string                         = ff.AVUtil_channel_from_string
+	AVUtil_channel_layout_standard                     = ff.AVUtil_channel_layout_standard
+	AVUtil_channel_layout_describe                     = ff.AVUtil_channel_layout_describe
+	AVUtil_channel_layout_default                      = ff.AVUtil_channel_layout_default
+	AVUtil_channel_layout_from_string                  = ff.AVUtil_channel_layout_from_string
+	AVUtil_channel_layout_uninit                       = ff.AVUtil_channel_layout_uninit
+	AVUtil_channel_layout_channel_from_index           = ff.AVUtil_channel_layout_channel_from_index
+	AVUtil_channel_layout_index_from_channel           = ff.AVUtil_channel_layout_index_from_channel
+	AVUtil_get_channel_layout_nb_channels              = ff.AVUtil_get_channel_layout_nb_channels
+	AVUtil_channel_layout_check                        = ff.AVUtil_channel_layout_check
+	AVUtil_channel_layout_compare                      = ff.AVUtil_channel_layout_compare
+	AV_CHANNEL_LAYOUT_MONO                             = ff.AV_CHANNEL_LAYOUT_MONO
+	AV_CHANNEL_LAYOUT_STEREO                           = ff.AV_CHANNEL_LAYOUT_STEREO
+	AV_CHANNEL_LAYOUT_2POINT1                          = ff.AV_CHANNEL_LAYOUT_2POINT1
+	AV_CHANNEL_LAYOUT_2_1                              = ff.AV_CHANNEL_LAYOUT_2_1
+	AV_CHANNEL_LAYOUT_SURROUND                         = ff.AV_CHANNEL_LAYOUT_SURROUND
+	AV_CHANNEL_LAYOUT_3POINT1                          = ff.AV_CHANNEL_LAYOUT_3POINT1
+	AV_CHANNEL_LAYOUT_4POINT0                          = ff.AV_CHANNEL_LAYOUT_4POINT0
+	AV_CHANNEL_LAYOUT_4POINT1                          = ff.AV_CHANNEL_LAYOUT_4POINT1
+	AV_CHANNEL_LAYOUT_2_2                              = ff.AV_CHANNEL_LAYOUT_2_2
+	AV_CHANNEL_LAYOUT_QUAD                             = ff.AV_CHANNEL_LAYOUT_QUAD
+	AV_CHANNEL_LAYOUT_5POINT0                          = ff.AV_CHANNEL_LAYOUT_5POINT0
+	AV_CHANNEL_LAYOUT_5POINT1                          = ff.AV_CHANNEL_LAYOUT_5POINT1
+	AV_CHANNEL_LAYOUT_5POINT0_BACK                     = ff.AV_CHANNEL_LAYOUT_5POINT0_BACK
+	AV_CHANNEL_LAYOUT_5POINT1_BACK                     = ff.AV_CHANNEL_LAYOUT_5POINT1_BACK
+	AV_CHANNEL_LAYOUT_6POINT0                          = ff.AV_CHANNEL_LAYOUT_6POINT0
+	AV_CHANNEL_LAYOUT_6POINT0_FRONT                    = ff.AV_CHANNEL_LAYOUT_6POINT0_FRONT
+	AV_CHANNEL_LAYOUT_HEXAGONAL                        = ff.AV_CHANNEL_LAYOUT_HEXAGONAL
+	AV_CHANNEL_LAYOUT_6POINT1                          = ff.AV_CHANNEL_LAYOUT_6POINT1
+	AV_CHANNEL_LAYOUT_6POINT1_BACK                     = ff.AV_CHANNEL_LAYOUT_6POINT1_BACK
+	AV_CHANNEL_LAYOUT_6POINT1_FRONT                    = ff.AV_CHANNEL_LAYOUT_6POINT1_FRONT
+	AV_CHANNEL_LAYOUT_7POINT0                          = ff.AV_CHANNEL_LAYOUT_7POINT0
+	AV_CHANNEL_LAYOUT_7POINT0_FRONT                    = ff.AV_CHANNEL_LAYOUT_7POINT0_FRONT
+	AV_CHANNEL_LAYOUT_7POINT1                          = ff.AV_CHANNEL_LAYOUT_7POINT1
+	AV_CHANNEL_LAYOUT_7POINT1_WIDE                     = ff.AV_CHANNEL_LAYOUT_7POINT1_WIDE
+	AV_CHANNEL_LAYOUT_7POINT1_WIDE_BACK                = ff.AV_CHANNEL_LAYOUT_7POINT1_WIDE_BACK
+	AV_CHANNEL_LAYOUT_OCTAGONAL                        = ff.AV_CHANNEL_LAYOUT_OCTAGONAL
+	AV_CHANNEL_LAYOUT_HEXADECAGONAL                    = ff.AV_CHANNEL_LAYOUT_HEXADECAGONAL
+	AV_CHANNEL_LAYOUT_STEREO_DOWNMIX                   = ff.AV_CHANNEL_LAYOUT_STEREO_DOWNMIX
+	AV_CHANNEL_LAYOUT_22POINT2                         = ff.AV_CHANNEL_LAYOUT_22POINT2
+	AV_CHANNEL_LAYOUT_AMBISONIC_FIRST_ORDER            = ff.AV_CHANNEL_LAYOUT_AMBISONIC_FIRST_ORDER
+	AVUtil_dict_alloc                                  = ff.AVUtil_dict_alloc
+	AVUtil_dict_free                                   = ff.AVUtil_dict_free
+	AVUtil_dict_copy                                   = ff.AVUtil_dict_copy
+	AVUtil_dict_count                                  = ff.AVUtil_dict_count
+	AVUtil_dict_set                                    = ff.AVUtil_dict_set
+	AVUtil_dict_delete                                 = ff.AVUtil_dict_delete
+	AVUtil_dict_get                                    = ff.AVUtil_dict_get
+	AVUtil_dict_keys                                   = ff.AVUtil_dict_keys
+	AVUtil_dict_entries                                = ff.AVUtil_dict_entries
+	AVUtil_dict_parse_string                           = ff.AVUtil_dict_parse_string
+	AVUtil_display_matrix                              = ff.AVUtil_display_matrix
+	AVUtil_display_rotation_get                        = ff.AVUtil_display_rotation_get
+	AVUtil_display_rotation_set                        = ff.AVUtil_display_rotation_set
+	AVUtil_display_matrix_flip                         = ff.AVUtil_display_matrix_flip
+	AVUtil_frame_alloc                                 = ff.AVUtil_frame_alloc
+	AVUtil_frame_free                                  = ff.AVUtil_frame_free
+	AVUtil_frame_unref                                 = ff.AVUtil_frame_unref
+	AVUtil_frame_get_buffer                            = ff.AVUtil_frame_get_buffer
+	AVUtil_frame_is_allocated                          = ff.AVUtil_frame_is_allocated
+	AVUtil_frame_make_writable                         = ff.AVUtil_frame_make_writable
+	AVUtil_frame_get_num_planes                        = ff.AVUtil_frame_get_num_planes
+	AVUtil_frame_copy                                  = ff.AVUtil_frame_copy
+	AVUtil_frame_copy_props                            = ff.AVUtil_frame_copy_props
+	AVUtil_frame_get_side_data                         = ff.AVUtil_frame_get_side_data
+	AVUtil_frame_new_side_data                         = ff.AVUtil_frame_new_side_data
+	AVUtil_frame_remove_side_data                      = ff.AVUtil_frame_remove_side_data
+	AVUtil_dynamic_hdr_plus                            = ff.AVUtil_dynamic_hdr_plus
+	AVUtil_dynamic_hdr_plus_from_t35                   = ff.AVUtil_dynamic_hdr_plus_from_t35
+	AVUtil_dynamic_hdr_plus_create_side_data           = ff.AVUtil_dynamic_hdr_plus_create_side_data
+	AVUtil_log_set_level                               = ff.AVUtil_log_set_level
+	AVUtil_log_get_level                               = ff.AVUtil_log_get_level
+	AVUtil_log                                         = ff.AVUtil_log
+	AVUtil_log_set_callback                            = ff.AVUtil_log_set_callback
+	AVUtil_log_item_name                               = ff.AVUtil_log_item_name
+	AVUtil_log_category                                = ff.AVUtil_log_category
+	AVUtil_mastering_display_metadata                  = ff.AVUtil_mastering_display_metadata
+	AVUtil_mastering_display_metadata_create_side_data = ff.AVUtil_mastering_display_metadata_create_side_data
+	AVUtil_content_light_metadata                      = ff.AVUtil_content_light_metadata
+	AVUtil_content_light_metadata_create_side_data     = ff.AVUtil_content_light_metadata_create_side_data
+	AVUtil_opt_show2                                   = ff.AVUtil_opt_show2
+	AVUtil_opt_set_defaults                            = ff.AVUtil_opt_set_defaults
+	AVUtil_opt_set_defaults2                           = ff.AVUtil_opt_set_defaults2
+	AVUtil_opt_set                                     = ff.AVUtil_opt_set
+	AVUtil_opt_set_int                                 = ff.AVUtil_opt_set_int
+	AVUtil_opt_set_double                              = ff.AVUtil_opt_set_double
+	AVUtil_opt_set_q                                   = ff.AVUtil_opt_set_q
+	AVUtil_opt_set_bin                                 = ff.AVUtil_opt_set_bin
+	AVUtil_opt_set_image_size                          = ff.AVUtil_opt_set_image_size
+	AVUtil_opt_set_pixel_fmt                           = ff.AVUtil_opt_set_pixel_fmt
+	AVUtil_opt_set_sample_fmt                          = ff.AVUtil_opt_set_sample_fmt
+	AVUtil_opt_set_video_rate                          = ff.AVUtil_opt_set_video_rate
+	AVUtil_opt_set_channel_layout                      = ff.AVUtil_opt_set_channel_layout
+	AVUtil_opt_get                                     = ff.AVUtil_opt_get
+	AVUtil_opt_get_int                                 = ff.AVUtil_opt_get_int
+	AVUtil_opt_get_double                              = ff.AVUtil_opt_get_double
+	AVUtil_opt_get_q                                   = ff.AVUtil_opt_get_q
+	AVUtil_opt_get_image_size                          = ff.AVUtil_opt_get_image_size
+	AVUtil_opt_get_pixel_fmt                           = ff.AVUtil_opt_get_pixel_fmt
+	AVUtil_opt_get_sample_fmt                          = ff.AVUtil_opt_get_sample_fmt
+	AVUtil_opt_get_video_rate                          = ff.AVUtil_opt_get_video_rate
+	AVUtil_opt_get_channel_layout                      = ff.AVUtil_opt_get_channel_layout
+	AVUtil_opt_find                                    = ff.AVUtil_opt_find
+	AVUtil_opt_find2                                   = ff.AVUtil_opt_find2
+	AVUtil_opt_copy                                    = ff.AVUtil_opt_copy
+	AVUtil_opt_query_ranges                            = ff.AVUtil_opt_query_ranges
+	AVUtil_opt_freep_ranges                            = ff.AVUtil_opt_freep_ranges
+	AVUtil_opt_is_set_to_default                       = ff.AVUtil_opt_is_set_to_default
+	AVUtil_opt_is_set_to_default_by_name               = ff.AVUtil_opt_is_set_to_default_by_name
+	AVUtil_opt_next                                    = ff.AVUtil_opt_next
+	AVUtil_opt_list                                    = ff.AVUtil_opt_list
+	AVUtil_opt_list_from_class                         = ff.AVUtil_opt_list_from_class
+	AVUtil_opt_serialize                               = ff.AVUtil_opt_serialize
+	AVUtil_parse_video_size                            = ff.AVUtil_parse_video_size
+	AVUtil_parse_video_rate                            = ff.AVUtil_parse_video_rate
+	AVUtil_parse_time                                  = ff.AVUtil_parse_time
+	AVUtil_get_picture_type_char                       = ff.AVUtil_get_picture_type_char
+	AVUtil_next_pixel_fmt                              = ff.AVUtil_next_pixel_fmt
+	AVUtil_get_pix_fmt_name                            = ff.AVUtil_get_pix_fmt_name
+	AVUtil_get_pix_fmt                                 = ff.AVUtil_get_pix_fmt
+	AVUtil_get_pix_fmt_desc                            = ff.AVUtil_get_pix_fmt_desc
+	AVUtil_pix_fmt_count_planes                        = ff.AVUtil_pix_fmt_count_planes
+	AVUtil_get_bits_per_pixel                          = ff.AVUtil_get_bits_per_pixel
+	AVUtil_get_padded_bits_per_pixel                   = ff.AVUtil_get_padded_bits_per_pixel
+	AVUtil_pix_fmt_num_components                      = ff.AVUtil_pix_fmt_num_components
+	AVUtil_pix_fmt_flags                               = ff.AVUtil_pix_fmt_flags
+	AVUtil_pix_fmt_is_planar                           = ff.AVUtil_pix_fmt_is_planar
+	AVUtil_pix_fmt_is_rgb                              = ff.AVUtil_pix_fmt_is_rgb
+	AVUtil_pix_fmt_has_alpha                           = ff.AVUtil_pix_fmt_has_alpha
+	AVUtil_pix_fmt_is_hwaccel                          = ff.AVUtil_pix_fmt_is_hwaccel
+	AVUtil_pix_fmt_is_float                            = ff.AVUtil_pix_fmt_is_float
+	AVUtil_pix_fmt_is_be                               = ff.AVUtil_pix_fmt_is_be
+	AVUtil_rational                                    = ff.AVUtil_rational
+	AVUtil_rational_d2q                                = ff.AVUtil_rational_d2q
+	AVUtil_rational_q2d                                = ff.AVUtil_rational_q2d
+	AVUtil_rational_equal                              = ff.AVUtil_rational_equal
+	AVUtil_rational_invert                             = ff.AVUtil_rational_invert
+	AVUtil_rational_rescale_q                          = ff.AVUtil_rational_rescale_q
+	AVUtil_rescale_rnd                                 = ff.AVUtil_rescale_rnd
+	AVUtil_compare_ts                                  = ff.AVUtil_compare_ts
+	AVUtil_replaygain                                  = ff.AVUtil_replaygain
+	AVUtil_next_sample_fmt                             = ff.AVUtil_next_sample_fmt
+	AVUtil_get_sample_fmt_name                         = ff.AVUtil_get_sample_fmt_name
+	AVUtil_get_sample_fmt                              = ff.AVUtil_get_sample_fmt
+	AVUtil_get_bytes_per_sample                        = ff.AVUtil_get_bytes_per_sample
+	AVUtil_sample_fmt_is_planar                        = ff.AVUtil_sample_fmt_is_planar
+	AVUtil_get_packed_sample_fmt                       = ff.AVUtil_get_packed_sample_fmt
+	AVUtil_get_planar_sample_fmt                       = ff.AVUtil_get_planar_sample_fmt
+	AVUtil_spherical                                   = ff.AVUtil_spherical
+	AVUtil_stereo3d                                    = ff.AVUtil_stereo3d
+	AVUtil_stereo3d_create_side_data                   = ff.AVUtil_stereo3d_create_side_data
+	AVUtil_ts_make_string                              = ff.AVUtil_ts_make_string
+	AVUtil_ts_make_time_string                         = ff.AVUtil_ts_make_time_string
+	AVUtil_ts2str                                      = ff.AVUtil_ts2str
+	AVUtil_ts2timestr                                  = ff.AVUtil_ts2timestr
+	AVUtil_version                                     = ff.AVUtil_version
+	AVUtil_configuration                               = ff.AVUtil_configuration
+	AVUtil_license                                     = ff.AVUtil_license
+)
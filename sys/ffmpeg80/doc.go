@@ -1,8 +1,9 @@
 /*
-The low-level ffmpeg bindings for ffmpeg version 8.0. It's more likely
-you would use the higher-level package github.com/mutablelogic/go-media/pkg/ffmpeg
-which provides a more idiomatic interface to ffmpeg.
+Package ffmpeg forwards to the low-level ffmpeg bindings in
+github.com/mutablelogic/go-media/sys/ffmpeg, which were in this package when
+it only built against ffmpeg 8.0. The types are aliases, so values can be
+passed between the two packages.
 
-Ref: https://ffmpeg.org/doxygen/8.0/index.html
+Deprecated: use github.com/mutablelogic/go-media/sys/ffmpeg instead.
 */
 package ffmpeg
@@ -1,27 +1,35 @@
 package ffmpeg
 
-////////////////////////////////////////////////////////////////////////////////
-// CGO
-
-/*
-#cgo pkg-config: libswresample
-#include <libswresample/swresample.h>
-*/
-import "C"
+import (
+	// Packages
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
+)
 
 ////////////////////////////////////////////////////////////////////////////////
 // TYPES
 
 type (
-	SWRContext C.struct_SwrContext
+	SWRContext = ff.SWRContext
 )
 
 ////////////////////////////////////////////////////////////////////////////////
-// STRINGIFY
+// FUNCTIONS
 
-func (ctx *SWRContext) String() string {
-	if ctx == nil {
-		return "<nil>"
-	}
-	return "<SWRContext>"
-}
+var (
+	SWResample_next_pts        = ff.SWResample_next_pts
+	SWResample_drop_output     = ff.SWResample_drop_output
+	SWResample_inject_silence  = ff.SWResample_inject_silence
+	SWResample_get_delay       = ff.SWResample_get_delay
+	SWResample_get_out_samples = ff.SWResample_get_out_samples
+	SWResample_convert_frame   = ff.SWResample_convert_frame
+	SWResample_config_frame    = ff.SWResample_config_frame
+	SWResample_alloc           = ff.SWResample_alloc
+	SWResample_free            = ff.SWResample_free
+	SWResample_init            = ff.SWResample_init
+	SWResample_close           = ff.SWResample_close
+	SWResample_is_initialized  = ff.SWResample_is_initialized
+	SWResample_set_opts        = ff.SWResample_set_opts
+	SWResample_version         = ff.SWResample_version
+	SWResample_configuration   = ff.SWResample_configuration
+	SWResample_license         = ff.SWResample_license
+)
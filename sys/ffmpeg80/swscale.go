@@ -1,100 +1,55 @@
 package ffmpeg
 
-////////////////////////////////////////////////////////////////////////////////
-// CGO
-
-/*
-#cgo pkg-config: libswscale
-#include <libswscale/swscale.h>
-*/
-import "C"
+import (
+	// Packages
+	ff "github.com/mutablelogic/go-media/sys/ffmpeg"
+)
 
 ////////////////////////////////////////////////////////////////////////////////
 // TYPES
 
 type (
-	SWSContext C.struct_SwsContext
-	SWSFilter  C.struct_SwsFilter
-	SWSFlag    C.int
+	SWSContext = ff.SWSContext
+	SWSFilter  = ff.SWSFilter
+	SWSFlag    = ff.SWSFlag
 )
 
 ////////////////////////////////////////////////////////////////////////////////
 // CONSTANTS
 
 const (
-	SWS_NONE          SWSFlag = 0
-	SWS_FAST_BILINEAR SWSFlag = C.SWS_FAST_BILINEAR
-	SWS_BILINEAR      SWSFlag = C.SWS_BILINEAR
-	SWS_BICUBIC       SWSFlag = C.SWS_BICUBIC
-	SWS_X             SWSFlag = C.SWS_X
-	SWS_POINT         SWSFlag = C.SWS_POINT
-	SWS_AREA          SWSFlag = C.SWS_AREA
-	SWS_BICUBLIN      SWSFlag = C.SWS_BICUBLIN
-	SWS_GAUSS         SWSFlag = C.SWS_GAUSS
-	SWS_SINC          SWSFlag = C.SWS_SINC
-	SWS_LANCZOS       SWSFlag = C.SWS_LANCZOS
-	SWS_SPLINE        SWSFlag = C.SWS_SPLINE
-	SWS_MIN                   = SWS_FAST_BILINEAR
-	SWS_MAX                   = SWS_SPLINE
+	SWS_NONE          = ff.SWS_NONE
+	SWS_FAST_BILINEAR = ff.SWS_FAST_BILINEAR
+	SWS_BILINEAR      = ff.SWS_BILINEAR
+	SWS_BICUBIC       = ff.SWS_BICUBIC
+	SWS_X             = ff.SWS_X
+	SWS_POINT         = ff.SWS_POINT
+	SWS_AREA          = ff.SWS_AREA
+	SWS_BICUBLIN      = ff.SWS_BICUBLIN
+	SWS_GAUSS         = ff.SWS_GAUSS
+	SWS_SINC          = ff.SWS_SINC
+	SWS_LANCZOS       = ff.SWS_LANCZOS
+	SWS_SPLINE        = ff.SWS_SPLINE
+	SWS_MIN           = ff.SWS_MIN
+	SWS_MAX           = ff.SWS_MAX
 )
 
 ////////////////////////////////////////////////////////////////////////////////
-// STRINGIFY
-
-func (v SWSFlag) FlagString() string {
-	switch v {
-	case SWS_NONE:
-		return "SWS_NONE"
-	case SWS_FAST_BILINEAR:
-		return "SWS_FAST_BILINEAR"
-	case SWS_BILINEAR:
-		return "SWS_BILINEAR"
-	case SWS_BICUBIC:
-		return "SWS_BICUBIC"
-	case SWS_X:
-		return "SWS_X"
-	case SWS_POINT:
-		return "SWS_POINT"
-	case SWS_AREA:
-		return "SWS_AREA"
-	case SWS_BICUBLIN:
-		return "SWS_BICUBLIN"
-	case SWS_GAUSS:
-		return "SWS_GAUSS"
-	case SWS_SINC:
-		return "SWS_SINC"
-	case SWS_LANCZOS:
-		return "SWS_LANCZOS"
-	case SWS_SPLINE:
-		return "SWS_SPLINE"
-	default:
-		return "[?? Invalid SWSFlag value]"
-	}
-}
-
-func (v SWSFlag) String() string {
-	if v == SWS_NONE {
-		return v.FlagString()
-	}
-	str := ""
-	for i := SWS_MIN; i <= SWS_MAX; i <<= 1 {
-		if v&i != 0 {
-			str += "|" + i.FlagString()
-		}
-	}
-	return str[1:]
-}
-
-func (ctx *SWSContext) String() string {
-	if ctx == nil {
-		return "<nil>"
-	}
-	return "<SWSContext>"
-}
-
-func (filter *SWSFilter) String() string {
-	if filter == nil {
-		return "<nil>"
-	}
-	return "<SWSFilter>"
-}
+// FUNCTIONS
+
+var (
+	SWScale_alloc_context      = ff.SWScale_alloc_context
+	SWScale_init_context       = ff.SWScale_init_context
+	SWScale_free_context       = ff.SWScale_free_context
+	SWScale_get_context        = ff.SWScale_get_context
+	SWScale_get_cached_context = ff.SWScale_get_cached_context
+	SWScale_scale              = ff.SWScale_scale
+	SWScale_scale_frame        = ff.SWScale_scale_frame
+	SWScale_frame_start        = ff.SWScale_frame_start
+	SWScale_frame_end          = ff.SWScale_frame_end
+	SWScale_send_slice         = ff.SWScale_send_slice
+	SWScale_receive_slice      = ff.SWScale_receive_slice
+	SWScale_version            = ff.SWScale_version
+	SWScale_configuration      = ff.SWScale_configuration
+	SWScale_license            = ff.SWScale_license
+)
@@ -10,6 +10,7 @@ import "unsafe"
 #include <stdlib.h>
 #include <libheif/heif_encoding.h>
 #include <libheif/heif_context.h>
+#include <libheif/heif_metadata.h>
 */
 import "C"
 
@@ -513,6 +514,62 @@ func Libheif_context_encode_image(ctx *Context, img *Image, encoder *Encoder, op
 	return nil, err
 }
 
+func Libheif_context_encode_thumbnail(ctx *Context, img *Image, master *ImageHandle, encoder *Encoder, options *EncodingOptions, bboxSize int) (*ImageHandle, error) {
+	var handle *C.heif_image_handle
+	var coptions *C.heif_encoding_options
+	if options != nil {
+		coptions = (*C.heif_encoding_options)(options)
+	}
+	cerr := C.heif_context_encode_thumbnail(
+		(*C.heif_context)(ctx),
+		(*C.heif_image)(img),
+		(*C.heif_image_handle)(master),
+		(*C.heif_encoder)(encoder),
+		coptions,
+		C.int(bboxSize),
+		&handle,
+	)
+	err := fromCError(cerr)
+	if err.Code == HEIF_ERROR_OK {
+		return (*ImageHandle)(handle), nil
+	}
+	return nil, err
+}
+
+func Libheif_context_add_exif_metadata(ctx *Context, handle *ImageHandle, data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+	cerr := C.heif_context_add_exif_metadata(
+		(*C.heif_context)(ctx),
+		(*C.heif_image_handle)(handle),
+		unsafe.Pointer(&data[0]),
+		C.int(len(data)),
+	)
+	err := fromCError(cerr)
+	if err.Code == HEIF_ERROR_OK {
+		return nil
+	}
+	return err
+}
+
+func Libheif_context_add_XMP_metadata(ctx *Context, handle *ImageHandle, data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+	cerr := C.heif_context_add_XMP_metadata(
+		(*C.heif_context)(ctx),
+		(*C.heif_image_handle)(handle),
+		unsafe.Pointer(&data[0]),
+		C.int(len(data)),
+	)
+	err := fromCError(cerr)
+	if err.Code == HEIF_ERROR_OK {
+		return nil
+	}
+	return err
+}
+
 func Libheif_context_write_to_file(ctx *Context, filename string) error {
 	cfilename := C.CString(filename)
 	defer C.free(unsafe.Pointer(cfilename))
@@ -18,7 +18,38 @@ import "C"
 // TYPES
 
 type (
-	ImageHandle C.heif_image_handle
+	ImageHandle             C.heif_image_handle
+	DepthRepresentationType C.enum_heif_depth_representation_type
+)
+
+// DepthRepresentationInfo describes how the samples of a depth image map
+// onto distances. The Has* fields indicate which of the limits are present.
+type DepthRepresentationInfo struct {
+	Type                   DepthRepresentationType
+	HasZNear               bool
+	HasZFar                bool
+	HasDMin                bool
+	HasDMax                bool
+	ZNear                  float64
+	ZFar                   float64
+	DMin                   float64
+	DMax                   float64
+	DisparityReferenceView int
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// CONSTS
+
+const (
+	HEIF_DEPTH_REPRESENTATION_TYPE_UNIFORM_INVERSE_Z    DepthRepresentationType = C.heif_depth_representation_type_uniform_inverse_Z
+	HEIF_DEPTH_REPRESENTATION_TYPE_UNIFORM_DISPARITY    DepthRepresentationType = C.heif_depth_representation_type_uniform_disparity
+	HEIF_DEPTH_REPRESENTATION_TYPE_UNIFORM_Z            DepthRepresentationType = C.heif_depth_representation_type_uniform_Z
+	HEIF_DEPTH_REPRESENTATION_TYPE_NONUNIFORM_DISPARITY DepthRepresentationType = C.heif_depth_representation_type_nonuniform_disparity
+)
+
+const (
+	LIBHEIF_AUX_IMAGE_FILTER_OMIT_ALPHA = C.LIBHEIF_AUX_IMAGE_FILTER_OMIT_ALPHA
+	LIBHEIF_AUX_IMAGE_FILTER_OMIT_DEPTH = C.LIBHEIF_AUX_IMAGE_FILTER_OMIT_DEPTH
 )
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -105,6 +136,130 @@ func Libheif_image_handle_get_thumbnail(handle *ImageHandle, thumbnailID ItemID)
 	return nil, err
 }
 
+////////////////////////////////////////////////////////////////////////////////
+// BINDINGS - AUXILIARY IMAGES
+
+func Libheif_image_handle_get_number_of_auxiliary_images(handle *ImageHandle, filter int) int {
+	return int(C.heif_image_handle_get_number_of_auxiliary_images((*C.heif_image_handle)(handle), C.int(filter)))
+}
+
+func Libheif_image_handle_get_list_of_auxiliary_image_IDs(handle *ImageHandle, filter int, count int) []ItemID {
+	if count <= 0 {
+		return nil
+	}
+
+	ids := make([]C.heif_item_id, count)
+	n := C.heif_image_handle_get_list_of_auxiliary_image_IDs((*C.heif_image_handle)(handle), C.int(filter), &ids[0], C.int(count))
+	if n <= 0 {
+		return nil
+	}
+	if int(n) > len(ids) {
+		n = C.int(len(ids))
+	}
+
+	result := make([]ItemID, int(n))
+	for i := 0; i < int(n); i++ {
+		result[i] = ItemID(ids[i])
+	}
+	return result
+}
+
+func Libheif_image_handle_get_auxiliary_image_handle(handle *ImageHandle, auxiliaryID ItemID) (*ImageHandle, error) {
+	var aux *C.heif_image_handle
+	cerr := C.heif_image_handle_get_auxiliary_image_handle(
+		(*C.heif_image_handle)(handle),
+		C.heif_item_id(auxiliaryID),
+		&aux,
+	)
+	err := fromCError(cerr)
+	if err.Code == HEIF_ERROR_OK {
+		return (*ImageHandle)(aux), nil
+	}
+	return nil, err
+}
+
+// Return the type URN of an auxiliary image handle, for example
+// "urn:mpeg:hevc:2015:auxid:1" for an alpha plane.
+func Libheif_image_handle_get_auxiliary_type(handle *ImageHandle) (string, error) {
+	var ctype *C.char
+	cerr := C.heif_image_handle_get_auxiliary_type((*C.heif_image_handle)(handle), &ctype)
+	err := fromCError(cerr)
+	if err.Code != HEIF_ERROR_OK {
+		return "", err
+	}
+	defer C.heif_image_handle_release_auxiliary_type((*C.heif_image_handle)(handle), &ctype)
+	return C.GoString(ctype), nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// BINDINGS - DEPTH IMAGES
+
+func Libheif_image_handle_has_depth_image(handle *ImageHandle) bool {
+	return C.heif_image_handle_has_depth_image((*C.heif_image_handle)(handle)) != 0
+}
+
+func Libheif_image_handle_get_number_of_depth_images(handle *ImageHandle) int {
+	return int(C.heif_image_handle_get_number_of_depth_images((*C.heif_image_handle)(handle)))
+}
+
+func Libheif_image_handle_get_list_of_depth_image_IDs(handle *ImageHandle, count int) []ItemID {
+	if count <= 0 {
+		return nil
+	}
+
+	ids := make([]C.heif_item_id, count)
+	n := C.heif_image_handle_get_list_of_depth_image_IDs((*C.heif_image_handle)(handle), &ids[0], C.int(count))
+	if n <= 0 {
+		return nil
+	}
+	if int(n) > len(ids) {
+		n = C.int(len(ids))
+	}
+
+	result := make([]ItemID, int(n))
+	for i := 0; i < int(n); i++ {
+		result[i] = ItemID(ids[i])
+	}
+	return result
+}
+
+func Libheif_image_handle_get_depth_image_handle(handle *ImageHandle, depthID ItemID) (*ImageHandle, error) {
+	var depth *C.heif_image_handle
+	cerr := C.heif_image_handle_get_depth_image_handle(
+		(*C.heif_image_handle)(handle),
+		C.heif_item_id(depthID),
+		&depth,
+	)
+	err := fromCError(cerr)
+	if err.Code == HEIF_ERROR_OK {
+		return (*ImageHandle)(depth), nil
+	}
+	return nil, err
+}
+
+// Return the depth representation info for a depth image, or nil if the
+// depth image does not carry any.
+func Libheif_image_handle_get_depth_image_representation_info(handle *ImageHandle, depthID ItemID) *DepthRepresentationInfo {
+	var cinfo *C.heif_depth_representation_info
+	if C.heif_image_handle_get_depth_image_representation_info((*C.heif_image_handle)(handle), C.heif_item_id(depthID), &cinfo) == 0 || cinfo == nil {
+		return nil
+	}
+	defer C.heif_depth_representation_info_free(cinfo)
+
+	return &DepthRepresentationInfo{
+		Type:                   DepthRepresentationType(cinfo.depth_representation_type),
+		HasZNear:               cinfo.has_z_near != 0,
+		HasZFar:                cinfo.has_z_far != 0,
+		HasDMin:                cinfo.has_d_min != 0,
+		HasDMax:                cinfo.has_d_max != 0,
+		ZNear:                  float64(cinfo.z_near),
+		ZFar:                   float64(cinfo.z_far),
+		DMin:                   float64(cinfo.d_min),
+		DMax:                   float64(cinfo.d_max),
+		DisparityReferenceView: int(cinfo.disparity_reference_view),
+	}
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 // BINDINGS - IMAGE METADATA
 
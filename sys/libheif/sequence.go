@@ -0,0 +1,141 @@
+package libheif
+
+////////////////////////////////////////////////////////////////////////////////
+// CGO
+
+/*
+#cgo pkg-config: --static libheif
+#include <stdlib.h>
+#include <libheif/heif_sequences.h>
+*/
+import "C"
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type (
+	Track     C.heif_track
+	TrackType uint32
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// CONSTS
+
+// Track handler types are the four-character codes of the track handler box.
+const (
+	HEIF_TRACK_TYPE_VIDEO          TrackType = 'v'<<24 | 'i'<<16 | 'd'<<8 | 'e'
+	HEIF_TRACK_TYPE_IMAGE_SEQUENCE TrackType = 'p'<<24 | 'i'<<16 | 'c'<<8 | 't'
+	HEIF_TRACK_TYPE_AUXILIARY      TrackType = 'a'<<24 | 'u'<<16 | 'x'<<8 | 'v'
+	HEIF_TRACK_TYPE_METADATA       TrackType = 'm'<<24 | 'e'<<16 | 't'<<8 | 'a'
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (t TrackType) String() string {
+	return string([]byte{byte(t >> 24), byte(t >> 16), byte(t >> 8), byte(t)})
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// BINDINGS - SEQUENCES
+
+func Libheif_context_has_sequence(ctx *Context) bool {
+	return C.heif_context_has_sequence((*C.heif_context)(ctx)) != 0
+}
+
+func Libheif_context_get_sequence_timescale(ctx *Context) uint32 {
+	return uint32(C.heif_context_get_sequence_timescale((*C.heif_context)(ctx)))
+}
+
+func Libheif_context_get_sequence_duration(ctx *Context) uint64 {
+	return uint64(C.heif_context_get_sequence_duration((*C.heif_context)(ctx)))
+}
+
+func Libheif_context_number_of_sequence_tracks(ctx *Context) int {
+	return int(C.heif_context_number_of_sequence_tracks((*C.heif_context)(ctx)))
+}
+
+func Libheif_context_get_track_ids(ctx *Context) []uint32 {
+	count := Libheif_context_number_of_sequence_tracks(ctx)
+	if count <= 0 {
+		return nil
+	}
+
+	ids := make([]C.uint32_t, count)
+	C.heif_context_get_track_ids((*C.heif_context)(ctx), &ids[0])
+
+	result := make([]uint32, count)
+	for i := range ids {
+		result[i] = uint32(ids[i])
+	}
+	return result
+}
+
+// Return a track by identifier, or the first visual track when id is zero.
+func Libheif_context_get_track(ctx *Context, id uint32) *Track {
+	track := C.heif_context_get_track((*C.heif_context)(ctx), C.uint32_t(id))
+	if track == nil {
+		return nil
+	}
+	return (*Track)(track)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// BINDINGS - TRACKS
+
+func Libheif_track_release(track *Track) {
+	if track == nil {
+		return
+	}
+	C.heif_track_release((*C.heif_track)(track))
+}
+
+func Libheif_track_get_id(track *Track) uint32 {
+	return uint32(C.heif_track_get_id((*C.heif_track)(track)))
+}
+
+func Libheif_track_get_track_handler_type(track *Track) TrackType {
+	return TrackType(C.heif_track_get_track_handler_type((*C.heif_track)(track)))
+}
+
+func Libheif_track_get_timescale(track *Track) uint32 {
+	return uint32(C.heif_track_get_timescale((*C.heif_track)(track)))
+}
+
+func Libheif_track_get_image_resolution(track *Track) (int, int, error) {
+	var width, height C.uint16_t
+	cerr := C.heif_track_get_image_resolution((*C.heif_track)(track), &width, &height)
+	err := fromCError(cerr)
+	if err.Code == HEIF_ERROR_OK {
+		return int(width), int(height), nil
+	}
+	return 0, 0, err
+}
+
+// Decode the next image of a visual track. Returns an error with code
+// HEIF_ERROR_END_OF_SEQUENCE once all images have been decoded.
+func Libheif_track_decode_next_image(track *Track, colorspace HeifColorspace, chroma HeifChroma, options *DecodingOptions) (*Image, error) {
+	var img *C.heif_image
+	var coptions *C.heif_decoding_options
+	if options != nil {
+		coptions = (*C.heif_decoding_options)(options)
+	}
+	cerr := C.heif_track_decode_next_image(
+		(*C.heif_track)(track),
+		&img,
+		C.heif_colorspace(colorspace),
+		C.heif_chroma(chroma),
+		coptions,
+	)
+	err := fromCError(cerr)
+	if err.Code == HEIF_ERROR_OK {
+		return (*Image)(img), nil
+	}
+	return nil, err
+}
+
+// Return the display duration of a decoded sequence image, in units of the
+// track timescale.
+func Libheif_image_get_duration(img *Image) uint32 {
+	return uint32(C.heif_image_get_duration((*C.heif_image)(img)))
+}
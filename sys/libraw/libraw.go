@@ -21,11 +21,24 @@ type (
 	ImageSizes      C.libraw_image_sizes_t
 	ImgOther        C.libraw_imgother_t
 	LensInfo        C.libraw_lensinfo_t
+	ShootingInfo    C.libraw_shootinginfo_t
 	Thumbnail       C.libraw_thumbnail_t
 	ImageFormat     C.enum_LibRaw_image_formats
 	ThumbnailFormat C.enum_LibRaw_thumbnail_formats
 )
 
+// GPSInfo is the GPS position parsed from the file. Latitude and longitude
+// are in degrees, minutes and seconds; the references are 'N'/'S', 'E'/'W'
+// and 0 (above) or 1 (below sea level).
+type GPSInfo struct {
+	Latitude     [3]float32
+	Longitude    [3]float32
+	Altitude     float32
+	LatitudeRef  byte
+	LongitudeRef byte
+	AltitudeRef  byte
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 // GLOBALS
 
@@ -72,6 +85,10 @@ func Libraw_get_lensinfo(data *Data) *LensInfo {
 	return (*LensInfo)(C.libraw_get_lensinfo((*C.libraw_data_t)(data)))
 }
 
+func Libraw_get_shootinginfo(data *Data) *ShootingInfo {
+	return (*ShootingInfo)(&(*C.libraw_data_t)(data).shootinginfo)
+}
+
 func Libraw_get_imgother(data *Data) *ImgOther {
 	return (*ImgOther)(C.libraw_get_imgother((*C.libraw_data_t)(data)))
 }
@@ -181,6 +198,85 @@ func ImgOther_artist(p *ImgOther) string {
 	return C.GoString(&(*C.libraw_imgother_t)(p).artist[0])
 }
 
+// Return the parsed GPS position, or false if the file carries none.
+func ImgOther_parsed_gps(p *ImgOther) (GPSInfo, bool) {
+	gps := &(*C.libraw_imgother_t)(p).parsed_gps
+	if gps.gpsparsed == 0 {
+		return GPSInfo{}, false
+	}
+	info := GPSInfo{
+		Altitude:     float32(gps.altitude),
+		LatitudeRef:  byte(gps.latref),
+		LongitudeRef: byte(gps.longref),
+		AltitudeRef:  byte(gps.altref),
+	}
+	for i := 0; i < 3; i++ {
+		info.Latitude[i] = float32(gps.latitude[i])
+		info.Longitude[i] = float32(gps.longitude[i])
+	}
+	return info, true
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// BINDINGS - LENSINFO FIELD ACCESSORS
+
+func LensInfo_min_focal(p *LensInfo) float32 {
+	return float32((*C.libraw_lensinfo_t)(p).MinFocal)
+}
+
+func LensInfo_max_focal(p *LensInfo) float32 {
+	return float32((*C.libraw_lensinfo_t)(p).MaxFocal)
+}
+
+func LensInfo_max_ap4_min_focal(p *LensInfo) float32 {
+	return float32((*C.libraw_lensinfo_t)(p).MaxAp4MinFocal)
+}
+
+func LensInfo_max_ap4_max_focal(p *LensInfo) float32 {
+	return float32((*C.libraw_lensinfo_t)(p).MaxAp4MaxFocal)
+}
+
+func LensInfo_exif_max_ap(p *LensInfo) float32 {
+	return float32((*C.libraw_lensinfo_t)(p).EXIF_MaxAp)
+}
+
+func LensInfo_lens_make(p *LensInfo) string {
+	return C.GoString(&(*C.libraw_lensinfo_t)(p).LensMake[0])
+}
+
+func LensInfo_lens(p *LensInfo) string {
+	return C.GoString(&(*C.libraw_lensinfo_t)(p).Lens[0])
+}
+
+func LensInfo_lens_serial(p *LensInfo) string {
+	return C.GoString(&(*C.libraw_lensinfo_t)(p).LensSerial[0])
+}
+
+func LensInfo_internal_lens_serial(p *LensInfo) string {
+	return C.GoString(&(*C.libraw_lensinfo_t)(p).InternalLensSerial[0])
+}
+
+func LensInfo_focal_length_in_35mm_format(p *LensInfo) int {
+	return int((*C.libraw_lensinfo_t)(p).FocalLengthIn35mmFormat)
+}
+
+// Return the lens model decoded from the maker notes, which is often present
+// when the EXIF lens model is not.
+func LensInfo_makernotes_lens(p *LensInfo) string {
+	return C.GoString(&(*C.libraw_lensinfo_t)(p).makernotes.Lens[0])
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// BINDINGS - SHOOTINGINFO FIELD ACCESSORS
+
+func ShootingInfo_body_serial(p *ShootingInfo) string {
+	return C.GoString(&(*C.libraw_shootinginfo_t)(p).BodySerial[0])
+}
+
+func ShootingInfo_internal_body_serial(p *ShootingInfo) string {
+	return C.GoString(&(*C.libraw_shootinginfo_t)(p).InternalBodySerial[0])
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 // BINDINGS - THUMBNAIL FIELD ACCESSORS
 
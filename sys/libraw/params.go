@@ -56,6 +56,43 @@ func Libraw_set_fbdd_noiserd(data *Data, value int) {
 	C.libraw_set_fbdd_noiserd((*C.libraw_data_t)(data), C.int(value))
 }
 
+////////////////////////////////////////////////////////////////////////////////
+// BINDINGS - OUTPUT PARAM FIELDS
+//
+// LibRaw has no C API setters for these, so the output parameters are
+// written directly.
+
+func Libraw_set_half_size(data *Data, value bool) {
+	(*C.libraw_data_t)(data).params.half_size = boolToCInt(value)
+}
+
+func Libraw_set_use_camera_wb(data *Data, value bool) {
+	(*C.libraw_data_t)(data).params.use_camera_wb = boolToCInt(value)
+}
+
+func Libraw_set_use_auto_wb(data *Data, value bool) {
+	(*C.libraw_data_t)(data).params.use_auto_wb = boolToCInt(value)
+}
+
+func Libraw_set_use_camera_matrix(data *Data, value int) {
+	(*C.libraw_data_t)(data).params.use_camera_matrix = C.int(value)
+}
+
+// Set linear exposure correction before demosaicing. shift is a linear
+// multiplier between 0.25 (-2EV) and 8.0 (+3EV); preserve is the amount of
+// highlight preservation between 0.0 and 1.0. A shift of 1.0 disables the
+// correction.
+func Libraw_set_exposure(data *Data, shift, preserve float32) {
+	params := &(*C.libraw_data_t)(data).params
+	if shift == 1.0 {
+		params.exp_correc = 0
+	} else {
+		params.exp_correc = 1
+	}
+	params.exp_shift = C.float(shift)
+	params.exp_preser = C.float(preserve)
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 // BINDINGS - COLOR GETTERS
 
@@ -74,3 +111,13 @@ func Libraw_get_rgb_cam(data *Data, index1, index2 int) float32 {
 func Libraw_get_color_maximum(data *Data) int {
 	return int(C.libraw_get_color_maximum((*C.libraw_data_t)(data)))
 }
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE
+
+func boolToCInt(value bool) C.int {
+	if value {
+		return 1
+	}
+	return 0
+}